@@ -2,11 +2,21 @@ package baseapp
 
 import (
 	"context"
+	"encoding/hex"
+	"time"
 
 	storetypes "cosmossdk.io/store/types"
+	"github.com/0xPellNetwork/pelldvs-libs/log"
 	avsitypes "github.com/0xPellNetwork/pelldvs/avsi/types"
+	pelldvstypes "github.com/0xPellNetwork/pelldvs/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/hashicorp/go-metrics"
 	"github.com/jinzhu/copier" //nolint:depguard
+	"go.opentelemetry.io/otel/attribute"
 
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+	"github.com/0xPellNetwork/pellapp-sdk/telemetry"
 	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
 )
 
@@ -29,19 +39,67 @@ func (app *BaseApp) Query(ctx context.Context, req *avsitypes.RequestQuery) (res
 // ProcessDVSRequest processes a DVS (Distributed Validation System) request.
 // It creates an SDK context with request data and invokes the appropriate request handler.
 // Returns the handler's response or an error response if processing fails.
-func (app *BaseApp) ProcessDVSRequest(ctx context.Context, req *avsitypes.RequestProcessDVSRequest) (*avsitypes.ResponseProcessDVSRequest, error) {
-	sdkCtx := sdktypes.NewContext(ctx, app.cms, app.logger)
+// When the determinism check is enabled, the request is first simulated and
+// any divergence between both runs is logged.
+func (app *BaseApp) ProcessDVSRequest(ctx context.Context, req *avsitypes.RequestProcessDVSRequest) (resp *avsitypes.ResponseProcessDVSRequest, err error) {
+	defer func(start time.Time) {
+		measureAVSI(telemetry.MetricKeyProcessDVSRequest, start, resp.GetCodespace(), resp.GetCode())
+	}(telemetry.Now())
+
+	ctx, span := telemetry.StartSpan(ctx, "avsi.ProcessDVSRequest", dvsRequestAttributes(req.Request)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if !app.beginRequest() {
+		resp = &avsitypes.ResponseProcessDVSRequest{}
+		_ = copier.Copy(resp, sdktypes.WarpAvsiBaseError(sdkerrors.ErrShuttingDown, nil, app.trace))
+		return resp, sdkerrors.ErrShuttingDown
+	}
+	defer app.endRequest()
+
+	if !app.checkDeterminism {
+		return app.processDVSRequest(ctx, app.cms, req)
+	}
+
+	simResp, simWrites, simErr := app.SimulateDVSRequest(ctx, req)
+
+	ms := newWriteSetStore(app.cms)
+	resp, err = app.processDVSRequest(ctx, ms, req)
+	ms.Write()
+
+	if divergence := compareDVSRuns(
+		dvsRun{resp: simResp, writes: simWrites, err: simErr},
+		dvsRun{resp: resp, writes: ms.writes, err: err},
+	); divergence != nil {
+		app.logger.Error("nondeterministic request", "height", req.Request.Height, "err", divergence)
+	}
+
+	return resp, err
+}
+
+// SimulateDVSRequest processes a DVS request on a branch of the application
+// state and discards it, returning the response along with the writes made
+// by the handler. Writes that bypass the sdk context, e.g. through
+// CommitMultiStore, are neither recorded nor discarded.
+func (app *BaseApp) SimulateDVSRequest(ctx context.Context, req *avsitypes.RequestProcessDVSRequest) (*avsitypes.ResponseProcessDVSRequest, WriteSet, error) {
+	ms := newWriteSetStore(app.cms)
+	resp, err := app.processDVSRequest(ctx, ms, req)
+	return resp, ms.writes, err
+}
+
+func (app *BaseApp) processDVSRequest(ctx context.Context, ms storetypes.MultiStore, req *avsitypes.RequestProcessDVSRequest) (*avsitypes.ResponseProcessDVSRequest, error) {
+	sdkCtx := sdktypes.NewContext(ctx, ms, app.requestLogger(req.Request, req.Operator))
 	sdkCtx = sdkCtx.WithChainID(req.Request.ChainId).
 		WithHeight(req.Request.Height).
 		WithGroupNumbers(req.Request.GroupNumbers).
 		WithRequestData(req.Request.Data).
 		WithGroupThresholdPercentages(req.Request.GroupThresholdPercentages).
-		WithOperator(req.Operator)
+		WithOperator(req.Operator).
+		WithMsgDispatcher(app.MsgDispatcher())
 
 	resp := &avsitypes.ResponseProcessDVSRequest{}
-	res, err := app.msgRouter.InvokeByMsgData(sdkCtx, req.Request.Data)
+	res, err := app.runMsg(sdkCtx, req.Request.Data)
 	if err != nil {
-		app.logger.Error("process request error", "err", err)
+		sdkCtx.Logger().Error("process request error", "err", err)
 
 		_ = copier.Copy(resp, sdktypes.WarpAvsiBaseError(err, res, app.trace))
 		return resp, err
@@ -49,7 +107,7 @@ func (app *BaseApp) ProcessDVSRequest(ctx context.Context, req *avsitypes.Reques
 
 	return &avsitypes.ResponseProcessDVSRequest{
 		Log:            res.Log,
-		Events:         sdktypes.MarkEventsToIndex(res.Events, app.indexEvents),
+		Events:         sdktypes.MarkEventsToIndex(res.Events, app.getIndexEvents()),
 		Response:       res.CustomData,
 		ResponseDigest: res.CustomDigest,
 	}, nil
@@ -58,19 +116,34 @@ func (app *BaseApp) ProcessDVSRequest(ctx context.Context, req *avsitypes.Reques
 // ProcessDVSResponse processes a DVS response after validators have processed a request.
 // It creates an SDK context with the original request data and validated response,
 // then invokes the appropriate response handler.
-func (app *BaseApp) ProcessDVSResponse(ctx context.Context, req *avsitypes.RequestProcessDVSResponse) (*avsitypes.ResponseProcessDVSResponse, error) {
-	sdkCtx := sdktypes.NewContext(ctx, app.cms, app.logger)
+func (app *BaseApp) ProcessDVSResponse(ctx context.Context, req *avsitypes.RequestProcessDVSResponse) (resp *avsitypes.ResponseProcessDVSResponse, err error) {
+	defer func(start time.Time) {
+		measureAVSI(telemetry.MetricKeyProcessDVSResponse, start, resp.GetCodespace(), resp.GetCode())
+	}(telemetry.Now())
+
+	ctx, span := telemetry.StartSpan(ctx, "avsi.ProcessDVSResponse", dvsRequestAttributes(req.DvsRequest)...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if !app.beginRequest() {
+		resp = &avsitypes.ResponseProcessDVSResponse{}
+		_ = copier.Copy(resp, sdktypes.WarpAvsiBaseError(sdkerrors.ErrShuttingDown, nil, app.trace))
+		return resp, sdkerrors.ErrShuttingDown
+	}
+	defer app.endRequest()
+
+	sdkCtx := sdktypes.NewContext(ctx, app.cms, app.requestLogger(req.DvsRequest, nil))
 	sdkCtx = sdkCtx.WithChainID(req.DvsRequest.ChainId).
 		WithHeight(req.DvsRequest.Height).
 		WithGroupNumbers(req.DvsRequest.GroupNumbers).
 		WithRequestData(req.DvsRequest.Data).
 		WithGroupThresholdPercentages(req.DvsRequest.GroupThresholdPercentages).
-		WithValidatedResponse(req.DvsResponse)
+		WithValidatedResponse(req.DvsResponse).
+		WithMsgDispatcher(app.MsgDispatcher())
 
-	resp := &avsitypes.ResponseProcessDVSResponse{}
-	res, err := app.msgRouter.InvokeByMsgData(sdkCtx, req.DvsRequest.Data)
+	resp = &avsitypes.ResponseProcessDVSResponse{}
+	res, err := app.runMsg(sdkCtx, req.DvsRequest.Data)
 	if err != nil {
-		app.logger.Error("post request error", "err", err)
+		sdkCtx.Logger().Error("post request error", "err", err)
 
 		_ = copier.Copy(resp, sdktypes.WarpAvsiBaseError(err, res, app.trace))
 		return resp, err
@@ -79,10 +152,89 @@ func (app *BaseApp) ProcessDVSResponse(ctx context.Context, req *avsitypes.Reque
 	return &avsitypes.ResponseProcessDVSResponse{
 		Data:   res.CustomData,
 		Log:    res.Log,
-		Events: sdktypes.MarkEventsToIndex(res.Events, app.indexEvents),
+		Events: sdktypes.MarkEventsToIndex(res.Events, app.getIndexEvents()),
 	}, nil
 }
 
+// runMsg runs the ante handler, if any, on the message of data that is routed
+// then invokes its handler. The data is decoded once, for both.
+func (app *BaseApp) runMsg(ctx sdktypes.Context, data []byte) (*sdktypes.AvsiResult, error) {
+	if app.anteHandler == nil {
+		return app.msgRouter.InvokeByMsgData(ctx, data)
+	}
+
+	msgs, err := app.msgRouter.DecodeMsgs(data)
+	if err != nil {
+		return nil, err
+	}
+	// the ante handler checks the message the handler gets, whatever the
+	// messages before it
+	msg, found := app.msgRouter.RoutedMsg(ctx, msgs)
+	if !found {
+		return app.msgRouter.InvokeByMsgs(ctx, msgs)
+	}
+
+	goCtx := ctx.Context()
+	spanCtx, span := telemetry.StartSpan(goCtx, "ante",
+		attribute.String(telemetry.MetricLabelMsgType, sdk.MsgTypeURL(msg)))
+	ctx, err = app.anteHandler(ctx.WithContext(spanCtx), msg)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		telemetry.IncrCounterWithLabels(
+			[]string{telemetry.MetricKeyAVSI, telemetry.MetricKeyAnteFailure},
+			1,
+			[]metrics.Label{telemetry.NewLabel(telemetry.MetricLabelMsgType, sdk.MsgTypeURL(msg))},
+		)
+		return nil, err
+	}
+	// the handler is not a child of the ante span, unless the ante handler
+	// derived a new context from it
+	if ctx.Context() == spanCtx {
+		ctx = ctx.WithContext(goCtx)
+	}
+
+	return app.msgRouter.InvokeByMsgs(ctx, []sdk.Msg{msg})
+}
+
+// requestLogger returns the app logger with the fields identifying a DVS
+// request and the operators processing it, if any
+func (app *BaseApp) requestLogger(req *avsitypes.DVSRequest, operators []*avsitypes.Operator) log.Logger {
+	keyVals := []any{
+		sdktypes.LogKeyChainID, req.GetChainId(),
+		sdktypes.LogKeyHeight, req.GetHeight(),
+	}
+	// the hash the node indexes the request by
+	if bz, err := proto.Marshal(req); err == nil {
+		keyVals = append(keyVals, sdktypes.LogKeyRequestHash, hex.EncodeToString(pelldvstypes.DvsRequest(bz).Hash()))
+	}
+	if len(operators) > 0 {
+		addresses := make([]string, len(operators))
+		for i, operator := range operators {
+			addresses[i] = hex.EncodeToString(operator.Address)
+		}
+		keyVals = append(keyVals, sdktypes.LogKeyOperators, addresses)
+	}
+	return app.logger.With(keyVals...)
+}
+
+// dvsRequestAttributes returns the span attributes identifying a DVS request
+func dvsRequestAttributes(req *avsitypes.DVSRequest) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int64("chain_id", req.GetChainId()),
+		attribute.Int64("height", req.GetHeight()),
+	}
+}
+
+// measureAVSI emits the latency and outcome of an AVSI method
+func measureAVSI(method string, start time.Time, codespace string, code uint32) {
+	telemetry.MeasureSince(start, telemetry.MetricKeyAVSI, method)
+	telemetry.IncrCounterWithLabels(
+		[]string{telemetry.MetricKeyAVSI, method, telemetry.MetricKeyResult},
+		1,
+		telemetry.ResultLabels(codespace, code),
+	)
+}
+
 // createQueryContext creates a new sdk.Context for a query, taking as args
 // the block height and whether the query needs a proof or not.
 func (app *BaseApp) CreateQueryContext() (sdktypes.Context, error) {
@@ -0,0 +1,202 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	avsitypes "github.com/0xPellNetwork/pelldvs/avsi/types"
+	pelldvstypes "github.com/0xPellNetwork/pelldvs/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
+	"github.com/0xPellNetwork/pellapp-sdk/service/tx"
+	"github.com/0xPellNetwork/pellapp-sdk/telemetry"
+	"github.com/0xPellNetwork/pellapp-sdk/testutil/tasktest"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+func TestProcessDVSRequestAnteHandler(t *testing.T) {
+	app := setupTaskApp(t, log.NewLogger(&bytes.Buffer{}), &taskService{})
+
+	var seen any
+	app.SetAnteHandler(func(ctx sdktypes.Context, msg any) (sdktypes.Context, error) {
+		seen = msg
+		assert.Equal(t, app.MsgDispatcher(), ctx.MsgDispatcher())
+		if msg.(*testpb.TestCallMsg).TaskId == 7 {
+			return ctx, sdkerrors.ErrPanic
+		}
+		return ctx, nil
+	})
+
+	resp, err := app.ProcessDVSRequest(context.Background(), taskRequest(t))
+	assert.ErrorIs(t, err, sdkerrors.ErrPanic)
+	assert.Equal(t, sdkerrors.ErrPanic.AVSICode(), resp.Code)
+	assert.Equal(t, []byte("payload"), seen.(*testpb.TestCallMsg).Payload)
+
+	// the handler is not invoked
+	assert.Nil(t, app.CommitMultiStore().GetKVStore(taskStoreKey).Get([]byte("task/7")))
+}
+
+// countingEncoder counts the request data it decodes
+type countingEncoder struct {
+	tx.MsgEncoder
+	decodes int
+}
+
+func (e *countingEncoder) Decode(data []byte) (sdk.Tx, error) {
+	e.decodes++
+	return e.MsgEncoder.Decode(data)
+}
+
+func TestProcessDVSRequestAnteHandlerDecodesOnce(t *testing.T) {
+	app := setupTaskApp(t, log.NewLogger(&bytes.Buffer{}), &taskService{})
+	encoder := &countingEncoder{MsgEncoder: tasktest.Coder(t)}
+	app.SetMsgEncoder(encoder)
+	app.SetAnteHandler(func(ctx sdktypes.Context, _ any) (sdktypes.Context, error) {
+		return ctx, nil
+	})
+
+	_, err := app.ProcessDVSRequest(context.Background(), taskRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, 1, encoder.decodes)
+}
+
+// unroutableTx carries an unroutable message before the messages of a task
+type unroutableTx struct {
+	sdk.Tx
+}
+
+func (tx unroutableTx) GetMsgs() []sdk.Msg {
+	return append([]sdk.Msg{&testpb.TestMsg{}}, tx.Tx.GetMsgs()...)
+}
+
+// unroutableEncoder decodes the data of a task into an unroutableTx
+type unroutableEncoder struct {
+	tx.MsgEncoder
+}
+
+func (e unroutableEncoder) Decode(data []byte) (sdk.Tx, error) {
+	decoded, err := e.MsgEncoder.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return unroutableTx{decoded}, nil
+}
+
+func TestProcessDVSRequestAnteHandlerRoutedMsg(t *testing.T) {
+	app := setupTaskApp(t, log.NewLogger(&bytes.Buffer{}), &taskService{})
+	app.SetMsgEncoder(unroutableEncoder{tasktest.Coder(t)})
+
+	var seen []any
+	app.SetAnteHandler(func(ctx sdktypes.Context, msg any) (sdktypes.Context, error) {
+		seen = append(seen, msg)
+		return ctx, sdkerrors.ErrPanic
+	})
+
+	// the ante handler checks the message that is routed, not the first one
+	_, err := app.ProcessDVSRequest(context.Background(), taskRequest(t))
+	assert.ErrorIs(t, err, sdkerrors.ErrPanic)
+	require.Len(t, seen, 1)
+	assert.IsType(t, &testpb.TestCallMsg{}, seen[0])
+	assert.Nil(t, app.CommitMultiStore().GetKVStore(taskStoreKey).Get([]byte("task/7")))
+}
+
+// taskResponseService stores task payloads and validates their responses
+type taskResponseService struct {
+	taskService
+}
+
+func (s *taskResponseService) SubmitTaskDVSResponsHandler(context.Context, *testpb.TestCallMsg) (*testpb.TestMsg, error) {
+	return &testpb.TestMsg{}, nil
+}
+
+func TestProcessDVSRequestPanic(t *testing.T) {
+	app := setupTaskApp(t, log.NewLogger(&bytes.Buffer{}), &taskResponseService{})
+	app.SetAnteHandler(func(sdktypes.Context, any) (sdktypes.Context, error) {
+		panic("ante panic")
+	})
+
+	// the panic is not replaced by one of the telemetry of the nil response
+	require.PanicsWithValue(t, "ante panic", func() {
+		_, _ = app.ProcessDVSRequest(context.Background(), taskRequest(t))
+	})
+	require.PanicsWithValue(t, "ante panic", func() {
+		_, _ = app.ProcessDVSResponse(context.Background(), &avsitypes.RequestProcessDVSResponse{
+			DvsRequest:  taskRequest(t).Request,
+			DvsResponse: &avsitypes.DVSResponse{},
+		})
+	})
+}
+
+func TestProcessDVSRequestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	telemetry.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	app := setupTaskApp(t, log.NewLogger(&bytes.Buffer{}), &taskService{})
+	app.SetAnteHandler(func(ctx sdktypes.Context, _ any) (sdktypes.Context, error) {
+		return ctx, nil
+	})
+
+	_, err := app.ProcessDVSRequest(context.Background(), taskRequest(t))
+	require.NoError(t, err)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		if _, ok := spans[span.Name()]; !ok {
+			spans[span.Name()] = span
+		}
+	}
+	root := spans["avsi.ProcessDVSRequest"]
+	require.NotNil(t, root)
+	assert.Contains(t, root.Attributes(), attribute.Int64("height", 1))
+
+	// the ante handler and the routing are children of the request, the
+	// handler of the routing and store operations of the handler
+	for child, parent := range map[string]string{
+		"ante":        "avsi.ProcessDVSRequest",
+		"msg.route":   "avsi.ProcessDVSRequest",
+		"msg.handler": "msg.route",
+		"store.Set":   "msg.handler",
+	} {
+		require.Contains(t, spans, child)
+		assert.Equal(t, spans[parent].SpanContext().SpanID(), spans[child].Parent().SpanID(), child)
+	}
+}
+
+func TestProcessDVSRequestLogger(t *testing.T) {
+	var logs bytes.Buffer
+	app := setupTaskApp(t, log.NewLogger(&logs, log.OutputJSONOption()), &taskService{})
+
+	req := taskRequest(t)
+	req.Operator = []*avsitypes.Operator{{Address: []byte{0xab, 0xcd}}}
+	_, err := app.ProcessDVSRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	bz, err := proto.Marshal(req.Request)
+	require.NoError(t, err)
+
+	var entry map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n")) {
+		require.NoError(t, json.Unmarshal(line, &entry))
+		if entry["message"] == "task submitted" {
+			break
+		}
+	}
+	require.Equal(t, "task submitted", entry["message"])
+	assert.EqualValues(t, 1337, entry[sdktypes.LogKeyChainID])
+	assert.EqualValues(t, 1, entry[sdktypes.LogKeyHeight])
+	assert.Equal(t, hex.EncodeToString(pelldvstypes.DvsRequest(bz).Hash()), entry[sdktypes.LogKeyRequestHash])
+	assert.Equal(t, []any{"abcd"}, entry[sdktypes.LogKeyOperators])
+	assert.Equal(t, sdk.MsgTypeURL(&testpb.TestCallMsg{}), entry[sdktypes.LogKeyMsgType])
+}
@@ -1,11 +1,12 @@
 package baseapp
 
 import (
-	"errors"
+	"maps"
+	"slices"
+	"sync"
 
 	cosmoslog "cosmossdk.io/log"
 	"cosmossdk.io/store"
-	storemetrics "cosmossdk.io/store/metrics"
 	storetypes "cosmossdk.io/store/types"
 	"github.com/0xPellNetwork/pelldvs-libs/log"
 	dbm "github.com/cosmos/cosmos-db"
@@ -13,6 +14,8 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/0xPellNetwork/pellapp-sdk/service"
+	"github.com/0xPellNetwork/pellapp-sdk/service/tx"
+	"github.com/0xPellNetwork/pellapp-sdk/telemetry"
 	"github.com/0xPellNetwork/pellapp-sdk/types"
 )
 
@@ -37,6 +40,7 @@ type BaseApp struct {
 	trace bool
 
 	db          dbm.DB                      // common DB backend
+	cdc         codec.Codec                 // codec of the genesis states of the modules
 	cms         storetypes.CommitMultiStore // Main (uncached) state
 	qms         storetypes.MultiStore       // Optional alternative multistore for querying only.
 	storeLoader StoreLoader                 // function to handle store loading, may be overridden with SetStoreLoader()
@@ -45,12 +49,27 @@ type BaseApp struct {
 	sealed bool
 	// indexEvents defines the set of events in the form {eventType}.{attributeKey},
 	// which informs PellDVS what to index. If empty, all events will be indexed.
-	indexEvents map[string]struct{}
+	// It is replaced, never modified, under indexEventsMtx, see ReloadIndexEvents.
+	indexEventsMtx sync.RWMutex
+	indexEvents    map[string]struct{}
 	// handlers for DVS services
 	msgRouter       *service.MsgRouter
-	grpcQueryRouter *GRPCQueryRouter // router for redirecting gRPC query calls
+	grpcQueryRouter *GRPCQueryRouter     // router for redirecting gRPC query calls
+	moduleManager   *types.ModuleManager // modules registering their gRPC gateway routes
 
 	anteHandler types.AnteHandler
+
+	// checkDeterminism runs every DVS request twice and logs divergent results
+	checkDeterminism bool
+
+	// shutdown state, see Drain and Close
+	shutdownMtx sync.Mutex
+	draining    bool
+	inFlight    sync.WaitGroup
+	requests    int // requests in flight, see beginRequest
+	closers     []namedCloser
+	closeOnce   sync.Once
+	closeErr    error
 }
 
 // NewBaseApp creates and initializes a new BaseApp instance with the provided parameters.
@@ -66,8 +85,10 @@ func NewBaseApp(
 	app := &BaseApp{
 		name:        name,
 		logger:      logger,
+		db:          db,
+		cdc:         cdc,
 		msgRouter:   service.NewMsgRouter(cdc),
-		cms:         store.NewCommitMultiStore(db, clogger, storemetrics.NewNoOpMetrics()), // by default we use a no-op metric gather in store
+		cms:         store.NewCommitMultiStore(db, clogger, telemetry.NewStoreMetrics()), // no-op metric gatherer unless telemetry is enabled
 		storeLoader: DefaultStoreLoader,
 	}
 
@@ -79,10 +100,98 @@ func NewBaseApp(
 	return app
 }
 
+// Name returns the name of the BaseApp.
+func (app *BaseApp) Name() string {
+	return app.name
+}
+
+// Version returns the application's version string.
+func (app *BaseApp) Version() string {
+	return app.version
+}
+
+// SetVersion sets the application's version string.
+func (app *BaseApp) SetVersion(v string) {
+	if app.sealed {
+		panic("Cannot call SetVersion: baseapp already sealed")
+	}
+
+	app.version = v
+}
+
+// SetIndexEvents sets the events in the form {eventType}.{attributeKey} that
+// PellDVS indexes. All events are indexed when empty.
+func (app *BaseApp) SetIndexEvents(ie []string) {
+	if app.sealed {
+		panic("Cannot call SetIndexEvents: baseapp already sealed")
+	}
+
+	app.setIndexEvents(ie)
+}
+
+func (app *BaseApp) setIndexEvents(ie []string) {
+	indexEvents := make(map[string]struct{}, len(ie))
+	for _, e := range ie {
+		indexEvents[e] = struct{}{}
+	}
+
+	app.indexEventsMtx.Lock()
+	defer app.indexEventsMtx.Unlock()
+	app.indexEvents = indexEvents
+}
+
+// getIndexEvents returns the set of the events indexed by PellDVS, which must
+// not be modified.
+func (app *BaseApp) getIndexEvents() map[string]struct{} {
+	app.indexEventsMtx.RLock()
+	defer app.indexEventsMtx.RUnlock()
+	return app.indexEvents
+}
+
+// IndexEvents returns the sorted events indexed by PellDVS, all events when empty.
+func (app *BaseApp) IndexEvents() []string {
+	return slices.Sorted(maps.Keys(app.getIndexEvents()))
+}
+
+// ReloadIndexEvents replaces the events indexed by PellDVS while the
+// application runs, unlike SetIndexEvents which is refused once sealed.
+func (app *BaseApp) ReloadIndexEvents(ie []string) {
+	app.setIndexEvents(ie)
+	app.logger.Info("reloaded index events", "index-events", ie)
+}
+
+// MsgHandlerMethods returns the full service method names of the registered
+// message handlers, by message type URL, see service.MsgRouterMgr.HandlerMethods.
+func (app *BaseApp) MsgHandlerMethods() map[string]string {
+	return app.msgRouter.GetConfigurator().Router.HandlerMethods()
+}
+
 func (app *BaseApp) GetMsgRouter() *service.MsgRouter {
 	return app.msgRouter
 }
 
+// MsgDispatcher returns the dispatcher executing DVS messages of other
+// modules from within handlers, bound to no authority. It is set on the
+// context of handlers, see types.Context.MsgDispatcher, and refuses messages
+// implementing types.HasAuthority.
+func (app *BaseApp) MsgDispatcher() types.MsgDispatcher {
+	return app.msgRouter.GetMsgDispatcher()
+}
+
+// ModuleMsgDispatcher returns the dispatcher executing DVS messages of other
+// modules on behalf of module, whose name is its authority, to be injected in
+// its keeper at construction.
+func (app *BaseApp) ModuleMsgDispatcher(module string) types.MsgDispatcher {
+	if module == "" {
+		panic("module name cannot be empty")
+	}
+	return app.msgRouter.GetMsgDispatcher().ForAuthority(module)
+}
+
+// SetAnteHandler sets the handler run on the message of every DVS request and
+// response before its handler, e.g. to authenticate or validate it. An error
+// of the ante handler fails the request or response without running the
+// handler. Dispatched messages do not run through the ante handler.
 func (app *BaseApp) SetAnteHandler(ah types.AnteHandler) {
 	if app.sealed {
 		panic("Cannot call SetAnteHandler: baseapp already sealed")
@@ -91,6 +200,62 @@ func (app *BaseApp) SetAnteHandler(ah types.AnteHandler) {
 	app.anteHandler = ah
 }
 
+// SetMsgEncoder sets the encoder used to decode DVS request data, e.g. an
+// abicodec.Coder for requests carrying contract calldata.
+func (app *BaseApp) SetMsgEncoder(encoder tx.MsgEncoder) {
+	if app.sealed {
+		panic("Cannot call SetMsgEncoder: baseapp already sealed")
+	}
+
+	app.msgRouter.SetEncoder(encoder)
+}
+
+// SetDefaultResultExtractor sets the extractor used for DVS results whose type
+// has no registered extractor, e.g. extractor.NewSha256().
+func (app *BaseApp) SetDefaultResultExtractor(extractor types.ResultMsgExtractor) {
+	if app.sealed {
+		panic("Cannot call SetDefaultResultExtractor: baseapp already sealed")
+	}
+
+	app.msgRouter.GetConfigurator().ResultManager.SetDefaultHandler(extractor)
+}
+
+// SetDeterminismCheck enables a diagnostics mode where every DVS request is
+// simulated before being processed, and any difference between both runs in
+// result data, digest, events or store writes is logged. Handlers run twice,
+// so it should not be enabled for handlers with external side effects.
+func (app *BaseApp) SetDeterminismCheck(enabled bool) {
+	if app.sealed {
+		panic("Cannot call SetDeterminismCheck: baseapp already sealed")
+	}
+
+	app.checkDeterminism = enabled
+}
+
+// SetModuleManager sets the module manager of the application, whose modules
+// register their gRPC gateway routes on the API server, and whose result
+// extractors are validated on start.
+func (app *BaseApp) SetModuleManager(mm *types.ModuleManager) {
+	if app.sealed {
+		panic("Cannot call SetModuleManager: baseapp already sealed")
+	}
+
+	app.moduleManager = mm
+}
+
+// ValidateResultExtractors checks that the response of every request handler
+// registered by the modules of the module manager, if set, has a result
+// extractor or explicitly opted out, see
+// ModuleManager.ValidateResultExtractors. It is called on start, so that
+// operators never sign responses with an empty digest.
+func (app *BaseApp) ValidateResultExtractors() error {
+	if app.moduleManager == nil {
+		return nil
+	}
+
+	return app.moduleManager.ValidateResultExtractors(app.msgRouter.GetConfigurator())
+}
+
 func (app *BaseApp) Sealed() {
 	if app.sealed {
 		panic("Cannot call SetAnteHandler: baseapp already sealed")
@@ -107,7 +272,7 @@ func (app *BaseApp) SetQueryMultiStore(ms storetypes.MultiStore) {
 // MountStore mounts a store to the provided key in the BaseApp multistore,
 // using the default DB.
 func (app *BaseApp) MountStore(key storetypes.StoreKey, typ storetypes.StoreType) {
-	app.cms.MountStoreWithDB(key, typ, app.db)
+	app.cms.MountStoreWithDB(key, typ, nil)
 }
 
 // LastCommitID returns the last CommitID of the multistore.
@@ -160,18 +325,3 @@ func (app *BaseApp) GRPCQueryRouter() *GRPCQueryRouter { return app.grpcQueryRou
 func (app *BaseApp) SetGRPCQueryRouter(grpcQueryRouter *GRPCQueryRouter) {
 	app.grpcQueryRouter = grpcQueryRouter
 }
-
-// Close is called in start cmd to gracefully cleanup resources.
-func (app *BaseApp) Close() error {
-	var errs []error
-
-	// Close app.db (opened by cosmos-sdk/server/start.go call to openDB)
-	if app.db != nil {
-		app.logger.Info("Closing application.db")
-		if err := app.db.Close(); err != nil {
-			errs = append(errs, err)
-		}
-	}
-
-	return errors.Join(errs...)
-}
@@ -12,6 +12,10 @@ import (
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/service/extractor"
+	"github.com/0xPellNetwork/pellapp-sdk/testutil/tasktest"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
 )
 
 func setupBaseApp(t *testing.T) *BaseApp {
@@ -74,6 +78,42 @@ func TestBaseAppSeal(t *testing.T) {
 	})
 }
 
+// taskModule registers the task service
+type taskModule struct {
+	sdktypes.BasicModule
+}
+
+func (taskModule) Name() string { return "task" }
+
+func (taskModule) RegisterServices(c sdktypes.Configurator) {
+	c.RegisterService(tasktest.ServiceDesc, &taskService{})
+}
+
+func TestBaseAppValidateResultExtractors(t *testing.T) {
+	app := setupBaseApp(t)
+	require.NoError(t, app.ValidateResultExtractors())
+
+	mm := sdktypes.NewManager(taskModule{})
+	mm.RegisterServices(app.GetMsgRouter().GetConfigurator())
+	app.SetModuleManager(mm)
+	require.ErrorContains(t, app.ValidateResultExtractors(), "module task: no result extractor for /test.service.TestCallResult")
+
+	app.SetDefaultResultExtractor(extractor.NewSha256())
+	require.NoError(t, app.ValidateResultExtractors())
+}
+
+func TestBaseAppReloadIndexEvents(t *testing.T) {
+	logger := log.NewLogger(os.Stdout)
+	app := NewBaseApp("test", logger, dbm.NewMemDB(), nil, SetIndexEvents([]string{"task.id"}))
+	app.Sealed()
+	require.Equal(t, []string{"task.id"}, app.IndexEvents())
+
+	// the index events are reloaded once sealed
+	app.ReloadIndexEvents([]string{"task.id", "message.action"})
+	require.Equal(t, []string{"message.action", "task.id"}, app.IndexEvents())
+	require.Panics(t, func() { app.SetIndexEvents(nil) })
+}
+
 func TestBaseAppStoreOperations(t *testing.T) {
 	app := setupBaseApp(t)
 
@@ -0,0 +1,168 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	avsitypes "github.com/0xPellNetwork/pelldvs/avsi/types"
+)
+
+// Fields of a DVS request result compared by CheckDeterminism.
+const (
+	DivergentCode     = "code"
+	DivergentData     = "data"
+	DivergentDigest   = "digest"
+	DivergentEvents   = "events"
+	DivergentWriteSet = "writeset"
+)
+
+// DivergenceError describes the first difference found between two runs of
+// the same DVS request. Fields are compared in the order code, data, digest,
+// events then writeset, and store writes by store name then key.
+type DivergenceError struct {
+	// Field is the first divergent field of the result, one of the Divergent constants
+	Field string
+	// Index is the index of the first divergent event
+	Index int
+	// Store and Key locate the first divergent write
+	Store string
+	Key   []byte
+	// A and B are the divergent values of the first and second run
+	A, B any
+}
+
+func (e *DivergenceError) Error() string {
+	switch e.Field {
+	case DivergentEvents:
+		return fmt.Sprintf("nondeterministic %s: event %d is %v and %v", e.Field, e.Index, e.A, e.B)
+	case DivergentWriteSet:
+		return fmt.Sprintf("nondeterministic %s: store %s key %X is %s and %s", e.Field, e.Store, e.Key, e.A, e.B)
+	case DivergentData, DivergentDigest:
+		return fmt.Sprintf("nondeterministic %s: %X and %X", e.Field, e.A, e.B)
+	default:
+		return fmt.Sprintf("nondeterministic %s: %v and %v", e.Field, e.A, e.B)
+	}
+}
+
+// CheckDeterminism simulates req on every app, or twice when a single app is
+// given, and returns a *DivergenceError for the first run whose result data,
+// digest, events or store writes differ from the first one. Apps are
+// expected to start from the same state; none of them is modified.
+func CheckDeterminism(ctx context.Context, req *avsitypes.RequestProcessDVSRequest, apps ...*BaseApp) error {
+	if len(apps) == 0 {
+		return errors.New("no application to check")
+	}
+	if len(apps) == 1 {
+		apps = append(apps, apps[0])
+	}
+
+	var first dvsRun
+	for i, app := range apps {
+		resp, writes, err := app.SimulateDVSRequest(ctx, req)
+		run := dvsRun{resp: resp, writes: writes, err: err}
+		if i == 0 {
+			first = run
+			continue
+		}
+		if divergence := compareDVSRuns(first, run); divergence != nil {
+			return divergence
+		}
+	}
+	return nil
+}
+
+// dvsRun is the outcome of processing a DVS request
+type dvsRun struct {
+	resp   *avsitypes.ResponseProcessDVSRequest
+	writes WriteSet
+	err    error
+}
+
+// compareDVSRuns returns the first divergence between a and b, nil if they agree
+func compareDVSRuns(a, b dvsRun) *DivergenceError {
+	if (a.err == nil) != (b.err == nil) || a.resp.Codespace != b.resp.Codespace || a.resp.Code != b.resp.Code {
+		return &DivergenceError{Field: DivergentCode, A: runCode(a), B: runCode(b)}
+	}
+	if !bytes.Equal(a.resp.Response, b.resp.Response) {
+		return &DivergenceError{Field: DivergentData, A: a.resp.Response, B: b.resp.Response}
+	}
+	if !bytes.Equal(a.resp.ResponseDigest, b.resp.ResponseDigest) {
+		return &DivergenceError{Field: DivergentDigest, A: a.resp.ResponseDigest, B: b.resp.ResponseDigest}
+	}
+	if divergence := compareEvents(a.resp.Events, b.resp.Events); divergence != nil {
+		return divergence
+	}
+	return compareWriteSets(a.writes, b.writes)
+}
+
+func runCode(run dvsRun) string {
+	if run.err == nil {
+		return "ok"
+	}
+	return fmt.Sprintf("%s/%d (%v)", run.resp.Codespace, run.resp.Code, run.err)
+}
+
+func compareEvents(a, b []avsitypes.Event) *DivergenceError {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var ea, eb *avsitypes.Event
+		if i < len(a) {
+			ea = &a[i]
+		}
+		if i < len(b) {
+			eb = &b[i]
+		}
+		if !reflect.DeepEqual(ea, eb) {
+			return &DivergenceError{Field: DivergentEvents, Index: i, A: ea, B: eb}
+		}
+	}
+	return nil
+}
+
+func compareWriteSets(a, b WriteSet) *DivergenceError {
+	stores := map[string]struct{}{}
+	for store := range a {
+		stores[store] = struct{}{}
+	}
+	for store := range b {
+		stores[store] = struct{}{}
+	}
+
+	for _, store := range sortedKeys(stores) {
+		keys := map[string]struct{}{}
+		for key := range a[store] {
+			keys[key] = struct{}{}
+		}
+		for key := range b[store] {
+			keys[key] = struct{}{}
+		}
+
+		for _, key := range sortedKeys(keys) {
+			va, okA := a[store][key]
+			vb, okB := b[store][key]
+			if okA != okB || (va == nil) != (vb == nil) || !bytes.Equal(va, vb) {
+				return &DivergenceError{
+					Field: DivergentWriteSet,
+					Store: store,
+					Key:   []byte(key),
+					A:     writeString(va, okA),
+					B:     writeString(vb, okB),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func writeString(value []byte, written bool) string {
+	switch {
+	case !written:
+		return "unwritten"
+	case value == nil:
+		return "deleted"
+	default:
+		return fmt.Sprintf("set to %X", value)
+	}
+}
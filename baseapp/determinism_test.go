@@ -0,0 +1,165 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	avsitypes "github.com/0xPellNetwork/pelldvs/avsi/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
+	"github.com/0xPellNetwork/pellapp-sdk/service/extractor"
+	"github.com/0xPellNetwork/pellapp-sdk/testutil/tasktest"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+var taskStoreKey = storetypes.NewKVStoreKey("task")
+
+// taskService stores task payloads. The result field named by
+// nondeterministic depends on the number of calls to the service.
+type taskService struct {
+	nondeterministic string
+	calls            uint64
+}
+
+func (s *taskService) SubmitTask(ctx context.Context, msg *testpb.TestCallMsg) (*testpb.TestCallResult, error) {
+	sdkCtx := sdktypes.UnwrapContext(ctx)
+	s.calls++
+	counter := binary.BigEndian.AppendUint64(nil, s.calls)
+
+	value := msg.Payload
+	if s.nondeterministic == DivergentWriteSet {
+		value = counter
+	}
+	store := sdkCtx.KVStore(taskStoreKey)
+	store.Set([]byte(fmt.Sprintf("task/%d", msg.TaskId)), value)
+	store.Delete([]byte("pending"))
+
+	// writes of a nested branch are recorded once written
+	cacheCtx, write := sdkCtx.CacheContext()
+	cacheCtx.KVStore(taskStoreKey).Set([]byte("last"), msg.Payload)
+	write()
+	discardCtx, _ := sdkCtx.CacheContext()
+	discardCtx.KVStore(taskStoreKey).Set([]byte("discarded"), msg.Payload)
+
+	attr := fmt.Sprint(msg.TaskId)
+	if s.nondeterministic == DivergentEvents {
+		attr = fmt.Sprint(s.calls)
+	}
+	sdkCtx.EventManager().EmitEvent(sdktypes.NewEvent("task", sdktypes.NewAttribute("id", attr)))
+	sdkCtx.Logger().Info("task submitted", "id", msg.TaskId)
+
+	res := &testpb.TestCallResult{TaskId: msg.TaskId, Accepted: true}
+	if s.nondeterministic == DivergentCode && s.calls%2 == 0 {
+		return nil, errors.New("task failed")
+	}
+	return res, nil
+}
+
+// countingDigest digests results with the number of digests computed so far
+type countingDigest struct {
+	calls uint64
+}
+
+func (e *countingDigest) GetData(msg proto.Message) ([]byte, error) {
+	return extractor.NewKeccak256().GetData(msg)
+}
+
+func (e *countingDigest) GetDigest(proto.Message) ([]byte, error) {
+	e.calls++
+	return binary.BigEndian.AppendUint64(make([]byte, 24), e.calls), nil
+}
+
+func setupTaskApp(t *testing.T, logger log.Logger, svc tasktest.Server) *BaseApp {
+	app := NewBaseApp("test", logger, dbm.NewMemDB(), nil)
+	app.MountStore(taskStoreKey, storetypes.StoreTypeIAVL)
+	require.NoError(t, app.CommitMultiStore().LoadLatestVersion())
+	app.CommitMultiStore().GetKVStore(taskStoreKey).Set([]byte("pending"), []byte{1})
+
+	tasktest.Setup(t, app, svc)
+	if svc, ok := svc.(*taskService); ok && svc.nondeterministic == DivergentDigest {
+		app.GetMsgRouter().GetConfigurator().RegisterResultMsgExtractor(&testpb.TestCallResult{}, &countingDigest{})
+	}
+	return app
+}
+
+func taskRequest(t *testing.T) *avsitypes.RequestProcessDVSRequest {
+	return tasktest.Request(t, 7, []byte("payload"))
+}
+
+func TestSimulateDVSRequest(t *testing.T) {
+	app := setupTaskApp(t, log.NewLogger(&bytes.Buffer{}), &taskService{})
+
+	resp, writes, err := app.SimulateDVSRequest(context.Background(), taskRequest(t))
+	require.NoError(t, err)
+	assert.Len(t, resp.ResponseDigest, 32)
+	assert.Equal(t, WriteSet{
+		"task": {
+			"task/7":  []byte("payload"),
+			"pending": nil,
+			"last":    []byte("payload"),
+		},
+	}, writes)
+
+	// the simulation is discarded
+	store := app.CommitMultiStore().GetKVStore(taskStoreKey)
+	assert.Nil(t, store.Get([]byte("task/7")))
+	assert.Equal(t, []byte{1}, store.Get([]byte("pending")))
+}
+
+func TestCheckDeterminism(t *testing.T) {
+	logger := log.NewLogger(&bytes.Buffer{})
+	ctx := context.Background()
+
+	app := setupTaskApp(t, logger, &taskService{})
+	require.NoError(t, CheckDeterminism(ctx, taskRequest(t), app))
+	require.NoError(t, CheckDeterminism(ctx, taskRequest(t), app, setupTaskApp(t, logger, &taskService{})))
+	require.Error(t, CheckDeterminism(ctx, taskRequest(t)))
+
+	for _, field := range []string{DivergentCode, DivergentDigest, DivergentEvents, DivergentWriteSet} {
+		t.Run(field, func(t *testing.T) {
+			app := setupTaskApp(t, logger, &taskService{nondeterministic: field})
+
+			err := CheckDeterminism(ctx, taskRequest(t), app)
+			var divergence *DivergenceError
+			require.ErrorAs(t, err, &divergence)
+			assert.Equal(t, field, divergence.Field)
+		})
+	}
+
+	// the first divergent write is reported
+	app = setupTaskApp(t, logger, &taskService{nondeterministic: DivergentWriteSet})
+	err := CheckDeterminism(ctx, taskRequest(t), app)
+	var divergence *DivergenceError
+	require.ErrorAs(t, err, &divergence)
+	assert.Equal(t, "task", divergence.Store)
+	assert.Equal(t, []byte("task/7"), divergence.Key)
+	assert.Equal(t, "set to 0000000000000001", divergence.A)
+	assert.Equal(t, "set to 0000000000000002", divergence.B)
+}
+
+func TestProcessDVSRequestDeterminismCheck(t *testing.T) {
+	var logs bytes.Buffer
+	app := setupTaskApp(t, log.NewLogger(&logs), &taskService{nondeterministic: DivergentWriteSet})
+	app.SetDeterminismCheck(true)
+
+	_, err := app.ProcessDVSRequest(context.Background(), taskRequest(t))
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "nondeterministic request")
+
+	// only the second run is written
+	store := app.CommitMultiStore().GetKVStore(taskStoreKey)
+	assert.Equal(t, binary.BigEndian.AppendUint64(nil, 2), store.Get([]byte("task/7")))
+	assert.Equal(t, []byte("payload"), store.Get([]byte("last")))
+	assert.Nil(t, store.Get([]byte("pending")))
+	assert.Nil(t, store.Get([]byte("discarded")))
+}
@@ -0,0 +1,64 @@
+package baseapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+// InitGenesis validates appState, the genesis states of the modules by module
+// name, then initializes the state of the modules of the module manager from
+// it and commits it. It is meant to be called once, on an empty state.
+func (app *BaseApp) InitGenesis(appState json.RawMessage) error {
+	if app.moduleManager == nil {
+		return errors.New("no module manager set")
+	}
+
+	var genesis map[string]json.RawMessage
+	if err := json.Unmarshal(appState, &genesis); err != nil {
+		return fmt.Errorf("failed to unmarshal app state: %w", err)
+	}
+	if err := app.moduleManager.ValidateGenesis(app.cdc, genesis); err != nil {
+		return fmt.Errorf("invalid genesis: %w", err)
+	}
+
+	cacheMS := app.cms.CacheMultiStore()
+	ctx := sdktypes.NewContext(context.Background(), cacheMS, app.logger)
+	if err := app.moduleManager.InitGenesis(ctx, app.cdc, genesis); err != nil {
+		return err
+	}
+	cacheMS.Write()
+
+	commitID := app.cms.Commit()
+	app.logger.Info("initialized genesis", "version", commitID.Version, "hash", fmt.Sprintf("%X", commitID.Hash))
+	return nil
+}
+
+// ExportGenesis exports the state of the modules of the module manager at
+// height, only modulesToExport unless empty, as their genesis states by module
+// name. The latest state is read as gRPC queries read it when height is -1.
+func (app *BaseApp) ExportGenesis(height int64, modulesToExport ...string) (json.RawMessage, error) {
+	if app.moduleManager == nil {
+		return nil, errors.New("no module manager set")
+	}
+
+	ctx, err := app.CreateQueryContext()
+	if err != nil {
+		return nil, err
+	}
+	if height != -1 {
+		cacheMS, err := app.cms.CacheMultiStoreWithVersion(height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load state at height %d: %w", height, err)
+		}
+		ctx = ctx.WithMultiStore(cacheMS)
+	}
+	genesis, err := app.moduleManager.ExportGenesis(ctx, app.cdc, modulesToExport...)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(genesis, "", "  ")
+}
@@ -0,0 +1,88 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+	"github.com/0xPellNetwork/pellapp-sdk/x/params"
+)
+
+func TestGenesis(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	cdc := codec.NewProtoCodec(registry)
+	key := storetypes.NewKVStoreKey(params.StoreKey)
+
+	app := NewBaseApp("test", log.NewLogger(&bytes.Buffer{}), dbm.NewMemDB(), cdc)
+	app.MountStore(key, storetypes.StoreTypeIAVL)
+	require.NoError(t, app.CommitMultiStore().LoadLatestVersion())
+
+	keeper := params.NewKeeper(cdc, key, "authority")
+	defaults := authtypes.DefaultParams()
+	subspace := params.NewSubspace(keeper, "tasks", &defaults)
+	mm := sdktypes.NewManager(params.NewAppModule(keeper))
+	mm.RegisterInterfaces(registry)
+
+	_, err := app.ExportGenesis(-1)
+	require.ErrorContains(t, err, "no module manager set")
+	app.SetModuleManager(mm)
+
+	genesis := mm.DefaultGenesis(cdc)
+	var paramsGenesis map[string]any
+	require.NoError(t, json.Unmarshal(genesis[params.ModuleName], &paramsGenesis))
+	paramsGenesis["params"].([]any)[0].(map[string]any)["params"].(map[string]any)["tx_sig_limit"] = "3"
+	genesis[params.ModuleName], err = json.Marshal(paramsGenesis)
+	require.NoError(t, err)
+	appState, err := json.Marshal(genesis)
+	require.NoError(t, err)
+
+	// the genesis state is committed
+	require.NoError(t, app.InitGenesis(appState))
+	assert.Equal(t, int64(1), app.LastBlockHeight())
+
+	ctx, err := app.CreateQueryContext()
+	require.NoError(t, err)
+	stored, err := subspace.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), stored.TxSigLimit)
+
+	exported, err := app.ExportGenesis(-1)
+	require.NoError(t, err)
+	var exportedGenesis map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(exported, &exportedGenesis))
+	assert.JSONEq(t, string(genesis[params.ModuleName]), string(exportedGenesis[params.ModuleName]))
+
+	// invalid genesis states are not initialized
+	paramsGenesis["params"].([]any)[0].(map[string]any)["params"].(map[string]any)["tx_sig_limit"] = "0"
+	genesis[params.ModuleName], err = json.Marshal(paramsGenesis)
+	require.NoError(t, err)
+	appState, err = json.Marshal(genesis)
+	require.NoError(t, err)
+	require.ErrorIs(t, app.InitGenesis(appState), params.ErrInvalidParams)
+	assert.Equal(t, int64(1), app.LastBlockHeight())
+
+	// the state of a past height is exported
+	updated := *stored
+	updated.TxSigLimit = 4
+	require.NoError(t, subspace.Set(sdktypes.NewContext(context.Background(), app.CommitMultiStore(), app.logger), &updated))
+	app.CommitMultiStore().Commit()
+	latest, err := app.ExportGenesis(-1)
+	require.NoError(t, err)
+	assert.NotEqual(t, string(exported), string(latest))
+	past, err := app.ExportGenesis(1)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(exported), string(past))
+	_, err = app.ExportGenesis(3)
+	require.ErrorContains(t, err, "failed to load state at height 3")
+}
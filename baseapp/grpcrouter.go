@@ -26,6 +26,8 @@ type GRPCQueryRouter struct {
 	// hybridHandlers maps the request name to the handler. It is a hybrid handler which seamlessly
 	// handles both gogo and protov2 messages.
 	hybridHandlers map[string][]func(ctx context.Context, req, resp protoiface.MessageV1) error
+	// methodHybridHandlers maps the full method name, /service/method, to the hybrid handler.
+	methodHybridHandlers map[string]func(ctx context.Context, req, resp protoiface.MessageV1) error
 	// binaryCodec is used to encode/decode binary protobuf messages.
 	binaryCodec codec.BinaryCodec
 	// cdc is the gRPC codec used by the router to correctly unmarshal messages.
@@ -43,8 +45,9 @@ type serviceData struct {
 // NewGRPCQueryRouter creates a new GRPCQueryRouter
 func NewGRPCQueryRouter() *GRPCQueryRouter {
 	return &GRPCQueryRouter{
-		routes:         map[string]GRPCQueryHandler{},
-		hybridHandlers: map[string][]func(ctx context.Context, req, resp protoiface.MessageV1) error{},
+		routes:               map[string]GRPCQueryHandler{},
+		hybridHandlers:       map[string][]func(ctx context.Context, req, resp protoiface.MessageV1) error{},
+		methodHybridHandlers: map[string]func(ctx context.Context, req, resp protoiface.MessageV1) error{},
 	}
 }
 
@@ -130,6 +133,12 @@ func (qrt *GRPCQueryRouter) HybridHandlerByRequestName(name string) []func(ctx c
 	return qrt.hybridHandlers[name]
 }
 
+// HybridHandlerByMethod returns the hybrid handler of a full method name,
+// /service/method, or nil if not found.
+func (qrt *GRPCQueryRouter) HybridHandlerByMethod(method string) func(ctx context.Context, req, resp protoiface.MessageV1) error {
+	return qrt.methodHybridHandlers[method]
+}
+
 func (qrt *GRPCQueryRouter) registerHybridHandler(sd *grpc.ServiceDesc, method grpc.MethodDesc, handler interface{}) error {
 	// extract message name from method descriptor
 	inputName, err := protocompat.RequestFullNameFromMethodDesc(sd, method)
@@ -143,6 +152,7 @@ func (qrt *GRPCQueryRouter) registerHybridHandler(sd *grpc.ServiceDesc, method g
 	}
 
 	qrt.hybridHandlers[string(inputName)] = append(qrt.hybridHandlers[string(inputName)], methodHandler)
+	qrt.methodHybridHandlers[fmt.Sprintf("/%s/%s", sd.ServiceName, method.MethodName)] = methodHandler
 	return nil
 }
 
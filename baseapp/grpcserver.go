@@ -8,29 +8,14 @@ import (
 	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"google.golang.org/grpc"
 
+	"github.com/0xPellNetwork/pellapp-sdk/telemetry"
 	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
 )
 
 // RegisterGRPCServer registers gRPC services directly with the gRPC server.
 func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
-	// Define an interceptor for all gRPC queries: this interceptor will create
-	// a new sdk.Context, and pass it into the query handler.
-	interceptor := func(grpcCtx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		// Create the sdk.Context. Passing false as 2nd arg, as we can't
-		// actually support proofs with gRPC right now.
-		sdkCtx, err := app.CreateQueryContext()
-		if err != nil {
-			return nil, err
-		}
-
-		// Attach the sdk.Context into the gRPC's context.Context.
-		grpcCtx = context.WithValue(grpcCtx, sdktypes.ContextKey, sdkCtx)
-
-		return handler(grpcCtx, req)
-	}
-
-	// Loop through all services and methods, add the interceptor, and register
-	// the service.
+	// Loop through all services and methods, add the query interceptor, and
+	// register the service.
 	for _, data := range app.GRPCQueryRouter().serviceData {
 		desc := data.serviceDesc
 		newMethods := make([]grpc.MethodDesc, len(desc.Methods))
@@ -39,11 +24,8 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 			methodHandler := method.Handler
 			newMethods[i] = grpc.MethodDesc{
 				MethodName: method.MethodName,
-				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
-					return methodHandler(srv, ctx, dec, grpcmiddleware.ChainUnaryServer(
-						grpcrecovery.UnaryServerInterceptor(),
-						interceptor,
-					))
+				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, srvInterceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+					return methodHandler(srv, ctx, dec, app.queryInterceptor(srvInterceptor))
 				},
 			}
 		}
@@ -59,3 +41,41 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 		server.RegisterService(newDesc, data.handler)
 	}
 }
+
+// queryInterceptor returns the interceptor of the calls to the query
+// services, running the interceptor of the server, if any, such as
+// authentication, then recovering from panics, tracing the call and creating
+// the sdk.Context passed to the query handler.
+func (app *BaseApp) queryInterceptor(srvInterceptor grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	interceptors := []grpc.UnaryServerInterceptor{
+		grpcrecovery.UnaryServerInterceptor(),
+		telemetry.UnaryServerInterceptor(),
+		app.queryContextInterceptor,
+	}
+	if srvInterceptor != nil {
+		interceptors = append([]grpc.UnaryServerInterceptor{srvInterceptor}, interceptors...)
+	}
+	return grpcmiddleware.ChainUnaryServer(interceptors...)
+}
+
+// queryContextInterceptor creates a new sdk.Context for all gRPC queries, and
+// passes it into the query handler.
+func (app *BaseApp) queryContextInterceptor(grpcCtx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	// Create the sdk.Context. Passing false as 2nd arg, as we can't
+	// actually support proofs with gRPC right now.
+	sdkCtx, err := app.CreateQueryContext()
+	if err != nil {
+		return nil, err
+	}
+
+	// Attach the sdk.Context into the gRPC's context.Context, the former
+	// carries the span of the call for store operations to be traced, and
+	// the caller authenticated by the server interceptors.
+	sdkCtx = sdkCtx.WithContext(grpcCtx)
+	if principal, ok := sdktypes.PrincipalFromContext(grpcCtx); ok {
+		sdkCtx = sdkCtx.WithPrincipal(principal)
+	}
+	grpcCtx = context.WithValue(grpcCtx, sdktypes.ContextKey, sdkCtx)
+
+	return handler(grpcCtx, req)
+}
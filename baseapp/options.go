@@ -0,0 +1,7 @@
+package baseapp
+
+// SetIndexEvents provides a BaseApp option function that sets the events
+// indexed by PellDVS, see BaseApp.SetIndexEvents.
+func SetIndexEvents(ie []string) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetIndexEvents(ie) }
+}
@@ -0,0 +1,77 @@
+package baseapp
+
+import (
+	"context"
+
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+var _ gogogrpc.ClientConn = (*queryConn)(nil)
+
+// queryConn is an in-process gogogrpc.ClientConn routing unary calls to the
+// hybrid handlers of the gRPC query router, through the query interceptor,
+// without the network and encoding round trip of a gRPC client.
+type queryConn struct {
+	app         *BaseApp
+	interceptor grpc.UnaryServerInterceptor
+}
+
+// Invoke implements gogogrpc.ClientConn. The outgoing metadata of ctx, e.g.
+// the HTTP headers forwarded by the gRPC gateway, is passed to the handler as
+// incoming metadata.
+func (c *queryConn) Invoke(ctx context.Context, method string, args, reply interface{}, _ ...grpc.CallOption) error {
+	handler := c.app.GRPCQueryRouter().HybridHandlerByMethod(method)
+	if handler == nil {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+
+	req, ok := args.(protoiface.MessageV1)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "invalid request type %T for method %s", args, method)
+	}
+	resp, ok := reply.(protoiface.MessageV1)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "invalid response type %T for method %s", reply, method)
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	_, err := c.interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return resp, handler(ctx, req.(protoiface.MessageV1), resp)
+	})
+	return err
+}
+
+// NewStream implements gogogrpc.ClientConn. Streams are not supported.
+func (c *queryConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streams are not supported in process")
+}
+
+// QueryConn returns an in-process gRPC client connection to the query
+// services registered on the gRPC query router, for module keepers, CLI tests
+// or the gRPC gateway to call them without a gRPC server. Each call runs
+// against a query context of the latest committed state, as gRPC queries do.
+// Requests and responses may be either gogoproto or protov2 messages.
+func (app *BaseApp) QueryConn() gogogrpc.ClientConn {
+	return &queryConn{app: app, interceptor: app.queryInterceptor(nil)}
+}
+
+// RegisterGRPCGatewayRoutes registers the gRPC gateway routes of the modules
+// of the module manager, if set, on mux. The gateway calls are routed to the
+// gRPC query router in process, running interceptor, if not nil, as the gRPC
+// server would, e.g. to authenticate the callers.
+func (app *BaseApp) RegisterGRPCGatewayRoutes(mux *runtime.ServeMux, interceptor grpc.UnaryServerInterceptor) {
+	if app.moduleManager == nil {
+		return
+	}
+
+	app.moduleManager.RegisterGRPCGatewayRoutes(&queryConn{app: app, interceptor: app.queryInterceptor(interceptor)}, mux)
+}
@@ -0,0 +1,149 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	reflectionv1beta1 "cosmossdk.io/api/cosmos/base/reflection/v1beta1"
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/cosmos-sdk/client/grpc/reflection"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/0xPellNetwork/pellapp-sdk/client"
+	"github.com/0xPellNetwork/pellapp-sdk/client/grpc/node"
+	nodev1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/node/v1"
+	"github.com/0xPellNetwork/pellapp-sdk/server/api"
+	"github.com/0xPellNetwork/pellapp-sdk/server/auth"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+// nodeGatewayModule serves the node info at GET /node/info through the gRPC
+// gateway.
+type nodeGatewayModule struct{}
+
+func (nodeGatewayModule) IsAppModule()                                    {}
+func (nodeGatewayModule) Name() string                                    { return "node" }
+func (nodeGatewayModule) RegisterServices(sdktypes.Configurator)          {}
+func (nodeGatewayModule) RegisterInterfaces(codectypes.InterfaceRegistry) {}
+func (nodeGatewayModule) RegisterQueryServices(gogogrpc.Server)           {}
+
+func (nodeGatewayModule) RegisterGRPCGatewayRoutes(conn gogogrpc.ClientConn, mux *runtime.ServeMux) {
+	pattern := runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"node", "info"}, "", runtime.AssumeColonVerbOpt(false)))
+	mux.Handle(http.MethodGet, pattern, func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, req)
+		ctx, err := runtime.AnnotateContext(req.Context(), mux, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, req, err)
+			return
+		}
+		resp, err := nodev1.NewServiceClient(conn).Info(ctx, &nodev1.InfoRequest{})
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, req, resp)
+	})
+}
+
+func setupQueryApp(t *testing.T, registry codectypes.InterfaceRegistry) *BaseApp {
+	app := NewBaseApp("test", log.NewLogger(&bytes.Buffer{}), dbm.NewMemDB(), nil)
+	app.SetVersion("v1.2.3")
+	router := NewGRPCQueryRouter()
+	router.SetInterfaceRegistry(registry)
+	app.SetGRPCQueryRouter(router)
+	node.RegisterNodeService(app.GRPCQueryRouter(), app, *config.DefaultConfig())
+	require.NoError(t, app.CommitMultiStore().LoadLatestVersion())
+	return app
+}
+
+func TestQueryConnInvoke(t *testing.T) {
+	app := setupQueryApp(t, codectypes.NewInterfaceRegistry())
+
+	// the interceptor runs with the outgoing metadata of the caller
+	var md metadata.MD
+	conn := &queryConn{app: app, interceptor: app.queryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		assert.Equal(t, "/pellapp.node.v1.Service/Info", info.FullMethod)
+		md, _ = metadata.FromIncomingContext(ctx)
+		return handler(ctx, req)
+	})}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-caller", "test")
+	resp, err := nodev1.NewServiceClient(conn).Info(ctx, &nodev1.InfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3", resp.AppVersion)
+	assert.Equal(t, []string{"test"}, md.Get("x-caller"))
+
+	err = conn.Invoke(ctx, "/pellapp.node.v1.Service/Unknown", &nodev1.InfoRequest{}, &nodev1.InfoResponse{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = conn.NewStream(ctx, &grpc.StreamDesc{}, "/pellapp.node.v1.Service/Info")
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestQueryConn(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	registry.RegisterInterface("test.Stringer", (*fmt.Stringer)(nil))
+	app := setupQueryApp(t, registry)
+	conn := app.QueryConn()
+	ctx := context.Background()
+
+	// gogoproto messages
+	info, err := nodev1.NewServiceClient(conn).Info(ctx, &nodev1.InfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3", info.AppVersion)
+	gogoRes, err := reflection.NewReflectionServiceClient(conn).ListAllInterfaces(ctx, &reflection.ListAllInterfacesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test.Stringer"}, gogoRes.InterfaceNames)
+
+	// protov2 messages
+	protov2Res, err := reflectionv1beta1.NewReflectionServiceClient(conn).ListAllInterfaces(ctx, &reflectionv1beta1.ListAllInterfacesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test.Stringer"}, protov2Res.InterfaceNames)
+}
+
+func TestRegisterGRPCGatewayRoutes(t *testing.T) {
+	app := setupQueryApp(t, codectypes.NewInterfaceRegistry())
+	app.SetModuleManager(sdktypes.NewManager(nodeGatewayModule{}))
+
+	authenticator, err := auth.New(config.AuthConfig{
+		Enable: true,
+		Tokens: [][]string{{"reader", "reader-token"}},
+	})
+	require.NoError(t, err)
+
+	apiSrv := api.New(client.Context{}, log.NewLogger(&bytes.Buffer{}), grpc.NewServer())
+	app.RegisterGRPCGatewayRoutes(apiSrv.GRPCGatewayRouter, authenticator.UnaryServerInterceptor())
+
+	get := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/node/info", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		apiSrv.GRPCGatewayRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// the interceptor authenticates the gateway calls
+	assert.Equal(t, http.StatusUnauthorized, get("").Code)
+
+	rec := get("reader-token")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var info map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+	assert.Equal(t, "v1.2.3", info["app_version"])
+}
@@ -0,0 +1,136 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// CloserFunc adapts a function to an io.Closer registered with RegisterCloser.
+type CloserFunc func() error
+
+// Close calls f.
+func (f CloserFunc) Close() error {
+	return f()
+}
+
+type namedCloser struct {
+	name   string
+	closer io.Closer
+}
+
+// RegisterCloser registers a resource of the application, e.g. a client of an
+// external service, to be closed by Close. Closers are closed in the reverse
+// order of their registration, before the stores are committed and the DB is
+// closed.
+func (app *BaseApp) RegisterCloser(name string, closer io.Closer) {
+	app.shutdownMtx.Lock()
+	defer app.shutdownMtx.Unlock()
+
+	app.closers = append(app.closers, namedCloser{name: name, closer: closer})
+}
+
+// beginRequest registers a DVS request in flight, returning false if the
+// application is draining. Accepted requests must call app.endRequest.
+func (app *BaseApp) beginRequest() bool {
+	app.shutdownMtx.Lock()
+	defer app.shutdownMtx.Unlock()
+
+	if app.draining {
+		return false
+	}
+	app.requests++
+	app.inFlight.Add(1)
+	return true
+}
+
+// endRequest unregisters a DVS request in flight.
+func (app *BaseApp) endRequest() {
+	app.shutdownMtx.Lock()
+	defer app.shutdownMtx.Unlock()
+
+	app.requests--
+	app.inFlight.Done()
+}
+
+// stopAccepting makes the application refuse new DVS requests with
+// errors.ErrShuttingDown.
+func (app *BaseApp) stopAccepting() {
+	app.shutdownMtx.Lock()
+	defer app.shutdownMtx.Unlock()
+
+	app.draining = true
+}
+
+// Drain stops accepting new DVS requests, which are refused with
+// errors.ErrShuttingDown, and waits for the requests in flight to complete or
+// ctx to be done.
+func (app *BaseApp) Drain(ctx context.Context) error {
+	app.stopAccepting()
+
+	done := make(chan struct{})
+	go func() {
+		app.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain DVS requests in flight: %w", ctx.Err())
+	}
+}
+
+// Close is called in start cmd to gracefully cleanup resources, once the
+// requests in flight are drained and the servers and node are stopped. It
+// closes the registered closers, commits the stores if their working state
+// changed since the last commit, then closes the DB. If requests are still in
+// flight, e.g. Drain timed out, the stores are neither committed nor the DB
+// closed under them, and an error is returned. Subsequent calls return the
+// error of the first one.
+func (app *BaseApp) Close() error {
+	app.closeOnce.Do(func() {
+		app.stopAccepting()
+		app.closeErr = app.close()
+	})
+	return app.closeErr
+}
+
+func (app *BaseApp) close() error {
+	var errs []error
+
+	app.shutdownMtx.Lock()
+	closers, requests := app.closers, app.requests
+	app.shutdownMtx.Unlock()
+
+	for i := len(closers) - 1; i >= 0; i-- {
+		app.logger.Info("closing", "closer", closers[i].name)
+		if err := closers[i].closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s: %w", closers[i].name, err))
+		}
+	}
+
+	if requests > 0 {
+		errs = append(errs, fmt.Errorf("%d DVS requests in flight, the application stores are not committed and the DB is not closed", requests))
+		return errors.Join(errs...)
+	}
+
+	if app.cms != nil && !bytes.Equal(app.cms.WorkingHash(), app.cms.LastCommitID().Hash) {
+		app.logger.Info("committing application stores")
+		commitID := app.cms.Commit()
+		app.logger.Info("committed application stores", "version", commitID.Version, "hash", fmt.Sprintf("%X", commitID.Hash))
+	}
+
+	// Close app.db (opened by the DBOpener of server/start.go)
+	if app.db != nil {
+		app.logger.Info("Closing application.db")
+		if err := app.db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
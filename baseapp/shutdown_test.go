@@ -0,0 +1,113 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+func TestDrain(t *testing.T) {
+	app := setupTaskApp(t, log.NewLogger(&bytes.Buffer{}), &taskService{})
+
+	// the ante handler blocks the request in flight until released
+	started, release := make(chan struct{}), make(chan struct{})
+	app.SetAnteHandler(func(ctx sdktypes.Context, msg any) (sdktypes.Context, error) {
+		close(started)
+		<-release
+		return ctx, nil
+	})
+
+	errCh := make(chan error)
+	go func() {
+		_, err := app.ProcessDVSRequest(context.Background(), taskRequest(t))
+		errCh <- err
+	}()
+	<-started
+
+	// the deadline expires before the request completes
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, app.Drain(ctx), context.DeadlineExceeded)
+
+	// new requests are refused while draining
+	resp, err := app.ProcessDVSRequest(context.Background(), taskRequest(t))
+	require.ErrorIs(t, err, sdkerrors.ErrShuttingDown)
+	assert.Equal(t, sdkerrors.ErrShuttingDown.AVSICode(), resp.Code)
+
+	drained := make(chan error)
+	go func() { drained <- app.Drain(context.Background()) }()
+	close(release)
+	require.NoError(t, <-errCh)
+	require.NoError(t, <-drained)
+}
+
+func TestCloseInFlight(t *testing.T) {
+	app := setupTaskApp(t, log.NewLogger(&bytes.Buffer{}), &taskService{})
+	var closed bool
+	app.RegisterCloser("closer", CloserFunc(func() error {
+		closed = true
+		return nil
+	}))
+
+	started, release := make(chan struct{}), make(chan struct{})
+	app.SetAnteHandler(func(ctx sdktypes.Context, msg any) (sdktypes.Context, error) {
+		close(started)
+		<-release
+		return ctx, nil
+	})
+	errCh := make(chan error)
+	go func() {
+		_, err := app.ProcessDVSRequest(context.Background(), taskRequest(t))
+		errCh <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, app.Drain(ctx))
+
+	// the stores are not committed nor the DB closed under the request
+	require.ErrorContains(t, app.Close(), "1 DVS requests in flight")
+	assert.True(t, closed)
+	assert.Equal(t, int64(0), app.LastBlockHeight())
+
+	close(release)
+	require.NoError(t, <-errCh)
+	require.NoError(t, app.db.Set([]byte("key"), []byte("value")))
+}
+
+func TestClose(t *testing.T) {
+	app := setupTaskApp(t, log.NewLogger(&bytes.Buffer{}), &taskService{})
+
+	var closed []string
+	app.RegisterCloser("first", CloserFunc(func() error {
+		closed = append(closed, "first")
+		return nil
+	}))
+	app.RegisterCloser("second", CloserFunc(func() error {
+		// the stores are not committed yet
+		assert.Equal(t, int64(0), app.LastBlockHeight())
+		closed = append(closed, "second")
+		return errors.New("close failed")
+	}))
+
+	err := app.Close()
+	require.ErrorContains(t, err, "failed to close second: close failed")
+	assert.Equal(t, []string{"second", "first"}, closed)
+	assert.Equal(t, int64(1), app.LastBlockHeight())
+
+	// the closers are closed once, and requests are refused
+	require.Equal(t, err, app.Close())
+	assert.Len(t, closed, 2)
+	_, err = app.ProcessDVSRequest(context.Background(), taskRequest(t))
+	require.ErrorIs(t, err, sdkerrors.ErrShuttingDown)
+}
@@ -0,0 +1,114 @@
+package baseapp
+
+import (
+	"bytes"
+	"sort"
+
+	"cosmossdk.io/store/cachekv"
+	storetypes "cosmossdk.io/store/types"
+)
+
+// WriteSet holds the final value of every key written by a DVS request, by
+// store name then key. Deleted keys have a nil value.
+type WriteSet map[string]map[string][]byte
+
+func (ws WriteSet) set(store string, key, value []byte) {
+	if ws[store] == nil {
+		ws[store] = map[string][]byte{}
+	}
+	ws[store][string(key)] = value
+}
+
+// merge applies the writes of other on top of ws
+func (ws WriteSet) merge(other WriteSet) {
+	for store, writes := range other {
+		for key, value := range writes {
+			ws.set(store, []byte(key), value)
+		}
+	}
+}
+
+// sortedKeys returns the keys of m in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeSetStore branches a multistore and records the writes made through
+// it. Writes of nested branches are recorded once the branch is written.
+type writeSetStore struct {
+	storetypes.MultiStore
+
+	cache  storetypes.CacheMultiStore
+	parent *writeSetStore
+	writes WriteSet
+}
+
+var _ storetypes.CacheMultiStore = &writeSetStore{}
+
+func newWriteSetStore(ms storetypes.MultiStore) *writeSetStore {
+	cache := ms.CacheMultiStore()
+	return &writeSetStore{
+		MultiStore: cache,
+		cache:      cache,
+		writes:     WriteSet{},
+	}
+}
+
+// Write flushes the branch to its parent, along with its recorded writes
+func (s *writeSetStore) Write() {
+	s.cache.Write()
+	if s.parent != nil {
+		s.parent.writes.merge(s.writes)
+	}
+}
+
+func (s *writeSetStore) CacheMultiStore() storetypes.CacheMultiStore {
+	child := newWriteSetStore(s.cache)
+	child.parent = s
+	return child
+}
+
+func (s *writeSetStore) CacheWrap() storetypes.CacheWrap {
+	return s.CacheMultiStore()
+}
+
+func (s *writeSetStore) GetKVStore(key storetypes.StoreKey) storetypes.KVStore {
+	return &writeSetKVStore{KVStore: s.cache.GetKVStore(key), name: key.Name(), writes: s.writes}
+}
+
+func (s *writeSetStore) GetStore(key storetypes.StoreKey) storetypes.Store {
+	store := s.cache.GetStore(key)
+	if kv, ok := store.(storetypes.KVStore); ok {
+		return &writeSetKVStore{KVStore: kv, name: key.Name(), writes: s.writes}
+	}
+	return store
+}
+
+// writeSetKVStore records the writes made to a KVStore
+type writeSetKVStore struct {
+	storetypes.KVStore
+
+	name   string
+	writes WriteSet
+}
+
+func (s *writeSetKVStore) Set(key, value []byte) {
+	s.KVStore.Set(key, value)
+	s.writes.set(s.name, key, bytes.Clone(value))
+}
+
+func (s *writeSetKVStore) Delete(key []byte) {
+	s.KVStore.Delete(key)
+	s.writes.set(s.name, key, nil)
+}
+
+// CacheWrap branches the recording store so that writes to the branch are
+// recorded when it is written.
+func (s *writeSetKVStore) CacheWrap() storetypes.CacheWrap {
+	return cachekv.NewStore(s)
+}
@@ -3,6 +3,11 @@ package client
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -110,9 +115,19 @@ func ReadPersistentCommandFlags(clientCtx Context, flagSet *pflag.FlagSet) (Cont
 			if useInsecure {
 				dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 			} else {
-				dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
-					MinVersion: tls.VersionTLS12,
-				})))
+				tlsCfg, err := readGRPCTLSFlags(flagSet)
+				if err != nil {
+					return Context{}, err
+				}
+				dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+			}
+			if token, _ := flagSet.GetString(flags.FlagGRPCToken); token != "" {
+				// the token is only sent in plaintext to local nodes
+				if useInsecure && !isLoopbackTarget(grpcURI) {
+					return Context{}, fmt.Errorf("--%s is only sent over --%s to a loopback address, not %s",
+						flags.FlagGRPCToken, flags.FlagGRPCInsecure, grpcURI)
+				}
+				dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken{token: token, allowInsecure: useInsecure}))
 			}
 
 			grpcClient, err := grpc.NewClient(grpcURI, dialOpts...) //nolint:nolintlint // grpc.Dial is deprecated but we still use it
@@ -125,3 +140,78 @@ func ReadPersistentCommandFlags(clientCtx Context, flagSet *pflag.FlagSet) (Cont
 
 	return clientCtx, nil
 }
+
+// readGRPCTLSFlags returns the TLS configuration of the gRPC client set by the
+// FlagGRPCTLS* flags.
+func readGRPCTLSFlags(flagSet *pflag.FlagSet) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	tlsCfg.ServerName, _ = flagSet.GetString(flags.FlagGRPCTLSServerName)
+
+	if caFile, _ := flagSet.GetString(flags.FlagGRPCTLSCA); caFile != "" {
+		bz, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(bz) {
+			return nil, fmt.Errorf("no certificate found in %s", caFile)
+		}
+	}
+
+	certFile, _ := flagSet.GetString(flags.FlagGRPCTLSCert)
+	keyFile, _ := flagSet.GetString(flags.FlagGRPCTLSKey)
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("--%s and --%s must be set together", flags.FlagGRPCTLSCert, flags.FlagGRPCTLSKey)
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
+
+// bearerToken authenticates gRPC calls with an "authorization: Bearer"
+// header. It requires TLS unless allowInsecure is set, for local nodes.
+type bearerToken struct {
+	token         string
+	allowInsecure bool
+}
+
+var _ credentials.PerRPCCredentials = bearerToken{}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+func (t bearerToken) RequireTransportSecurity() bool {
+	return !t.allowInsecure
+}
+
+// isLoopbackTarget reports whether the gRPC target, e.g. localhost:9090 or
+// dns:///127.0.0.1:9090, is a loopback address or a unix socket.
+func isLoopbackTarget(target string) bool {
+	if strings.HasPrefix(target, "unix:") {
+		return true
+	}
+	if i := strings.LastIndex(target, "/"); i >= 0 {
+		target = target[i+1:]
+	}
+	host, _, err := net.SplitHostPort(target)
+	if err != nil {
+		host = target
+	}
+	host = strings.Trim(host, "[]")
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/client/flags"
+	"github.com/0xPellNetwork/pellapp-sdk/testutil/tlstest"
+)
+
+func TestReadGRPCTLSFlags(t *testing.T) {
+	ca := tlstest.NewCA(t, "ca")
+	certFile, keyFile, caFile := ca.WriteFiles(t, t.TempDir(), "client", x509.ExtKeyUsageClientAuth)
+
+	testCases := []struct {
+		name   string
+		args   []string
+		expErr string
+	}{
+		{"defaults", nil, ""},
+		{"ca and client cert", []string{"--grpc-tls-ca", caFile, "--grpc-tls-cert", certFile, "--grpc-tls-key", keyFile, "--grpc-tls-server-name", "localhost"}, ""},
+		{"cert without key", []string{"--grpc-tls-cert", certFile}, "must be set together"},
+		{"invalid ca", []string{"--grpc-tls-ca", keyFile}, "no certificate found"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			flags.AddQueryFlagsToCmd(cmd)
+			require.NoError(t, cmd.ParseFlags(tc.args))
+
+			tlsCfg, err := readGRPCTLSFlags(cmd.Flags())
+			if tc.expErr != "" {
+				require.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			if len(tc.args) == 0 {
+				require.Nil(t, tlsCfg.RootCAs)
+				require.Empty(t, tlsCfg.Certificates)
+				return
+			}
+			require.NotNil(t, tlsCfg.RootCAs)
+			require.Len(t, tlsCfg.Certificates, 1)
+			require.Equal(t, "localhost", tlsCfg.ServerName)
+		})
+	}
+}
+
+func TestReadPersistentCommandFlagsToken(t *testing.T) {
+	testCases := []struct {
+		name   string
+		args   []string
+		expErr string
+	}{
+		{"tls", []string{"--grpc-addr", "node.example.com:9090", "--grpc-token", "secret"}, ""},
+		{"insecure loopback", []string{"--grpc-addr", "127.0.0.1:9090", "--grpc-insecure", "--grpc-token", "secret"}, ""},
+		{"insecure localhost", []string{"--grpc-addr", "dns:///localhost:9090", "--grpc-insecure", "--grpc-token", "secret"}, ""},
+		{"insecure remote", []string{"--grpc-addr", "node.example.com:9090", "--grpc-insecure", "--grpc-token", "secret"}, "only sent over --grpc-insecure to a loopback address"},
+		{"insecure remote without token", []string{"--grpc-addr", "node.example.com:9090", "--grpc-insecure"}, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			flags.AddQueryFlagsToCmd(cmd)
+			require.NoError(t, cmd.ParseFlags(tc.args))
+
+			clientCtx, err := ReadPersistentCommandFlags(Context{}, cmd.Flags())
+			if tc.expErr != "" {
+				require.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, clientCtx.GRPCClient)
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	require.True(t, bearerToken{token: "secret"}.RequireTransportSecurity())
+	require.False(t, bearerToken{token: "secret", allowInsecure: true}.RequireTransportSecurity())
+
+	md, err := bearerToken{token: "secret"}.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"authorization": "Bearer secret"}, md)
+}
+
+func TestIsLoopbackTarget(t *testing.T) {
+	for target, expected := range map[string]bool{
+		"localhost:9090":          true,
+		"127.0.0.1:9090":          true,
+		"[::1]:9090":              true,
+		"dns:///127.0.0.1:9090":   true,
+		"unix:///tmp/grpc.sock":   true,
+		"0.0.0.0:9090":            false,
+		"node.example.com:9090":   false,
+		"dns:///10.0.0.1:9090":    false,
+		"localhost.example.com:1": false,
+	} {
+		require.Equal(t, expected, isLoopbackTarget(target), target)
+	}
+}
@@ -10,6 +10,13 @@ const (
 	FlagGRPC         = "grpc-addr"
 	FlagGRPCInsecure = "grpc-insecure"
 	FlagHeight       = "height"
+	// gRPC TLS flags, used unless FlagGRPCInsecure is set
+	FlagGRPCTLSCA         = "grpc-tls-ca"
+	FlagGRPCTLSCert       = "grpc-tls-cert"
+	FlagGRPCTLSKey        = "grpc-tls-key"
+	FlagGRPCTLSServerName = "grpc-tls-server-name"
+	// FlagGRPCToken is the bearer token authenticating gRPC calls
+	FlagGRPCToken = "grpc-token"
 	// FlagOutput is the flag to set the output format.
 	// This differs from FlagOutputDocument that is used to set the output file.
 	FlagOutput = "output"
@@ -33,6 +40,11 @@ var LineBreak = &cobra.Command{Run: func(*cobra.Command, []string) {}}
 func AddQueryFlagsToCmd(cmd *cobra.Command) {
 	cmd.Flags().String(FlagGRPC, "", "the gRPC endpoint to use for this chain")
 	cmd.Flags().Bool(FlagGRPCInsecure, false, "allow gRPC over insecure channels, if not the server must use TLS")
+	cmd.Flags().String(FlagGRPCTLSCA, "", "PEM encoded CA bundle to verify the gRPC server certificate against, instead of the system roots")
+	cmd.Flags().String(FlagGRPCTLSCert, "", "PEM encoded client certificate to present to the gRPC server (mTLS)")
+	cmd.Flags().String(FlagGRPCTLSKey, "", "PEM encoded private key of the client certificate")
+	cmd.Flags().String(FlagGRPCTLSServerName, "", "Server name to verify the gRPC server certificate against, instead of the endpoint host")
+	cmd.Flags().String(FlagGRPCToken, "", "Bearer token, static token or JWT, authenticating the gRPC calls (requires TLS unless the endpoint is a loopback address)")
 	cmd.Flags().Int64(FlagHeight, 0, "Use a specific height to query state at (this can error if the node is pruning state)")
 	cmd.Flags().StringP(FlagOutput, "o", "text", "Output format (text|json)")
 }
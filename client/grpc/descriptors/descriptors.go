@@ -0,0 +1,118 @@
+// Package descriptors exports the protobuf descriptors of the messages and
+// services registered by an application, for clients to generate bindings.
+package descriptors
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Output formats of a FileDescriptorSet
+const (
+	FormatBinary = "binary"
+	FormatJSON   = "json"
+)
+
+const (
+	flagServices = "services"
+	flagOutput   = "output"
+	flagFormat   = "format"
+)
+
+// FileDescriptorSet returns the descriptors of the files defining services,
+// along with the files they import, imports first. When services is empty,
+// all the files registered with gogoproto and protoregistry are returned.
+func FileDescriptorSet(services ...string) (*descriptorpb.FileDescriptorSet, error) {
+	if len(services) == 0 {
+		return proto.MergedGlobalFileDescriptors()
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+
+	for _, service := range services {
+		desc, err := proto.HybridResolver.FindDescriptorByName(protoreflect.FullName(service))
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", service, err)
+		}
+		if _, ok := desc.(protoreflect.ServiceDescriptor); !ok {
+			return nil, fmt.Errorf("%s is not a service", service)
+		}
+		add(desc.ParentFile())
+	}
+	return set, nil
+}
+
+// Marshal encodes set in format, FormatBinary or FormatJSON.
+func Marshal(set *descriptorpb.FileDescriptorSet, format string) ([]byte, error) {
+	switch format {
+	case FormatBinary:
+		return protov2.Marshal(set)
+	case FormatJSON:
+		return protojson.MarshalOptions{Indent: "  "}.Marshal(set)
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected %q or %q", format, FormatBinary, FormatJSON)
+	}
+}
+
+// NewCommand returns a CLI command exporting the FileDescriptorSet of the
+// messages and services registered in the application binary.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "descriptors",
+		Short: "Export the protobuf FileDescriptorSet of the registered messages and services",
+		Long: `Export the protobuf FileDescriptorSet of the messages and services registered in
+the application binary, e.g. to generate client bindings with
+protoc --descriptor_set_in or buf. Files are written imports first.`,
+		Example: "appd descriptors --services pellapp.node.v1.Service --output descriptors.binpb",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			services, _ := cmd.Flags().GetStringSlice(flagServices)
+			format, _ := cmd.Flags().GetString(flagFormat)
+			output, _ := cmd.Flags().GetString(flagOutput)
+
+			set, err := FileDescriptorSet(services...)
+			if err != nil {
+				return err
+			}
+			bz, err := Marshal(set, strings.ToLower(format))
+			if err != nil {
+				return err
+			}
+
+			if output == "" {
+				_, err = cmd.OutOrStdout().Write(bz)
+				return err
+			}
+			return os.WriteFile(output, bz, 0o644)
+		},
+	}
+
+	cmd.Flags().StringSlice(flagServices, nil, "Export only the files defining these services, along with their imports")
+	cmd.Flags().StringP(flagOutput, "o", "", "Write to this file instead of stdout")
+	cmd.Flags().String(flagFormat, FormatBinary, "Output format (binary|json)")
+
+	return cmd
+}
@@ -0,0 +1,69 @@
+package descriptors
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	_ "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/node/v1"
+)
+
+func TestFileDescriptorSet(t *testing.T) {
+	set, err := FileDescriptorSet("pellapp.node.v1.Service", "grpc.health.v1.Health")
+	require.NoError(t, err)
+	var names []string
+	for _, file := range set.File {
+		names = append(names, file.GetName())
+	}
+	assert.Equal(t, []string{"pellapp/node/v1/query.proto", "grpc/health/v1/health.proto"}, names)
+
+	// the set is self-contained
+	_, err = protodesc.NewFiles(set)
+	require.NoError(t, err)
+
+	_, err = FileDescriptorSet("pellapp.node.v1.InfoRequest")
+	require.ErrorContains(t, err, "is not a service")
+	_, err = FileDescriptorSet("unknown.Service")
+	require.Error(t, err)
+
+	all, err := FileDescriptorSet()
+	require.NoError(t, err)
+	assert.Greater(t, len(all.File), len(set.File))
+}
+
+func TestCommand(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "descriptors.binpb")
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--services", "pellapp.node.v1.Service", "--output", output})
+	require.NoError(t, cmd.Execute())
+
+	bz, err := os.ReadFile(output)
+	require.NoError(t, err)
+	var set descriptorpb.FileDescriptorSet
+	require.NoError(t, protov2.Unmarshal(bz, &set))
+	require.Len(t, set.File, 1)
+	assert.Equal(t, "pellapp.node.v1", set.File[0].GetPackage())
+
+	var out bytes.Buffer
+	cmd = NewCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--services", "pellapp.node.v1.Service", "--format", "json"})
+	require.NoError(t, cmd.Execute())
+	require.NoError(t, protojson.Unmarshal(out.Bytes(), &set))
+	assert.Equal(t, "pellapp.node.v1", set.File[0].GetPackage())
+
+	cmd = NewCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"--format", "yaml"})
+	require.Error(t, cmd.Execute())
+}
@@ -0,0 +1,111 @@
+// Package node implements the node gRPC service, reporting the version,
+// state and configuration of a running application.
+package node
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	storetypes "cosmossdk.io/store/types"
+	dbm "github.com/cosmos/cosmos-db"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+
+	nodev1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/node/v1"
+	"github.com/0xPellNetwork/pellapp-sdk/server/appdb"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/service"
+	"github.com/0xPellNetwork/pellapp-sdk/version"
+)
+
+// Application defines the application queried by the node service.
+type Application interface {
+	Name() string
+	Version() string
+	LastCommitID() storetypes.CommitID
+	MsgHandlerMethods() map[string]string
+	IndexEvents() []string
+}
+
+// RegisterNodeService registers the node gRPC service on the provided gRPC router.
+func RegisterNodeService(server gogogrpc.Server, app Application, cfg config.Config) {
+	nodev1.RegisterServiceServer(server, NewQueryServer(app, cfg))
+}
+
+var _ nodev1.ServiceServer = queryServer{}
+
+type queryServer struct {
+	app Application
+	cfg config.Config
+}
+
+// NewQueryServer returns the node service server of app, running with cfg.
+func NewQueryServer(app Application, cfg config.Config) nodev1.ServiceServer {
+	return queryServer{app: app, cfg: cfg}
+}
+
+// Info implements nodev1.ServiceServer.
+func (s queryServer) Info(_ context.Context, _ *nodev1.InfoRequest) (*nodev1.InfoResponse, error) {
+	info := version.NewInfo()
+	versionInfo := &nodev1.VersionInfo{
+		Name:              info.Name,
+		AppName:           info.AppName,
+		Version:           info.Version,
+		GitCommit:         info.GitCommit,
+		BuildTags:         info.BuildTags,
+		GoVersion:         info.GoVersion,
+		PellappSdkVersion: info.PellAppSdkVersion,
+	}
+	for _, dep := range info.BuildDeps {
+		versionInfo.BuildDeps = append(versionInfo.BuildDeps, &nodev1.Module{
+			Path:    dep.Path,
+			Version: dep.Version,
+			Sum:     dep.Sum,
+		})
+	}
+
+	methods := s.app.MsgHandlerMethods()
+	handlers := make([]*nodev1.MsgHandler, 0, len(methods))
+	for _, key := range slices.Sorted(maps.Keys(methods)) {
+		handler := &nodev1.MsgHandler{MsgTypeUrl: key, Method: methods[key]}
+		// handlers of validated responses are routed under a suffixed key
+		if strings.Contains(methods[key], service.DVSResponsHandler) {
+			handler.MsgTypeUrl = strings.TrimSuffix(key, fmt.Sprintf(service.DVSResponseHandlerKeyPattern, ""))
+			handler.Response = true
+		}
+		handlers = append(handlers, handler)
+	}
+
+	return &nodev1.InfoResponse{
+		AppName:     s.app.Name(),
+		AppVersion:  s.app.Version(),
+		VersionInfo: versionInfo,
+		MsgHandlers: handlers,
+	}, nil
+}
+
+// Status implements nodev1.ServiceServer.
+func (s queryServer) Status(_ context.Context, _ *nodev1.StatusRequest) (*nodev1.StatusResponse, error) {
+	commitID := s.app.LastCommitID()
+	return &nodev1.StatusResponse{
+		Height:     commitID.Version,
+		CommitHash: commitID.Hash,
+	}, nil
+}
+
+// Config implements nodev1.ServiceServer.
+func (s queryServer) Config(_ context.Context, _ *nodev1.ConfigRequest) (*nodev1.ConfigResponse, error) {
+	return &nodev1.ConfigResponse{
+		IndexEvents:      s.app.IndexEvents(),
+		AppDbBackend:     s.cfg.AppDBBackend,
+		ApiEnable:        s.cfg.API.Enable,
+		ApiAddress:       s.cfg.API.Address,
+		GrpcEnable:       s.cfg.GRPC.Enable,
+		GrpcAddress:      s.cfg.GRPC.Address,
+		TelemetryEnabled: s.cfg.Telemetry.Enabled,
+		TracingEnabled:   s.cfg.Tracing.Enabled,
+		AppDbOptions:     appdb.Options(dbm.BackendType(s.cfg.AppDBBackend), s.cfg),
+	}, nil
+}
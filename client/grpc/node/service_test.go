@@ -0,0 +1,57 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nodev1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/node/v1"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+)
+
+type testApp struct{}
+
+func (testApp) Name() string    { return "test" }
+func (testApp) Version() string { return "v1" }
+func (testApp) LastCommitID() storetypes.CommitID {
+	return storetypes.CommitID{Version: 3, Hash: []byte{0xab}}
+}
+func (testApp) IndexEvents() []string { return nil }
+func (testApp) MsgHandlerMethods() map[string]string {
+	return map[string]string{
+		"/test.TaskMsg":     "/test.Service/SubmitTask",
+		"/test.TaskMsgResp": "/test.Service/SubmitTaskDVSResponsHandler",
+	}
+}
+
+func TestQueryServer(t *testing.T) {
+	srv := NewQueryServer(testApp{}, *config.DefaultConfig())
+	ctx := context.Background()
+
+	info, err := srv.Info(ctx, &nodev1.InfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []*nodev1.MsgHandler{
+		{MsgTypeUrl: "/test.TaskMsg", Method: "/test.Service/SubmitTask"},
+		{MsgTypeUrl: "/test.TaskMsg", Method: "/test.Service/SubmitTaskDVSResponsHandler", Response: true},
+	}, info.MsgHandlers)
+
+	status, err := srv.Status(ctx, &nodev1.StatusRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &nodev1.StatusResponse{Height: 3, CommitHash: []byte{0xab}}, status)
+
+	cfg, err := srv.Config(ctx, &nodev1.ConfigRequest{})
+	require.NoError(t, err)
+	assert.True(t, cfg.ApiEnable)
+	assert.False(t, cfg.TracingEnabled)
+	assert.Empty(t, cfg.AppDbOptions)
+
+	appCfg := *config.DefaultConfig()
+	appCfg.AppDBBackend = "pebbledb"
+	cfg, err = NewQueryServer(testApp{}, appCfg).Config(ctx, &nodev1.ConfigRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "pebbledb", cfg.AppDbBackend)
+	assert.Equal(t, "8", cfg.AppDbOptions["cache-size"])
+}
@@ -0,0 +1,24 @@
+// Command pellapp is the developer tool of the SDK, generating DVS
+// applications, modules and messages.
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPellNetwork/pellapp-sdk/scaffold"
+)
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:          "pellapp",
+		Short:        "Develop DVS applications with the pellapp SDK",
+		SilenceUsage: true,
+	}
+	rootCmd.AddCommand(scaffold.NewCommand())
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -11,6 +11,22 @@ var (
 	// ErrStopIterating is used to break out of an iteration
 	ErrStopIterating = Register(UndefinedCodespace, 2, "stop iterating")
 
+	// ErrResultExtraction is returned when a result extractor fails to produce
+	// the custom data or digest of a DVS result
+	ErrResultExtraction = Register(UndefinedCodespace, 3, "result extraction failed")
+
+	// ErrShuttingDown is returned for DVS requests received once the
+	// application started draining for shutdown
+	ErrShuttingDown = Register(UndefinedCodespace, 4, "application is shutting down")
+
+	// ErrUnauthorized is returned for messages dispatched on behalf of
+	// another authority than theirs
+	ErrUnauthorized = Register(UndefinedCodespace, 5, "unauthorized")
+
+	// ErrMaxDispatchDepth is returned for messages dispatched from within
+	// handlers beyond the maximum nesting depth
+	ErrMaxDispatchDepth = Register(UndefinedCodespace, 6, "max message dispatch depth exceeded")
+
 	// ErrPanic should only be set when we recovering from a panic
 	ErrPanic = Register(UndefinedCodespace, 111222, "panic")
 )
@@ -29,6 +29,18 @@ func (n *Node) Start() error {
 	return n.node.Start()
 }
 
+// Stop stops the node if it is running.
+func (n *Node) Stop() error {
+	if n.node == nil {
+		return fmt.Errorf("node is nil")
+	}
+	if !n.node.IsRunning() {
+		return nil
+	}
+
+	return n.node.Stop()
+}
+
 func NewNode(
 	logger log.Logger,
 	app avsitypes.Application,
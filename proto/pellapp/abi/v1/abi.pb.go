@@ -0,0 +1,120 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.17.3
+// source: pellapp/abi/v1/abi.proto
+
+package abiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_pellapp_abi_v1_abi_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         83001,
+		Name:          "pellapp.abi.v1.abi_type",
+		Tag:           "bytes,83001,opt,name=abi_type",
+		Filename:      "pellapp/abi/v1/abi.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         83001,
+		Name:          "pellapp.abi.v1.struct_name",
+		Tag:           "bytes,83001,opt,name=struct_name",
+		Filename:      "pellapp/abi/v1/abi.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// abi_type overrides the Solidity type a field is encoded as, e.g. "address"
+	// for a hex string field or "uint256" for a decimal string field.
+	//
+	// optional string abi_type = 83001;
+	E_AbiType = &file_pellapp_abi_v1_abi_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// struct_name overrides the Solidity struct name of a message, used as the
+	// EIP-712 type name. Defaults to the message name.
+	//
+	// optional string struct_name = 83001;
+	E_StructName = &file_pellapp_abi_v1_abi_proto_extTypes[1]
+)
+
+var File_pellapp_abi_v1_abi_proto protoreflect.FileDescriptor
+
+var file_pellapp_abi_v1_abi_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x65, 0x6c, 0x6c, 0x61, 0x70, 0x70, 0x2f, 0x61, 0x62, 0x69, 0x2f, 0x76, 0x31,
+	0x2f, 0x61, 0x62, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x70, 0x65, 0x6c, 0x6c,
+	0x61, 0x70, 0x70, 0x2e, 0x61, 0x62, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3a, 0x3a, 0x0a, 0x08,
+	0x61, 0x62, 0x69, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xb9, 0x88, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x62, 0x69, 0x54, 0x79, 0x70, 0x65, 0x3a, 0x42, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xb9, 0x88, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x41, 0x5a, 0x3f,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x30, 0x78, 0x50, 0x65, 0x6c,
+	0x6c, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x70, 0x65, 0x6c, 0x6c, 0x61, 0x70, 0x70,
+	0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x65, 0x6c, 0x6c, 0x61,
+	0x70, 0x70, 0x2f, 0x61, 0x62, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x62, 0x69, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_pellapp_abi_v1_abi_proto_goTypes = []interface{}{
+	(*descriptorpb.FieldOptions)(nil),   // 0: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil), // 1: google.protobuf.MessageOptions
+}
+var file_pellapp_abi_v1_abi_proto_depIdxs = []int32{
+	0, // 0: pellapp.abi.v1.abi_type:extendee -> google.protobuf.FieldOptions
+	1, // 1: pellapp.abi.v1.struct_name:extendee -> google.protobuf.MessageOptions
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	0, // [0:2] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pellapp_abi_v1_abi_proto_init() }
+func file_pellapp_abi_v1_abi_proto_init() {
+	if File_pellapp_abi_v1_abi_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pellapp_abi_v1_abi_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 2,
+			NumServices:   0,
+		},
+		GoTypes:           file_pellapp_abi_v1_abi_proto_goTypes,
+		DependencyIndexes: file_pellapp_abi_v1_abi_proto_depIdxs,
+		ExtensionInfos:    file_pellapp_abi_v1_abi_proto_extTypes,
+	}.Build()
+	File_pellapp_abi_v1_abi_proto = out.File
+	file_pellapp_abi_v1_abi_proto_rawDesc = nil
+	file_pellapp_abi_v1_abi_proto_goTypes = nil
+	file_pellapp_abi_v1_abi_proto_depIdxs = nil
+}
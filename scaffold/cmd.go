@@ -0,0 +1,158 @@
+package scaffold
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagOutput     = "output"
+	flagSDKVersion = "sdk-version"
+	flagPath       = "path"
+	flagMessage    = "message"
+
+	defaultMessage = "SubmitTask"
+)
+
+// NewCommand returns the scaffold command, generating applications, modules
+// and messages.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "Generate DVS applications, modules and messages",
+	}
+
+	cmd.AddCommand(
+		appCommand(),
+		moduleCommand(),
+		messageCommand(),
+	)
+
+	return cmd
+}
+
+func appCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "app [module-path]",
+		Short: "Generate a DVS application",
+		Long: `Generate a DVS application of the given Go module path, with its node
+binary and an empty module manager. Modules are added with "scaffold module".`,
+		Example: "pellapp scaffold app github.com/acme/oracle",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modulePath := strings.TrimSuffix(args[0], "/")
+			output, _ := cmd.Flags().GetString(flagOutput)
+			if output == "" {
+				output = path.Base(modulePath)
+			}
+			sdkVersion, _ := cmd.Flags().GetString(flagSDKVersion)
+
+			files, err := App(output, modulePath, sdkVersion)
+			if err != nil {
+				return err
+			}
+			printFiles(cmd, output, files)
+
+			// the requirements of the application and their checksums are
+			// resolved from the SDK
+			tidy := exec.Command("go", "mod", "tidy")
+			tidy.Dir = output
+			tidy.Stdout = cmd.OutOrStdout()
+			tidy.Stderr = cmd.ErrOrStderr()
+			if err := tidy.Run(); err != nil {
+				return fmt.Errorf("failed to tidy the go.mod of the application: %w", err)
+			}
+
+			cmd.Println("\nNext steps:")
+			cmd.Printf("  cd %s\n", output)
+			cmd.Println("  pellapp scaffold module <name>")
+			cmd.Println("  go mod tidy")
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagOutput, "", "The directory to generate the application in, defaults to the last element of the module path")
+	cmd.Flags().String(flagSDKVersion, SDKVersion(), "The version of the SDK the application requires, defaults to the one pellapp was built with")
+
+	return cmd
+}
+
+func moduleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "module [name]",
+		Short: "Generate a module and register it with the application",
+		Long: `Generate a module with its keeper, store keys, proto services, result
+extractor, CLI query commands and tests, register it with the application,
+and generate its first message, unless --message is empty.`,
+		Example: "pellapp scaffold module oracle --message SubmitPrice",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, _ := cmd.Flags().GetString(flagPath)
+			message, _ := cmd.Flags().GetString(flagMessage)
+
+			files, err := Module(dir, args[0])
+			if err != nil {
+				return err
+			}
+			if message != "" {
+				messageFiles, err := Message(dir, args[0], message)
+				if err != nil {
+					return err
+				}
+				files = append(files, messageFiles...)
+			}
+			printFiles(cmd, dir, files)
+			printProtoSteps(cmd)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagPath, ".", "The directory of the application")
+	cmd.Flags().String(flagMessage, defaultMessage, "The name of the first message of the module, none if empty")
+
+	return cmd
+}
+
+func messageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "message [module] [name]",
+		Short: "Generate a message with its request and response handlers",
+		Long: `Generate a message of a module: the proto rpcs of its request and response
+handlers, their implementation storing a record of each processed request,
+the query and CLI command of the records, and tests.`,
+		Example: "pellapp scaffold message oracle SubmitPrice",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, _ := cmd.Flags().GetString(flagPath)
+
+			files, err := Message(dir, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			printFiles(cmd, dir, files)
+			printProtoSteps(cmd)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagPath, ".", "The directory of the application")
+
+	return cmd
+}
+
+func printFiles(cmd *cobra.Command, dir string, files []string) {
+	for _, file := range files {
+		cmd.Printf("  %s\n", filepath.Join(dir, file))
+	}
+}
+
+func printProtoSteps(cmd *cobra.Command) {
+	cmd.Println("\nNext steps:")
+	cmd.Println("  make proto-gen")
+	cmd.Println("  go mod tidy")
+}
@@ -0,0 +1,484 @@
+// Package scaffold generates the skeleton of DVS applications built with the
+// SDK: the application and its commands, modules with their keeper, proto
+// services, result extractor, CLI query commands and tests, and messages with
+// their request and response handlers.
+//
+// Code is generated from templates. Modules and messages are added to the
+// files generated before through the "pellapp:scaffold" marker comments they
+// carry, which must be kept in place.
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const (
+	sdkModulePath = "github.com/0xPellNetwork/pellapp-sdk"
+
+	// markerPrefix prefixes the marker comments snippets are inserted before
+	markerPrefix = "// pellapp:scaffold "
+
+	// markerImport marks the imports of a Go file, imports already present
+	// are not inserted again
+	markerImport = "import"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	moduleNameRegexp  = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	messageNameRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// names are the forms of the name of a message
+type names struct {
+	// Name is the name in CamelCase, e.g. SubmitTask
+	Name string
+	// Lower is the name in lowerCamelCase, e.g. submitTask
+	Lower string
+	// Snake is the name in snake_case, e.g. submit_task
+	Snake string
+	// Kebab is the name in kebab-case, e.g. submit-task
+	Kebab string
+}
+
+func newNames(name string) names {
+	var words []string
+	start := 0
+	for i, r := range name {
+		if i == 0 || !unicode.IsUpper(r) {
+			continue
+		}
+		// a word starts at an uppercase letter following a lowercase one, or
+		// ending an acronym, e.g. VerifyBLSSig is verify_bls_sig
+		if !unicode.IsUpper(rune(name[i-1])) || i+1 < len(name) && unicode.IsLower(rune(name[i+1])) {
+			words = append(words, strings.ToLower(name[start:i]))
+			start = i
+		}
+	}
+	words = append(words, strings.ToLower(name[start:]))
+
+	return names{
+		Name:  name,
+		Lower: strings.ToLower(name[:1]) + name[1:],
+		Snake: strings.Join(words, "_"),
+		Kebab: strings.Join(words, "-"),
+	}
+}
+
+// data is the data templates are executed with
+type data struct {
+	ModulePath string
+	AppName    string
+	BinaryName string
+	EnvPrefix  string
+	SDKVersion string
+
+	Module       string
+	ModuleField  string
+	ProtoPackage string
+	ProtoDir     string
+	GoPackage    string
+	PbAlias      string
+
+	Msg names
+}
+
+func newData(modulePath string) (data, error) {
+	appName := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(path.Base(modulePath)))
+	if !moduleNameRegexp.MatchString(appName) {
+		return data{}, fmt.Errorf("cannot derive an application name from module path %q", modulePath)
+	}
+
+	return data{
+		ModulePath: modulePath,
+		AppName:    appName,
+		BinaryName: appName + "d",
+		EnvPrefix:  strings.ToUpper(appName),
+	}, nil
+}
+
+func (d data) withModule(module string) data {
+	d.Module = module
+	d.ModuleField = strings.ToUpper(module[:1]) + module[1:]
+	d.ProtoPackage = fmt.Sprintf("%s.%s.v1", d.AppName, module)
+	d.ProtoDir = fmt.Sprintf("%s/%s/v1", d.AppName, module)
+	d.GoPackage = fmt.Sprintf("%s/proto/%s", d.ModulePath, d.ProtoDir)
+	d.PbAlias = module + "v1"
+	return d
+}
+
+// App generates in dir a DVS application of Go module modulePath, requiring
+// sdkVersion of the SDK. It returns the files created.
+func App(dir, modulePath, sdkVersion string) ([]string, error) {
+	d, err := newData(modulePath)
+	if err != nil {
+		return nil, err
+	}
+	if sdkVersion == "" {
+		return nil, errors.New("the version of the SDK the application requires is unknown")
+	}
+	d.SDKVersion = sdkVersion
+
+	p := newPlan(dir)
+	for tmpl, file := range map[string]string{
+		"app/go.mod.tmpl":      "go.mod",
+		"app/Makefile.tmpl":    "Makefile",
+		"app/gitignore.tmpl":   ".gitignore",
+		"app/app.go.tmpl":      "app/app.go",
+		"app/app_test.go.tmpl": "app/app_test.go",
+		"app/main.go.tmpl":     fmt.Sprintf("cmd/%s/main.go", d.BinaryName),
+		"app/root.go.tmpl":     fmt.Sprintf("cmd/%s/cmd/root.go", d.BinaryName),
+	} {
+		if err := p.create(file, tmpl, d); err != nil {
+			return nil, err
+		}
+	}
+	return p.write()
+}
+
+// Module generates the module named module in the application in dir and
+// registers it with the application. It returns the files created and
+// updated.
+func Module(dir, module string) ([]string, error) {
+	if !moduleNameRegexp.MatchString(module) {
+		return nil, fmt.Errorf("invalid module name %q, expected lowercase letters and digits", module)
+	}
+	d, err := readApp(dir)
+	if err != nil {
+		return nil, err
+	}
+	d = d.withModule(module)
+
+	if _, err := os.Stat(filepath.Join(dir, "x", module)); err == nil {
+		return nil, fmt.Errorf("module %s already exists", module)
+	}
+
+	p := newPlan(dir)
+	moduleDir := "x/" + module
+	protoDir := "proto/" + d.ProtoDir
+	for tmpl, file := range map[string]string{
+		"module/tx.proto.tmpl":        protoDir + "/tx.proto",
+		"module/query.proto.tmpl":     protoDir + "/query.proto",
+		"module/types.proto.tmpl":     protoDir + "/types.proto",
+		"module/keys.go.tmpl":         moduleDir + "/types/keys.go",
+		"module/codec.go.tmpl":        moduleDir + "/types/codec.go",
+		"module/keeper.go.tmpl":       moduleDir + "/keeper/keeper.go",
+		"module/msg_server.go.tmpl":   moduleDir + "/keeper/msg_server.go",
+		"module/query_server.go.tmpl": moduleDir + "/keeper/query_server.go",
+		"module/keeper_test.go.tmpl":  moduleDir + "/keeper/keeper_test.go",
+		"module/extractor.go.tmpl":    moduleDir + "/extractor.go",
+		"module/module.go.tmpl":       moduleDir + "/module.go",
+		"module/query_cli.go.tmpl":    moduleDir + "/client/cli/query.go",
+	} {
+		if err := p.create(file, tmpl, d); err != nil {
+			return nil, err
+		}
+	}
+
+	appFile := "app/app.go"
+	rootFile := fmt.Sprintf("cmd/%s/cmd/root.go", d.BinaryName)
+	for _, insertion := range []struct {
+		file, marker, snippet string
+	}{
+		{appFile, markerImport, `"[[.ModulePath]]/x/[[.Module]]"
+[[.Module]]keeper "[[.ModulePath]]/x/[[.Module]]/keeper"
+[[.Module]]types "[[.ModulePath]]/x/[[.Module]]/types"
+`},
+		{appFile, "store-key", "[[.Module]]types.StoreKey,\n"},
+		{appFile, "keeper-field", "[[.ModuleField]]Keeper [[.Module]]keeper.Keeper\n"},
+		{appFile, "keeper", "app.[[.ModuleField]]Keeper = [[.Module]]keeper.NewKeeper(cdc, app.GetKey([[.Module]]types.StoreKey))\n"},
+		{appFile, "module", "[[.Module]].NewAppModule(app.[[.ModuleField]]Keeper),\n"},
+		{rootFile, markerImport, "[[.Module]]cli \"[[.ModulePath]]/x/[[.Module]]/client/cli\"\n"},
+		{rootFile, "query-cmd", "[[.Module]]cli.GetQueryCmd(),\n"},
+	} {
+		if err := p.insert(insertion.file, insertion.marker, insertion.snippet, d); err != nil {
+			return nil, err
+		}
+	}
+	return p.write()
+}
+
+// Message generates the message named name, with its request and response
+// handlers, in the module named module of the application in dir. It
+// returns the files created and updated.
+func Message(dir, module, name string) ([]string, error) {
+	if !messageNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid message name %q, expected CamelCase", name)
+	}
+	d, err := readApp(dir)
+	if err != nil {
+		return nil, err
+	}
+	d = d.withModule(module)
+	d.Msg = newNames(name)
+
+	if _, err := os.Stat(filepath.Join(dir, "x", module)); err != nil {
+		return nil, fmt.Errorf("module %s not found: %w", module, err)
+	}
+
+	p := newPlan(dir)
+	moduleDir := "x/" + module
+	protoDir := "proto/" + d.ProtoDir
+	for tmpl, file := range map[string]string{
+		"message/keeper.go.tmpl":          moduleDir + "/keeper/" + d.Msg.Snake + ".go",
+		"message/msg_server.go.tmpl":      moduleDir + "/keeper/msg_server_" + d.Msg.Snake + ".go",
+		"message/query_server.go.tmpl":    moduleDir + "/keeper/query_server_" + d.Msg.Snake + ".go",
+		"message/msg_server_test.go.tmpl": moduleDir + "/keeper/msg_server_" + d.Msg.Snake + "_test.go",
+		"message/query_cli.go.tmpl":       moduleDir + "/client/cli/query_" + d.Msg.Snake + ".go",
+	} {
+		if err := p.create(file, tmpl, d); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, insertion := range []struct {
+		file, marker, tmpl string
+	}{
+		{protoDir + "/tx.proto", "rpc", "message/rpc.proto.tmpl"},
+		{protoDir + "/tx.proto", "message", "message/message.proto.tmpl"},
+		{protoDir + "/types.proto", "type", "message/type.proto.tmpl"},
+		{protoDir + "/query.proto", "query-rpc", "message/query_rpc.proto.tmpl"},
+		{protoDir + "/query.proto", "query-message", "message/query_message.proto.tmpl"},
+	} {
+		snippet, err := templates.ReadFile("templates/" + insertion.tmpl)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.insert(insertion.file, insertion.marker, string(snippet), d); err != nil {
+			return nil, err
+		}
+	}
+
+	insertions := []struct {
+		file, marker, snippet string
+	}{
+		{moduleDir + "/types/keys.go", "key-prefix", `// [[.Msg.Name]]KeyPrefix prefixes the keys of the [[.Msg.Name]] records
+[[.Msg.Name]]KeyPrefix = []byte("[[.Msg.Snake]]/")
+`},
+		{moduleDir + "/types/codec.go", markerImport, "[[.PbAlias]] \"[[.GoPackage]]\"\n"},
+		{moduleDir + "/types/codec.go", "msg", "&[[.PbAlias]].Msg[[.Msg.Name]]{},\n"},
+		{moduleDir + "/module.go", "result-extractor", `cfg.RegisterResultMsgExtractor(&[[.PbAlias]].Msg[[.Msg.Name]]Response{}, NewResultExtractor())
+cfg.OptOutResultMsgExtractor(&[[.PbAlias]].Msg[[.Msg.Name]]Validated{})
+`},
+		{moduleDir + "/client/cli/query.go", "query-cmd", "Get[[.Msg.Name]]Cmd(),\n"},
+	}
+	// the gateway of the Query service is only generated once the service
+	// has a route, the one of the first message
+	moduleFile, err := os.ReadFile(filepath.Join(dir, moduleDir, "module.go"))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Contains(moduleFile, []byte("RegisterQueryHandlerClient")) {
+		insertions = append(insertions, []struct {
+			file, marker, snippet string
+		}{
+			{moduleDir + "/module.go", markerImport, "\"context\"\n"},
+			{moduleDir + "/module.go", "gateway", `if err := [[.PbAlias]].RegisterQueryHandlerClient(context.Background(), mux, [[.PbAlias]].NewQueryClient(conn)); err != nil {
+	panic(err)
+}
+`},
+		}...)
+	}
+	for _, insertion := range insertions {
+		if err := p.insert(insertion.file, insertion.marker, insertion.snippet, d); err != nil {
+			return nil, err
+		}
+	}
+	return p.write()
+}
+
+// SDKVersion returns the version of the SDK the running binary was built
+// with, empty when unknown.
+func SDKVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	version := ""
+	if info.Main.Path == sdkModulePath {
+		version = info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == sdkModulePath {
+			version = dep.Version
+			if dep.Replace != nil {
+				version = dep.Replace.Version
+			}
+		}
+	}
+	if version == "(devel)" {
+		return ""
+	}
+	return version
+}
+
+// readApp returns the data of the application in dir, from its go.mod
+func readApp(dir string) (data, error) {
+	f, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return data{}, fmt.Errorf("not an application directory: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if modulePath, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return newData(strings.Trim(strings.TrimSpace(modulePath), `"`))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return data{}, err
+	}
+	return data{}, errors.New("no module path in go.mod")
+}
+
+// plan collects the files to create and update in a directory, so that
+// nothing is written unless every file could be generated
+type plan struct {
+	dir     string
+	files   map[string][]byte
+	created map[string]bool
+}
+
+func newPlan(dir string) *plan {
+	return &plan{
+		dir:     dir,
+		files:   make(map[string][]byte),
+		created: make(map[string]bool),
+	}
+}
+
+// create adds file, generated from the template tmpl
+func (p *plan) create(file, tmpl string, d data) error {
+	if _, err := os.Stat(filepath.Join(p.dir, file)); err == nil {
+		return fmt.Errorf("%s already exists", file)
+	}
+
+	text, err := templates.ReadFile("templates/" + tmpl)
+	if err != nil {
+		return err
+	}
+	content, err := execute(tmpl, string(text), d)
+	if err != nil {
+		return err
+	}
+	p.files[file] = content
+	p.created[file] = true
+	return nil
+}
+
+// insert inserts the snippet generated from the template text before the
+// marker of file. Imports already in file are skipped.
+func (p *plan) insert(file, marker, text string, d data) error {
+	content, ok := p.files[file]
+	if !ok {
+		var err error
+		if content, err = os.ReadFile(filepath.Join(p.dir, file)); err != nil {
+			return err
+		}
+	}
+
+	snippet, err := execute(file+":"+marker, text, d)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != markerPrefix+marker {
+			continue
+		}
+
+		var inserted []string
+		for _, snippetLine := range strings.SplitAfter(string(snippet), "\n") {
+			if snippetLine == "" {
+				continue
+			}
+			if marker == markerImport && bytes.Contains(content, []byte(strings.TrimSpace(snippetLine))) {
+				continue
+			}
+			inserted = append(inserted, snippetLine)
+		}
+		// the imports of the application go in a group of their own
+		if marker == markerImport && len(inserted) > 0 && i > 0 &&
+			strings.TrimSpace(lines[i-1]) != "" && !strings.Contains(lines[i-1], d.ModulePath) {
+			inserted = append([]string{"\n"}, inserted...)
+		}
+		// gofmt aligns a marker alone in a list with the closing parenthesis,
+		// once the list has elements it belongs with them
+		if filepath.Ext(file) == ".go" && i+1 < len(lines) {
+			indent := line[:len(line)-len(strings.TrimLeft(line, "\t"))]
+			next := strings.TrimPrefix(lines[i+1], indent)
+			if strings.HasPrefix(next, ")") || strings.HasPrefix(next, "}") {
+				lines[i] = "\t" + line
+			}
+		}
+
+		lines = append(lines[:i], append(inserted, lines[i:]...)...)
+		p.files[file] = []byte(strings.Join(lines, ""))
+		return nil
+	}
+	return fmt.Errorf("%s: marker %q not found", file, markerPrefix+marker)
+}
+
+// write formats the Go files and writes the files, returning their names
+func (p *plan) write() ([]string, error) {
+	var written []string
+	for file, content := range p.files {
+		if filepath.Ext(file) == ".go" {
+			formatted, err := format.Source(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to format %s: %w", file, err)
+			}
+			p.files[file] = formatted
+		}
+		written = append(written, file)
+	}
+	sort.Strings(written)
+
+	for _, file := range written {
+		name := filepath.Join(p.dir, file)
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(name, p.files[file], 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return written, nil
+}
+
+// execute executes the template text, delimited by [[ and ]]
+func execute(name, text string, d data) ([]byte, error) {
+	tmpl, err := template.New(name).Delims("[[", "]]").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,219 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, dir, file string) string {
+	t.Helper()
+	bz, err := os.ReadFile(filepath.Join(dir, file))
+	require.NoError(t, err)
+	return string(bz)
+}
+
+// requireGoFiles requires the Go files of dir to parse
+func requireGoFiles(t *testing.T, dir string) {
+	t.Helper()
+	err := filepath.WalkDir(dir, func(name string, _ os.DirEntry, err error) error {
+		if err != nil || filepath.Ext(name) != ".go" {
+			return err
+		}
+		_, err = parser.ParseFile(token.NewFileSet(), name, nil, parser.ParseComments)
+		return err
+	})
+	require.NoError(t, err)
+}
+
+func TestNewNames(t *testing.T) {
+	assert.Equal(t, names{Name: "SubmitTask", Lower: "submitTask", Snake: "submit_task", Kebab: "submit-task"}, newNames("SubmitTask"))
+	assert.Equal(t, names{Name: "Ping", Lower: "ping", Snake: "ping", Kebab: "ping"}, newNames("Ping"))
+	assert.Equal(t, "verify_bls_sig", newNames("VerifyBLSSig").Snake)
+}
+
+func TestApp(t *testing.T) {
+	dir := t.TempDir()
+
+	files, err := App(dir, "github.com/acme/price-oracle", "v0.1.0")
+	require.NoError(t, err)
+	assert.Contains(t, files, "app/app.go")
+	assert.Contains(t, files, "cmd/priceoracled/main.go")
+	requireGoFiles(t, dir)
+
+	goMod := readFile(t, dir, "go.mod")
+	assert.Contains(t, goMod, "module github.com/acme/price-oracle")
+	assert.Contains(t, goMod, sdkModulePath+" v0.1.0")
+
+	// existing files are not overwritten
+	_, err = App(dir, "github.com/acme/price-oracle", "v0.1.0")
+	require.ErrorContains(t, err, "already exists")
+
+	_, err = App(t.TempDir(), "github.com/acme/_", "v0.1.0")
+	require.ErrorContains(t, err, "cannot derive an application name")
+	_, err = App(t.TempDir(), "github.com/acme/oracle", "")
+	require.ErrorContains(t, err, "version of the SDK the application requires is unknown")
+}
+
+func TestModule(t *testing.T) {
+	dir := t.TempDir()
+	_, err := App(dir, "github.com/acme/oracle", "v0.1.0")
+	require.NoError(t, err)
+
+	files, err := Module(dir, "price")
+	require.NoError(t, err)
+	assert.Contains(t, files, "app/app.go")
+	assert.Contains(t, files, "x/price/keeper/keeper.go")
+	requireGoFiles(t, dir)
+
+	app := readFile(t, dir, "app/app.go")
+	assert.Contains(t, app, `pricekeeper "github.com/acme/oracle/x/price/keeper"`)
+	assert.Contains(t, app, "app.PriceKeeper = pricekeeper.NewKeeper(cdc, app.GetKey(pricetypes.StoreKey))")
+	assert.Contains(t, app, "price.NewAppModule(app.PriceKeeper),")
+	assert.Contains(t, readFile(t, dir, "cmd/oracled/cmd/root.go"), "pricecli.GetQueryCmd(),")
+	// the Query service has no route, hence no gateway
+	assert.NotContains(t, readFile(t, dir, "x/price/module.go"), "RegisterQueryHandlerClient")
+
+	_, err = Module(dir, "price")
+	require.ErrorContains(t, err, "module price already exists")
+	_, err = Module(dir, "Price")
+	require.ErrorContains(t, err, "invalid module name")
+	_, err = Module(t.TempDir(), "price")
+	require.ErrorContains(t, err, "not an application directory")
+}
+
+func TestMessage(t *testing.T) {
+	dir := t.TempDir()
+	_, err := App(dir, "github.com/acme/oracle", "v0.1.0")
+	require.NoError(t, err)
+	_, err = Module(dir, "price")
+	require.NoError(t, err)
+
+	_, err = Message(dir, "price", "SubmitPrice")
+	require.NoError(t, err)
+	_, err = Message(dir, "price", "AttestFeed")
+	require.NoError(t, err)
+	requireGoFiles(t, dir)
+
+	tx := readFile(t, dir, "proto/oracle/price/v1/tx.proto")
+	assert.Contains(t, tx, "rpc SubmitPrice(MsgSubmitPrice) returns (MsgSubmitPriceResponse);")
+	assert.Contains(t, tx, "rpc AttestFeedDVSResponsHandler(MsgAttestFeed) returns (MsgAttestFeedValidated);")
+	assert.Contains(t, readFile(t, dir, "proto/oracle/price/v1/query.proto"), `get = "/oracle/price/v1/attest_feed/{id}"`)
+
+	// the import of the messages is inserted once
+	codec := readFile(t, dir, "x/price/types/codec.go")
+	assert.Equal(t, 1, strings.Count(codec, `pricev1 "github.com/acme/oracle/proto/oracle/price/v1"`))
+	assert.Contains(t, codec, "&pricev1.MsgAttestFeed{},")
+
+	module := readFile(t, dir, "x/price/module.go")
+	assert.Contains(t, module, "cfg.RegisterResultMsgExtractor(&pricev1.MsgSubmitPriceResponse{}, NewResultExtractor())")
+	assert.Contains(t, module, "cfg.OptOutResultMsgExtractor(&pricev1.MsgAttestFeedValidated{})")
+	// the gateway is registered once
+	assert.Equal(t, 1, strings.Count(module, "pricev1.RegisterQueryHandlerClient(context.Background(), mux, pricev1.NewQueryClient(conn))"))
+	assert.Equal(t, 1, strings.Count(module, `"context"`))
+	assert.Contains(t, readFile(t, dir, "x/price/types/keys.go"), `AttestFeedKeyPrefix = []byte("attest_feed/")`)
+
+	_, err = Message(dir, "price", "SubmitPrice")
+	require.ErrorContains(t, err, "already exists")
+	_, err = Message(dir, "price", "submit_price")
+	require.ErrorContains(t, err, "invalid message name")
+	_, err = Message(dir, "fees", "SubmitPrice")
+	require.ErrorContains(t, err, "module fees not found")
+}
+
+func TestMessageMissingMarker(t *testing.T) {
+	dir := t.TempDir()
+	_, err := App(dir, "github.com/acme/oracle", "v0.1.0")
+	require.NoError(t, err)
+	_, err = Module(dir, "price")
+	require.NoError(t, err)
+
+	codec := filepath.Join(dir, "x/price/types/codec.go")
+	bz, err := os.ReadFile(codec)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(codec, []byte(strings.ReplaceAll(string(bz), "// pellapp:scaffold msg", "")), 0o644))
+
+	_, err = Message(dir, "price", "SubmitPrice")
+	require.ErrorContains(t, err, `marker "// pellapp:scaffold msg" not found`)
+
+	// nothing is written unless every file could be generated
+	_, err = os.Stat(filepath.Join(dir, "x/price/keeper/submit_price.go"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// goRun runs the go command with args in dir, resolving the missing
+// requirements of the module of dir
+func goRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "go %s: %s", strings.Join(args, " "), out)
+}
+
+func TestBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the generated application")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command is required")
+	}
+	sdkDir, err := filepath.Abs("..")
+	require.NoError(t, err)
+	// the generated application cannot build unless the SDK does
+	var stderr bytes.Buffer
+	list := exec.Command("go", "list", "-deps", "./server")
+	list.Dir = sdkDir
+	list.Stderr = &stderr
+	if err := list.Run(); err != nil {
+		t.Skipf("the dependencies of the SDK are not available: %s", stderr.String())
+	}
+
+	// the application builds against the SDK of this tree
+	dir := t.TempDir()
+	_, err = App(dir, "github.com/acme/oracle", "v0.0.0")
+	require.NoError(t, err)
+	goMod, err := os.OpenFile(filepath.Join(dir, "go.mod"), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = fmt.Fprintf(goMod, "\nreplace %s => %s\n", sdkModulePath, sdkDir)
+	require.NoError(t, err)
+	require.NoError(t, goMod.Close())
+	goSum, err := os.ReadFile(filepath.Join(sdkDir, "go.sum"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), goSum, 0o644))
+	goRun(t, dir, "vet", "./...")
+
+	for _, tool := range []string{"make", "protoc", "protoc-gen-gocosmos", "protoc-gen-grpc-gateway"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is required to generate the proto code of modules", tool)
+		}
+	}
+	protoGen := func() {
+		t.Helper()
+		cmd := exec.Command("make", "proto-gen")
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "make proto-gen: %s", out)
+	}
+
+	// a module without messages has no gateway to register
+	_, err = Module(dir, "price")
+	require.NoError(t, err)
+	protoGen()
+	goRun(t, dir, "vet", "./...")
+
+	_, err = Message(dir, "price", "SubmitPrice")
+	require.NoError(t, err)
+	protoGen()
+	goRun(t, dir, "vet", "./...")
+}
@@ -2,11 +2,14 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPellNetwork/pelldvs-libs/log"
@@ -21,7 +24,10 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/0xPellNetwork/pellapp-sdk/client"
+	"github.com/0xPellNetwork/pellapp-sdk/client/grpc/descriptors"
 	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/server/ratelimit"
+	"github.com/0xPellNetwork/pellapp-sdk/server/tlsconfig"
 	grpctypes "github.com/0xPellNetwork/pellapp-sdk/types/grpc"
 )
 
@@ -40,6 +46,12 @@ type Server struct {
 	// this mutex to avoid data races.
 	mtx      sync.Mutex
 	listener net.Listener
+
+	// handler serves the requests, Router wrapped with the CORS and rate
+	// limit middlewares, and unsafeCORS allows cross origin gRPC-web
+	// requests. Both are replaced by Reload.
+	handler    atomic.Pointer[http.Handler]
+	unsafeCORS atomic.Bool
 }
 
 // CustomGRPCHeaderMatcher for mapping request headers to
@@ -102,15 +114,33 @@ func (s *Server) Start(ctx context.Context, cfg config.Config) error {
 	cmtCfg.ReadTimeout = time.Duration(cfg.API.RPCReadTimeout) * time.Second
 	cmtCfg.WriteTimeout = time.Duration(cfg.API.RPCWriteTimeout) * time.Second
 	cmtCfg.MaxBodyBytes = int64(cfg.API.RPCMaxBodyBytes)
+	if cfg.API.RPCMaxHeaderBytes > 0 {
+		cmtCfg.MaxHeaderBytes = int(cfg.API.RPCMaxHeaderBytes)
+	}
+
+	if err := s.Reload(cfg.API); err != nil {
+		s.mtx.Unlock()
+		return err
+	}
 
 	listener, err := pelldvsrpcserver.Listen(cfg.API.Address, cmtCfg.MaxOpenConnections)
 	if err != nil {
 		s.mtx.Unlock()
 		return err
 	}
+	if cfg.API.TLS.Enabled() {
+		tlsCfg, err := tlsconfig.New(cfg.API.TLS, s.logger)
+		if err != nil {
+			listener.Close()
+			s.mtx.Unlock()
+			return fmt.Errorf("failed to load api tls config: %w", err)
+		}
+		listener = tls.NewListener(listener, tlsCfg)
+	}
 	s.logger.Info("startAPIServer",
 		"api.enable", cfg.API.Enable,
 		"api.address", cfg.API.Address,
+		"api.tls", cfg.API.TLS.Enabled(),
 		"s.GRPCGatewayRouter", s.GRPCGatewayRouter,
 		"listener", listener,
 	)
@@ -118,18 +148,22 @@ func (s *Server) Start(ctx context.Context, cfg config.Config) error {
 	s.listener = listener
 	s.mtx.Unlock()
 
-	// configure grpc-web server
-	if cfg.GRPC.Enable && cfg.GRPCWeb.Enable {
-		var options []grpcweb.Option
-		if cfg.API.EnableUnsafeCORS {
-			options = append(options,
-				grpcweb.WithOriginFunc(func(origin string) bool {
-					return true
-				}),
-			)
+	// register the routes of the API server first, as the first match is used
+	s.registerDescriptors()
+	if cfg.API.Swagger {
+		if err := s.registerSwagger(); err != nil {
+			_ = s.Close()
+			return fmt.Errorf("failed to register swagger: %w", err)
 		}
+	}
 
-		wrappedGrpc := grpcweb.WrapServer(s.GRPCSrv, options...)
+	// configure grpc-web server
+	if cfg.GRPC.Enable && cfg.GRPCWeb.Enable {
+		wrappedGrpc := grpcweb.WrapServer(s.GRPCSrv,
+			grpcweb.WithOriginFunc(func(origin string) bool {
+				return s.unsafeCORS.Load()
+			}),
+		)
 		s.Router.PathPrefix("/").Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			if wrappedGrpc.IsGrpcWebRequest(req) {
 				wrappedGrpc.ServeHTTP(w, req)
@@ -148,16 +182,14 @@ func (s *Server) Start(ctx context.Context, cfg config.Config) error {
 	// Start the API in an external goroutine as Serve is blocking and will return
 	// an error upon failure, which we'll send on the error channel that will be
 	// consumed by the for block below.
-	go func(enableUnsafeCORS bool) {
+	go func() {
 		s.logger.Info("starting API server...", "address", cfg.API.Address)
 
-		if enableUnsafeCORS {
-			allowAllCORS := handlers.CORS(handlers.AllowedHeaders([]string{"Content-Type"}))
-			errCh <- pelldvsrpcserver.Serve(s.listener, allowAllCORS(s.Router), s.logger, cmtCfg)
-		} else {
-			errCh <- pelldvsrpcserver.Serve(s.listener, s.Router, s.logger, cmtCfg)
-		}
-	}(cfg.API.EnableUnsafeCORS)
+		handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			(*s.handler.Load()).ServeHTTP(w, req)
+		})
+		errCh <- pelldvsrpcserver.Serve(s.listener, handler, s.logger, cmtCfg)
+	}()
 
 	// Start a blocking select to wait for an indication to stop the server or that
 	// the server failed to start properly.
@@ -173,6 +205,28 @@ func (s *Server) Start(ctx context.Context, cfg config.Config) error {
 	}
 }
 
+// Reload applies the CORS and rate limit settings of cfg to the requests
+// served from then on. The rate limit buckets start full again.
+func (s *Server) Reload(cfg config.APIConfig) error {
+	var handler http.Handler = s.Router
+	if cfg.EnableUnsafeCORS {
+		allowAllCORS := handlers.CORS(handlers.AllowedHeaders([]string{"Content-Type"}))
+		handler = allowAllCORS(handler)
+	}
+	// rejected requests are answered before any work is done for them
+	if cfg.RateLimit.Enable {
+		limiter, err := ratelimit.New(cfg.RateLimit, "api")
+		if err != nil {
+			return fmt.Errorf("failed to load api rate limit config: %w", err)
+		}
+		handler = limiter.Middleware(handler)
+	}
+
+	s.handler.Store(&handler)
+	s.unsafeCORS.Store(cfg.EnableUnsafeCORS)
+	return nil
+}
+
 // Close closes the API server.
 func (s *Server) Close() error {
 	s.mtx.Lock()
@@ -204,6 +258,48 @@ func (s *Server) registerMetrics() {
 	s.Router.HandleFunc("/metrics", metricsHandler).Methods("GET")
 }
 
+// registerDescriptors serves the FileDescriptorSet of the services of the
+// gRPC server at /descriptors, see descriptors.FileDescriptorSet. The services
+// and format query parameters select the services exported and the encoding.
+func (s *Server) registerDescriptors() {
+	descriptorsHandler := func(w http.ResponseWriter, r *http.Request) {
+		var services []string
+		if param := strings.TrimSpace(r.FormValue("services")); param != "" {
+			services = strings.Split(param, ",")
+		} else if s.GRPCSrv != nil {
+			for name := range s.GRPCSrv.GetServiceInfo() {
+				services = append(services, name)
+			}
+			sort.Strings(services)
+		}
+
+		set, err := descriptors.FileDescriptorSet(services...)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("failed to resolve descriptors: %s", err))
+			return
+		}
+
+		format := strings.TrimSpace(r.FormValue("format"))
+		if format == "" {
+			format = descriptors.FormatBinary
+		}
+		bz, err := descriptors.Marshal(set, format)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		contentType := "application/x-protobuf"
+		if format == descriptors.FormatJSON {
+			contentType = "application/json"
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(bz)
+	}
+
+	s.Router.HandleFunc("/descriptors", descriptorsHandler).Methods("GET")
+}
+
 // errorResponse defines the attributes of a JSON error response.
 type errorResponse struct {
 	Code  int    `json:"code,omitempty"`
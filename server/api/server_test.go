@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/0xPellNetwork/pellapp-sdk/client"
+	nodev1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/node/v1"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+)
+
+func TestServerReload(t *testing.T) {
+	s := New(client.Context{}, log.NewLogger(&bytes.Buffer{}), nil)
+	s.Router.HandleFunc("/ping", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("Origin", "http://example.com")
+		rec := httptest.NewRecorder()
+		(*s.handler.Load()).ServeHTTP(rec, req)
+		return rec
+	}
+
+	cfg := config.DefaultConfig().API
+	require.NoError(t, s.Reload(cfg))
+	rec := serve()
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	// the rate limit and CORS apply to the requests served after the reload
+	cfg.EnableUnsafeCORS = true
+	cfg.RateLimit.Enable = true
+	cfg.RateLimit.ClientRate, cfg.RateLimit.ClientBurst = 1, 1
+	require.NoError(t, s.Reload(cfg))
+	rec = serve()
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.True(t, s.unsafeCORS.Load())
+	require.Equal(t, http.StatusTooManyRequests, serve().Code)
+
+	// an invalid config keeps the current handler
+	cfg.RateLimit.ClientBurst = 0
+	require.ErrorContains(t, s.Reload(cfg), "client-burst")
+	require.Equal(t, http.StatusTooManyRequests, serve().Code)
+}
+
+// startServer starts s on a free local port until t ends and returns the URL
+// it serves
+func startServer(t *testing.T, s *Server, cfg config.Config) string {
+	t.Helper()
+	cfg.API.Address = "tcp://127.0.0.1:0"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Start(ctx, cfg) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+
+	addr := make(chan string, 1)
+	require.Eventually(t, func() bool {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		if s.listener == nil {
+			return false
+		}
+		addr <- s.listener.Addr().String()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	return "http://" + <-addr
+}
+
+func TestServerStartRoutes(t *testing.T) {
+	grpcSrv := grpc.NewServer()
+	nodev1.RegisterServiceServer(grpcSrv, &nodev1.UnimplementedServiceServer{})
+	s := New(client.Context{}, log.NewLogger(&bytes.Buffer{}), grpcSrv)
+
+	// the routes are not shadowed by the gRPC-web handler
+	cfg := config.DefaultConfig()
+	require.True(t, cfg.GRPC.Enable && cfg.GRPCWeb.Enable)
+	url := startServer(t, s, *cfg)
+
+	resp, err := http.Get(url + "/descriptors?format=json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+}
@@ -0,0 +1,473 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/docs"
+	"github.com/cosmos/gogoproto/proto"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"sigs.k8s.io/yaml"
+
+	"github.com/0xPellNetwork/pellapp-sdk/version"
+)
+
+// definition names of the schemas shared by all operations
+const (
+	swaggerDefinitionAny    = "google.protobuf.Any"
+	swaggerDefinitionStatus = "google.rpc.Status"
+)
+
+// swaggerPathParam matches the parameters of a google.api.http path template,
+// e.g. {name} or {name=projects/*}
+var swaggerPathParam = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// Swagger is an OpenAPI 2.0 document.
+type Swagger struct {
+	Swagger     string                                  `json:"swagger"`
+	Info        SwaggerInfo                             `json:"info"`
+	Consumes    []string                                `json:"consumes"`
+	Produces    []string                                `json:"produces"`
+	Paths       map[string]map[string]*SwaggerOperation `json:"paths"`
+	Definitions map[string]*SwaggerSchema               `json:"definitions"`
+}
+
+// SwaggerInfo is the metadata of a Swagger document.
+type SwaggerInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// SwaggerOperation is an operation on a path of a Swagger document, the
+// HTTP binding of a gRPC method.
+type SwaggerOperation struct {
+	OperationID string                      `json:"operationId"`
+	Tags        []string                    `json:"tags"`
+	Parameters  []*SwaggerParameter         `json:"parameters,omitempty"`
+	Responses   map[string]*SwaggerResponse `json:"responses"`
+}
+
+// SwaggerParameter is a path, query or body parameter of an operation.
+type SwaggerParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Type     string         `json:"type,omitempty"`
+	Format   string         `json:"format,omitempty"`
+	Items    *SwaggerSchema `json:"items,omitempty"`
+	Enum     []string       `json:"enum,omitempty"`
+	Schema   *SwaggerSchema `json:"schema,omitempty"`
+}
+
+// SwaggerResponse is a response of an operation.
+type SwaggerResponse struct {
+	Description string         `json:"description"`
+	Schema      *SwaggerSchema `json:"schema"`
+}
+
+// SwaggerSchema is the schema of a message, a field or a parameter.
+type SwaggerSchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *SwaggerSchema            `json:"items,omitempty"`
+	Properties           map[string]*SwaggerSchema `json:"properties,omitempty"`
+	AdditionalProperties *SwaggerSchema            `json:"additionalProperties,omitempty"`
+}
+
+// GenerateSwagger returns the Swagger document of the gRPC gateway routes of
+// services, generated from the google.api.http annotations of their methods.
+// Methods without annotation have no route and are skipped. Field names are
+// the proto names, as marshaled by the gateway.
+func GenerateSwagger(title, version string, services ...protoreflect.ServiceDescriptor) (*Swagger, error) {
+	g := &swaggerGenerator{
+		spec: &Swagger{
+			Swagger:  "2.0",
+			Info:     SwaggerInfo{Title: title, Version: version},
+			Consumes: []string{"application/json"},
+			Produces: []string{"application/json"},
+			Paths:    make(map[string]map[string]*SwaggerOperation),
+			Definitions: map[string]*SwaggerSchema{
+				swaggerDefinitionAny: {
+					Type:                 "object",
+					Properties:           map[string]*SwaggerSchema{"@type": {Type: "string"}},
+					AdditionalProperties: &SwaggerSchema{},
+				},
+				swaggerDefinitionStatus: {
+					Type: "object",
+					Properties: map[string]*SwaggerSchema{
+						"code":    {Type: "integer", Format: "int32"},
+						"message": {Type: "string"},
+						"details": {Type: "array", Items: swaggerRef(swaggerDefinitionAny)},
+					},
+				},
+			},
+		},
+	}
+
+	for _, service := range services {
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			rule, err := httpRule(method)
+			if err != nil {
+				return nil, fmt.Errorf("method %s: %w", method.FullName(), err)
+			}
+			if rule == nil {
+				continue
+			}
+
+			rules := append([]*annotations.HttpRule{rule}, rule.AdditionalBindings...)
+			for j, r := range rules {
+				if err := g.addOperation(method, r, j); err != nil {
+					return nil, fmt.Errorf("method %s: %w", method.FullName(), err)
+				}
+			}
+		}
+	}
+	return g.spec, nil
+}
+
+// httpRule returns the google.api.http annotation of method, nil if none.
+// The options are decoded again as the extension may not have been resolved
+// when the descriptor of method was built.
+func httpRule(method protoreflect.MethodDescriptor) (*annotations.HttpRule, error) {
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	if !ok || opts == nil {
+		return nil, nil
+	}
+
+	bz, err := protov2.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+	resolved := &descriptorpb.MethodOptions{}
+	if err := (protov2.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}).Unmarshal(bz, resolved); err != nil {
+		return nil, err
+	}
+	if !protov2.HasExtension(resolved, annotations.E_Http) {
+		return nil, nil
+	}
+	return protov2.GetExtension(resolved, annotations.E_Http).(*annotations.HttpRule), nil
+}
+
+type swaggerGenerator struct {
+	spec *Swagger
+}
+
+func (g *swaggerGenerator) addOperation(method protoreflect.MethodDescriptor, rule *annotations.HttpRule, binding int) error {
+	var verb, template string
+	switch pattern := rule.Pattern.(type) {
+	case *annotations.HttpRule_Get:
+		verb, template = "get", pattern.Get
+	case *annotations.HttpRule_Put:
+		verb, template = "put", pattern.Put
+	case *annotations.HttpRule_Post:
+		verb, template = "post", pattern.Post
+	case *annotations.HttpRule_Delete:
+		verb, template = "delete", pattern.Delete
+	case *annotations.HttpRule_Patch:
+		verb, template = "patch", pattern.Patch
+	case *annotations.HttpRule_Custom:
+		verb, template = strings.ToLower(pattern.Custom.GetKind()), pattern.Custom.GetPath()
+	default:
+		return errors.New("http rule without pattern")
+	}
+	if template == "" {
+		return errors.New("http rule without path")
+	}
+
+	input := method.Input()
+	operation := &SwaggerOperation{
+		OperationID: string(method.FullName()),
+		Tags:        []string{string(method.Parent().FullName())},
+		Responses: map[string]*SwaggerResponse{
+			"200":     {Description: "A successful response.", Schema: g.messageRef(method.Output())},
+			"default": {Description: "An unexpected error response.", Schema: swaggerRef(swaggerDefinitionStatus)},
+		},
+	}
+	if binding > 0 {
+		operation.OperationID = fmt.Sprintf("%s%d", method.FullName(), binding+1)
+	}
+
+	// path parameters, the template variables
+	pathFields := make(map[string]bool)
+	for _, match := range swaggerPathParam.FindAllStringSubmatch(template, -1) {
+		name := match[1]
+		field, err := fieldByPath(input, name)
+		if err != nil {
+			return err
+		}
+		param := &SwaggerParameter{Name: name, In: "path", Required: true}
+		setParameterType(param, field)
+		operation.Parameters = append(operation.Parameters, param)
+		pathFields[name] = true
+	}
+	path := swaggerPathParam.ReplaceAllString(template, "{$1}")
+
+	// the body, then query parameters for the fields bound to neither the
+	// path nor the body
+	switch rule.Body {
+	case "":
+		operation.Parameters = append(operation.Parameters, g.queryParameters(input, "", pathFields, map[protoreflect.FullName]bool{})...)
+	case "*":
+		operation.Parameters = append(operation.Parameters, &SwaggerParameter{
+			Name: "body", In: "body", Required: true, Schema: g.messageRef(input),
+		})
+	default:
+		field := input.Fields().ByName(protoreflect.Name(rule.Body))
+		if field == nil {
+			return fmt.Errorf("unknown body field %s of %s", rule.Body, input.FullName())
+		}
+		operation.Parameters = append(operation.Parameters, &SwaggerParameter{
+			Name: rule.Body, In: "body", Required: true, Schema: g.fieldSchema(field),
+		})
+		pathFields[rule.Body] = true
+		operation.Parameters = append(operation.Parameters, g.queryParameters(input, "", pathFields, map[protoreflect.FullName]bool{})...)
+	}
+
+	if g.spec.Paths[path] == nil {
+		g.spec.Paths[path] = make(map[string]*SwaggerOperation)
+	}
+	if _, ok := g.spec.Paths[path][verb]; ok {
+		return fmt.Errorf("duplicate route %s %s", strings.ToUpper(verb), path)
+	}
+	g.spec.Paths[path][verb] = operation
+	return nil
+}
+
+// fieldByPath returns the field of msg named by path, e.g. a.b for the field b
+// of the message field a
+func fieldByPath(msg protoreflect.MessageDescriptor, path string) (protoreflect.FieldDescriptor, error) {
+	var field protoreflect.FieldDescriptor
+	for _, name := range strings.Split(path, ".") {
+		if msg == nil {
+			return nil, fmt.Errorf("path parameter %s is not a field path", path)
+		}
+		field = msg.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil, fmt.Errorf("unknown path parameter %s of %s", path, msg.FullName())
+		}
+		msg = field.Message()
+	}
+	return field, nil
+}
+
+// queryParameters returns the query parameters of the fields of msg not bound
+// to the path or the body, nested messages being flattened as prefix.field
+func (g *swaggerGenerator) queryParameters(msg protoreflect.MessageDescriptor, prefix string, bound map[string]bool, visiting map[protoreflect.FullName]bool) []*SwaggerParameter {
+	if visiting[msg.FullName()] {
+		return nil
+	}
+	visiting[msg.FullName()] = true
+	defer delete(visiting, msg.FullName())
+
+	var params []*SwaggerParameter
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		name := prefix + string(field.Name())
+		if bound[name] || field.IsMap() {
+			continue
+		}
+		if field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+			if field.IsList() || isWellKnownType(field.Message()) {
+				continue
+			}
+			params = append(params, g.queryParameters(field.Message(), name+".", bound, visiting)...)
+			continue
+		}
+
+		param := &SwaggerParameter{Name: name, In: "query"}
+		setParameterType(param, field)
+		params = append(params, param)
+	}
+	return params
+}
+
+func setParameterType(param *SwaggerParameter, field protoreflect.FieldDescriptor) {
+	schema := scalarSchema(field)
+	if field.IsList() {
+		param.Type, param.Items = "array", schema
+		return
+	}
+	param.Type, param.Format, param.Enum = schema.Type, schema.Format, schema.Enum
+}
+
+// messageRef returns a reference to the definition of msg, adding it and the
+// definitions of its fields if missing
+func (g *swaggerGenerator) messageRef(msg protoreflect.MessageDescriptor) *SwaggerSchema {
+	if schema := wellKnownSchema(msg); schema != nil {
+		return schema
+	}
+
+	name := string(msg.FullName())
+	if _, ok := g.spec.Definitions[name]; ok {
+		return swaggerRef(name)
+	}
+
+	// the definition is added before its fields for recursive messages
+	schema := &SwaggerSchema{Type: "object", Properties: make(map[string]*SwaggerSchema)}
+	g.spec.Definitions[name] = schema
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		schema.Properties[string(field.Name())] = g.fieldSchema(field)
+	}
+	return swaggerRef(name)
+}
+
+func (g *swaggerGenerator) fieldSchema(field protoreflect.FieldDescriptor) *SwaggerSchema {
+	switch {
+	case field.IsMap():
+		return &SwaggerSchema{Type: "object", AdditionalProperties: g.fieldSchema(field.MapValue())}
+	case field.IsList():
+		return &SwaggerSchema{Type: "array", Items: g.singularSchema(field)}
+	default:
+		return g.singularSchema(field)
+	}
+}
+
+func (g *swaggerGenerator) singularSchema(field protoreflect.FieldDescriptor) *SwaggerSchema {
+	if field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+		return g.messageRef(field.Message())
+	}
+	return scalarSchema(field)
+}
+
+// scalarSchema returns the schema of the values of a scalar or enum field,
+// 64-bit integers being strings in JSON
+func scalarSchema(field protoreflect.FieldDescriptor) *SwaggerSchema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return &SwaggerSchema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &SwaggerSchema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &SwaggerSchema{Type: "integer", Format: "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &SwaggerSchema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &SwaggerSchema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &SwaggerSchema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &SwaggerSchema{Type: "number", Format: "double"}
+	case protoreflect.BytesKind:
+		return &SwaggerSchema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		enum := make([]string, values.Len())
+		for i := range enum {
+			enum[i] = string(values.Get(i).Name())
+		}
+		return &SwaggerSchema{Type: "string", Enum: enum}
+	default:
+		return &SwaggerSchema{Type: "string"}
+	}
+}
+
+func isWellKnownType(msg protoreflect.MessageDescriptor) bool {
+	return wellKnownSchema(msg) != nil
+}
+
+// wellKnownSchema returns the schema of the well-known types with a special
+// JSON mapping, nil for other messages
+func wellKnownSchema(msg protoreflect.MessageDescriptor) *SwaggerSchema {
+	switch msg.FullName() {
+	case "google.protobuf.Timestamp":
+		return &SwaggerSchema{Type: "string", Format: "date-time"}
+	case "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return &SwaggerSchema{Type: "string"}
+	case "google.protobuf.Any":
+		return swaggerRef(swaggerDefinitionAny)
+	case "google.protobuf.Struct":
+		return &SwaggerSchema{Type: "object", AdditionalProperties: &SwaggerSchema{}}
+	case "google.protobuf.Value":
+		return &SwaggerSchema{}
+	default:
+		return nil
+	}
+}
+
+func swaggerRef(name string) *SwaggerSchema {
+	return &SwaggerSchema{Ref: "#/definitions/" + name}
+}
+
+// registerSwagger serves the Swagger UI at /swagger/, along with the Swagger
+// document of the gateway routes of the services of the gRPC server, at
+// /swagger/swagger.yaml and /swagger/swagger.json.
+func (s *Server) registerSwagger() error {
+	root, err := fs.Sub(docs.SwaggerUI, "swagger-ui")
+	if err != nil {
+		return err
+	}
+
+	specHandler := func(format string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			spec, err := s.swagger()
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to generate swagger: %s", err))
+				return
+			}
+
+			bz, err := json.MarshalIndent(spec, "", "  ")
+			if err == nil && format == "yaml" {
+				bz, err = yaml.JSONToYAML(bz)
+			}
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/"+format)
+			_, _ = w.Write(bz)
+		}
+	}
+
+	s.Router.HandleFunc("/swagger/swagger.yaml", specHandler("yaml")).Methods("GET")
+	s.Router.HandleFunc("/swagger/swagger.json", specHandler("json")).Methods("GET")
+	s.Router.Handle("/swagger", http.RedirectHandler("/swagger/", http.StatusMovedPermanently))
+	s.Router.PathPrefix("/swagger/").Handler(http.StripPrefix("/swagger/", http.FileServer(http.FS(root))))
+	return nil
+}
+
+// swagger returns the Swagger document of the services of the gRPC server
+func (s *Server) swagger() (*Swagger, error) {
+	var names []string
+	if s.GRPCSrv != nil {
+		for name := range s.GRPCSrv.GetServiceInfo() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	services := make([]protoreflect.ServiceDescriptor, 0, len(names))
+	for _, name := range names {
+		desc, err := proto.HybridResolver.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			// services registered without descriptors, e.g. by hand, have no routes
+			continue
+		}
+		if service, ok := desc.(protoreflect.ServiceDescriptor); ok {
+			services = append(services, service)
+		}
+	}
+
+	title := version.Name
+	if title == "" {
+		title = version.AppName
+	}
+	return GenerateSwagger(title, version.Version, services...)
+}
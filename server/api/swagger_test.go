@@ -0,0 +1,291 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"sigs.k8s.io/yaml"
+
+	"github.com/0xPellNetwork/pellapp-sdk/client"
+	nodev1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/node/v1"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+)
+
+func descField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     protov2.String(name),
+		JsonName: protov2.String(name),
+		Number:   protov2.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = protov2.String(typeName)
+	}
+	return f
+}
+
+func descRepeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+func descMethod(name, input, output string, rule *annotations.HttpRule) *descriptorpb.MethodDescriptorProto {
+	m := &descriptorpb.MethodDescriptorProto{
+		Name:       protov2.String(name),
+		InputType:  protov2.String(input),
+		OutputType: protov2.String(output),
+	}
+	if rule != nil {
+		m.Options = &descriptorpb.MethodOptions{}
+		protov2.SetExtension(m.Options, annotations.E_Http, rule)
+	}
+	return m
+}
+
+// taskService returns the descriptor of a service with annotated methods
+func taskService(t *testing.T) protoreflect.ServiceDescriptor {
+	const (
+		msg = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		str = descriptorpb.FieldDescriptorProto_TYPE_STRING
+	)
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       protov2.String("test/swagger/task.proto"),
+		Package:    protov2.String("test.swagger"),
+		Syntax:     protov2.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: protov2.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: protov2.String("STATUS_PENDING"), Number: protov2.Int32(0)},
+				{Name: protov2.String("STATUS_DONE"), Number: protov2.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: protov2.String("Task"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					descField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT64, ""),
+					descField("name", 2, str, ""),
+					descField("status", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".test.swagger.Status"),
+					descRepeated(descField("tags", 4, str, "")),
+					descRepeated(descField("data", 5, msg, ".test.swagger.Task.DataEntry")),
+					descField("created", 6, msg, ".google.protobuf.Timestamp"),
+					descField("parent", 7, msg, ".test.swagger.Task"),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name: protov2.String("DataEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						descField("key", 1, str, ""),
+						descField("value", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, ""),
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: protov2.Bool(true)},
+				}},
+			},
+			{
+				Name: protov2.String("Filter"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					descField("owner", 1, str, ""),
+					descField("done", 2, descriptorpb.FieldDescriptorProto_TYPE_BOOL, ""),
+				},
+			},
+			{
+				Name: protov2.String("GetTaskRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					descField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT64, ""),
+					descField("filter", 2, msg, ".test.swagger.Filter"),
+				},
+			},
+			{
+				Name:  protov2.String("CreateTaskRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{descField("task", 1, msg, ".test.swagger.Task"), descField("owner", 2, str, "")},
+			},
+			{
+				Name:  protov2.String("TaskResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{descField("task", 1, msg, ".test.swagger.Task")},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: protov2.String("TaskService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				descMethod("GetTask", ".test.swagger.GetTaskRequest", ".test.swagger.TaskResponse", &annotations.HttpRule{
+					Pattern: &annotations.HttpRule_Get{Get: "/tasks/{id}"},
+					AdditionalBindings: []*annotations.HttpRule{
+						{Pattern: &annotations.HttpRule_Get{Get: "/v2/tasks/{id=*}"}},
+					},
+				}),
+				descMethod("CreateTask", ".test.swagger.CreateTaskRequest", ".test.swagger.TaskResponse", &annotations.HttpRule{
+					Pattern: &annotations.HttpRule_Post{Post: "/tasks"},
+					Body:    "task",
+				}),
+				descMethod("ReplaceTask", ".test.swagger.CreateTaskRequest", ".test.swagger.TaskResponse", &annotations.HttpRule{
+					Pattern: &annotations.HttpRule_Put{Put: "/tasks/{task.id}"},
+					Body:    "*",
+				}),
+				// no route
+				descMethod("WatchTask", ".test.swagger.GetTaskRequest", ".test.swagger.TaskResponse", nil),
+			},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+	return fd.Services().Get(0)
+}
+
+func TestGenerateSwagger(t *testing.T) {
+	spec, err := GenerateSwagger("task", "v1.0.0", taskService(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0", spec.Swagger)
+	assert.Equal(t, SwaggerInfo{Title: "task", Version: "v1.0.0"}, spec.Info)
+	assert.Len(t, spec.Paths, 4)
+
+	get := spec.Paths["/tasks/{id}"]["get"]
+	require.NotNil(t, get)
+	assert.Equal(t, "test.swagger.TaskService.GetTask", get.OperationID)
+	assert.Equal(t, []string{"test.swagger.TaskService"}, get.Tags)
+	assert.Equal(t, []*SwaggerParameter{
+		{Name: "id", In: "path", Required: true, Type: "string", Format: "uint64"},
+		{Name: "filter.owner", In: "query", Type: "string"},
+		{Name: "filter.done", In: "query", Type: "boolean"},
+	}, get.Parameters)
+	assert.Equal(t, "#/definitions/test.swagger.TaskResponse", get.Responses["200"].Schema.Ref)
+	assert.Equal(t, "#/definitions/google.rpc.Status", get.Responses["default"].Schema.Ref)
+
+	// additional bindings have their own operation
+	getV2 := spec.Paths["/v2/tasks/{id}"]["get"]
+	require.NotNil(t, getV2)
+	assert.Equal(t, "test.swagger.TaskService.GetTask2", getV2.OperationID)
+
+	create := spec.Paths["/tasks"]["post"]
+	require.NotNil(t, create)
+	assert.Equal(t, []*SwaggerParameter{
+		{Name: "task", In: "body", Required: true, Schema: &SwaggerSchema{Ref: "#/definitions/test.swagger.Task"}},
+		{Name: "owner", In: "query", Type: "string"},
+	}, create.Parameters)
+
+	replace := spec.Paths["/tasks/{task.id}"]["put"]
+	require.NotNil(t, replace)
+	assert.Equal(t, []*SwaggerParameter{
+		{Name: "task.id", In: "path", Required: true, Type: "string", Format: "uint64"},
+		{Name: "body", In: "body", Required: true, Schema: &SwaggerSchema{Ref: "#/definitions/test.swagger.CreateTaskRequest"}},
+	}, replace.Parameters)
+
+	assert.Equal(t, &SwaggerSchema{
+		Type: "object",
+		Properties: map[string]*SwaggerSchema{
+			"id":      {Type: "string", Format: "uint64"},
+			"name":    {Type: "string"},
+			"status":  {Type: "string", Enum: []string{"STATUS_PENDING", "STATUS_DONE"}},
+			"tags":    {Type: "array", Items: &SwaggerSchema{Type: "string"}},
+			"data":    {Type: "object", AdditionalProperties: &SwaggerSchema{Type: "string", Format: "byte"}},
+			"created": {Type: "string", Format: "date-time"},
+			"parent":  {Ref: "#/definitions/test.swagger.Task"},
+		},
+	}, spec.Definitions["test.swagger.Task"])
+	assert.NotContains(t, spec.Definitions, "test.swagger.Task.DataEntry")
+}
+
+func TestGenerateSwaggerInvalidRoute(t *testing.T) {
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:        protov2.String("test/swagger/invalid.proto"),
+		Package:     protov2.String("test.swagger.invalid"),
+		Syntax:      protov2.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{Name: protov2.String("Empty")}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: protov2.String("Service"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				descMethod("Get", ".test.swagger.invalid.Empty", ".test.swagger.invalid.Empty", &annotations.HttpRule{
+					Pattern: &annotations.HttpRule_Get{Get: "/items/{id}"},
+				}),
+			},
+		}},
+	}, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	_, err = GenerateSwagger("invalid", "", fd.Services().Get(0))
+	assert.ErrorContains(t, err, "unknown path parameter id of test.swagger.invalid.Empty")
+}
+
+func TestRegisterSwagger(t *testing.T) {
+	grpcSrv := grpc.NewServer()
+	nodev1.RegisterServiceServer(grpcSrv, &nodev1.UnimplementedServiceServer{})
+
+	s := New(client.Context{}, log.NewLogger(&bytes.Buffer{}), grpcSrv)
+	require.NoError(t, s.registerSwagger())
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.Router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/swagger/swagger.json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var spec Swagger
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+	assert.Equal(t, "2.0", spec.Swagger)
+	// the node service has no gateway routes
+	assert.Empty(t, spec.Paths)
+
+	rec = get("/swagger/swagger.yaml")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var yamlSpec Swagger
+	require.NoError(t, yaml.Unmarshal(rec.Body.Bytes(), &yamlSpec))
+	assert.Equal(t, spec, yamlSpec)
+
+	// the embedded UI
+	rec = get("/swagger/")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "swagger-ui")
+	assert.Equal(t, http.StatusOK, get("/swagger/swagger-ui-bundle.js").Code)
+	assert.Equal(t, http.StatusMovedPermanently, get("/swagger").Code)
+}
+
+func TestServerStartSwagger(t *testing.T) {
+	grpcSrv := grpc.NewServer()
+	nodev1.RegisterServiceServer(grpcSrv, &nodev1.UnimplementedServiceServer{})
+	s := New(client.Context{}, log.NewLogger(&bytes.Buffer{}), grpcSrv)
+
+	// the routes are not shadowed by the gRPC-web handler
+	cfg := config.DefaultConfig()
+	cfg.API.Swagger = true
+	require.True(t, cfg.GRPC.Enable && cfg.GRPCWeb.Enable)
+	url := startServer(t, s, *cfg)
+
+	get := func(path string) (*http.Response, []byte) {
+		resp, err := http.Get(url + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, body
+	}
+
+	resp, body := get("/swagger/swagger.json")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var spec Swagger
+	require.NoError(t, json.Unmarshal(body, &spec))
+	assert.Equal(t, "2.0", spec.Swagger)
+
+	resp, body = get("/swagger/")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "swagger-ui")
+}
@@ -0,0 +1,109 @@
+// Package appdb opens the application database with the backend and the
+// tuning of the app config.
+package appdb
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+)
+
+// Name is the name of the application database in the data directory.
+const Name = "application"
+
+// Open opens the database name in dir with backend. The goleveldb and pebble
+// databases are tuned with the goleveldb and pebble sections of cfg, and a
+// memdb database is kept in memory, dir being ignored.
+func Open(name, dir string, backend dbm.BackendType, cfg config.Config) (dbm.DB, error) {
+	switch backend {
+	case dbm.GoLevelDBBackend:
+		return dbm.NewGoLevelDBWithOpts(name, dir, goLevelDBOptions(cfg.GoLevelDB))
+	case dbm.PebbleDBBackend:
+		return NewPebbleDB(name, dir, pebbleOptions(cfg.Pebble))
+	case dbm.MemDBBackend:
+		return dbm.NewMemDB(), nil
+	default:
+		return dbm.NewDB(name, backend, dir)
+	}
+}
+
+// Options returns the tuning of a database opened with backend and cfg, keyed
+// by the settings of the app config, or nil if the backend is not tuned.
+func Options(backend dbm.BackendType, cfg config.Config) map[string]string {
+	var settings map[string]int
+	switch backend {
+	case dbm.GoLevelDBBackend:
+		settings = map[string]int{
+			"block-cache-size":      cfg.GoLevelDB.BlockCacheSize,
+			"write-buffer-size":     cfg.GoLevelDB.WriteBufferSize,
+			"compaction-table-size": cfg.GoLevelDB.CompactionTableSize,
+			"compaction-l0-trigger": cfg.GoLevelDB.CompactionL0Trigger,
+			"bloom-filter-bits":     cfg.GoLevelDB.BloomFilterBits,
+			"max-open-files":        cfg.GoLevelDB.MaxOpenFiles,
+		}
+	case dbm.PebbleDBBackend:
+		settings = map[string]int{
+			"cache-size":                 cfg.Pebble.CacheSize,
+			"memtable-size":              cfg.Pebble.MemTableSize,
+			"max-concurrent-compactions": cfg.Pebble.MaxConcurrentCompactions,
+			"l0-compaction-threshold":    cfg.Pebble.L0CompactionThreshold,
+			"bloom-filter-bits":          cfg.Pebble.BloomFilterBits,
+			"max-open-files":             cfg.Pebble.MaxOpenFiles,
+		}
+	default:
+		return nil
+	}
+
+	options := make(map[string]string, len(settings))
+	for key, value := range settings {
+		options[key] = strconv.Itoa(value)
+	}
+	return options
+}
+
+// goLevelDBOptions returns the goleveldb options of cfg, the sizes of which
+// are in MiB.
+func goLevelDBOptions(cfg config.GoLevelDBConfig) *opt.Options {
+	o := &opt.Options{
+		BlockCacheCapacity:     cfg.BlockCacheSize * opt.MiB,
+		WriteBuffer:            cfg.WriteBufferSize * opt.MiB,
+		CompactionTableSize:    cfg.CompactionTableSize * opt.MiB,
+		CompactionL0Trigger:    cfg.CompactionL0Trigger,
+		OpenFilesCacheCapacity: cfg.MaxOpenFiles,
+	}
+	if cfg.BloomFilterBits > 0 {
+		o.Filter = filter.NewBloomFilter(cfg.BloomFilterBits)
+	}
+	return o
+}
+
+// pebbleOptions returns the pebble options of cfg, the sizes of which are in
+// MiB. The cache of the options, if any, is released by NewPebbleDB.
+func pebbleOptions(cfg config.PebbleConfig) *pebble.Options {
+	o := &pebble.Options{
+		MemTableSize:          uint64(cfg.MemTableSize) << 20,
+		L0CompactionThreshold: cfg.L0CompactionThreshold,
+		MaxOpenFiles:          cfg.MaxOpenFiles,
+	}
+	if cfg.CacheSize > 0 {
+		o.Cache = pebble.NewCache(int64(cfg.CacheSize) << 20)
+	}
+	if n := cfg.MaxConcurrentCompactions; n > 0 {
+		o.MaxConcurrentCompactions = func() int { return n }
+	}
+	o.EnsureDefaults()
+
+	if cfg.BloomFilterBits > 0 {
+		for i := range o.Levels {
+			o.Levels[i].FilterPolicy = bloom.FilterPolicy(cfg.BloomFilterBits)
+			o.Levels[i].FilterType = pebble.TableFilter
+		}
+	}
+	return o
+}
@@ -0,0 +1,88 @@
+package appdb
+
+import (
+	"fmt"
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+)
+
+var backends = []dbm.BackendType{dbm.GoLevelDBBackend, dbm.PebbleDBBackend, dbm.MemDBBackend}
+
+func TestOpen(t *testing.T) {
+	noBloom := *config.DefaultConfig()
+	noBloom.GoLevelDB.BloomFilterBits, noBloom.Pebble.BloomFilterBits = 0, 0
+
+	for _, cfg := range []config.Config{*config.DefaultConfig(), {}, noBloom} {
+		for _, backend := range backends {
+			t.Run(string(backend), func(t *testing.T) {
+				dir := t.TempDir()
+				db, err := Open(Name, dir, backend, cfg)
+				require.NoError(t, err)
+
+				batch := db.NewBatch()
+				for i := 0; i < 10; i++ {
+					require.NoError(t, batch.Set([]byte(fmt.Sprintf("key%d", i)), []byte{byte(i)}))
+				}
+				require.NoError(t, batch.Write())
+				require.Error(t, batch.Write())
+				require.NoError(t, batch.Close())
+				require.NoError(t, db.Delete([]byte("key9")))
+
+				value, err := db.Get([]byte("key3"))
+				require.NoError(t, err)
+				require.Equal(t, []byte{3}, value)
+				has, err := db.Has([]byte("key9"))
+				require.NoError(t, err)
+				require.False(t, has)
+
+				itr, err := db.ReverseIterator([]byte("key2"), []byte("key5"))
+				require.NoError(t, err)
+				var keys []string
+				for ; itr.Valid(); itr.Next() {
+					keys = append(keys, string(itr.Key()))
+				}
+				require.NoError(t, itr.Close())
+				require.Equal(t, []string{"key4", "key3", "key2"}, keys)
+
+				if backend == dbm.MemDBBackend {
+					return
+				}
+
+				// the data is persisted
+				require.NoError(t, db.Close())
+				db, err = Open(Name, dir, backend, cfg)
+				require.NoError(t, err)
+				value, err = db.Get([]byte("key8"))
+				require.NoError(t, err)
+				require.Equal(t, []byte{8}, value)
+				require.NoError(t, db.Close())
+			})
+		}
+	}
+}
+
+func TestPebbleOptions(t *testing.T) {
+	o := pebbleOptions(config.DefaultConfig().Pebble)
+	defer o.Cache.Unref()
+
+	require.Equal(t, int64(8<<20), o.Cache.MaxSize())
+	require.Equal(t, uint64(4<<20), o.MemTableSize)
+	require.Equal(t, 3, o.MaxConcurrentCompactions())
+	require.Equal(t, "rocksdb.BuiltinBloomFilter", o.Levels[0].FilterPolicy.Name())
+
+	o = pebbleOptions(config.PebbleConfig{})
+	require.Nil(t, o.Levels[0].FilterPolicy)
+	require.Equal(t, 1, o.MaxConcurrentCompactions())
+}
+
+func TestOptions(t *testing.T) {
+	cfg := *config.DefaultConfig()
+	require.Equal(t, "8", Options(dbm.GoLevelDBBackend, cfg)["block-cache-size"])
+	require.Equal(t, "3", Options(dbm.PebbleDBBackend, cfg)["max-concurrent-compactions"])
+	require.Len(t, Options(dbm.PebbleDBBackend, cfg), 6)
+	require.Nil(t, Options(dbm.MemDBBackend, cfg))
+}
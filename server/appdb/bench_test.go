@@ -0,0 +1,139 @@
+package appdb
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+)
+
+// The benchmarks compare the backends and their tunings on the access
+// patterns of the application database, to pick the defaults of the app
+// config:
+//
+//	go test ./server/appdb -run '^$' -bench . -benchmem
+
+const (
+	benchKeys      = 100_000
+	benchValueSize = 256
+	benchBatchSize = 1_000
+)
+
+// benchConfigs returns the tunings benchmarked: the defaults, the backend
+// defaults, the defaults without bloom filters and with larger caches.
+func benchConfigs() map[string]config.Config {
+	defaults := *config.DefaultConfig()
+
+	noBloom := defaults
+	noBloom.GoLevelDB.BloomFilterBits, noBloom.Pebble.BloomFilterBits = 0, 0
+
+	largeCache := defaults
+	largeCache.GoLevelDB.BlockCacheSize, largeCache.Pebble.CacheSize = 64, 64
+	largeCache.GoLevelDB.WriteBufferSize, largeCache.Pebble.MemTableSize = 16, 16
+
+	return map[string]config.Config{
+		"default":     defaults,
+		"backend":     {},
+		"no-bloom":    noBloom,
+		"large-cache": largeCache,
+	}
+}
+
+func benchKey(i int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(i))
+	return key
+}
+
+// runBenchmark runs fn against a database of each backend and tuning, filled
+// with benchKeys keys.
+func runBenchmark(b *testing.B, fn func(b *testing.B, db dbm.DB)) {
+	b.Helper()
+	for _, backend := range backends {
+		for name, cfg := range benchConfigs() {
+			if backend == dbm.MemDBBackend && name != "default" {
+				continue
+			}
+			b.Run(string(backend)+"/"+name, func(b *testing.B) {
+				db, err := Open(Name, b.TempDir(), backend, cfg)
+				require.NoError(b, err)
+				defer db.Close()
+
+				value := make([]byte, benchValueSize)
+				batch := db.NewBatch()
+				for i := 0; i < benchKeys; i++ {
+					require.NoError(b, batch.Set(benchKey(i), value))
+				}
+				require.NoError(b, batch.Write())
+				require.NoError(b, batch.Close())
+
+				b.ResetTimer()
+				fn(b, db)
+			})
+		}
+	}
+}
+
+func BenchmarkSet(b *testing.B) {
+	value := make([]byte, benchValueSize)
+	runBenchmark(b, func(b *testing.B, db dbm.DB) {
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, db.Set(benchKey(benchKeys+i), value))
+		}
+	})
+}
+
+func BenchmarkBatchWrite(b *testing.B) {
+	value := make([]byte, benchValueSize)
+	runBenchmark(b, func(b *testing.B, db dbm.DB) {
+		for i := 0; i < b.N; i++ {
+			batch := db.NewBatch()
+			for j := 0; j < benchBatchSize; j++ {
+				require.NoError(b, batch.Set(benchKey(benchKeys+i*benchBatchSize+j), value))
+			}
+			require.NoError(b, batch.Write())
+			require.NoError(b, batch.Close())
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	runBenchmark(b, func(b *testing.B, db dbm.DB) {
+		r := rand.New(rand.NewSource(0))
+		for i := 0; i < b.N; i++ {
+			value, err := db.Get(benchKey(r.Intn(benchKeys)))
+			require.NoError(b, err)
+			require.NotNil(b, value)
+		}
+	})
+}
+
+func BenchmarkGetMissing(b *testing.B) {
+	runBenchmark(b, func(b *testing.B, db dbm.DB) {
+		r := rand.New(rand.NewSource(0))
+		for i := 0; i < b.N; i++ {
+			value, err := db.Get(benchKey(benchKeys + r.Intn(benchKeys)))
+			require.NoError(b, err)
+			require.Nil(b, value)
+		}
+	})
+}
+
+func BenchmarkIterate(b *testing.B) {
+	runBenchmark(b, func(b *testing.B, db dbm.DB) {
+		r := rand.New(rand.NewSource(0))
+		for i := 0; i < b.N; i++ {
+			start := r.Intn(benchKeys - 100)
+			itr, err := db.Iterator(benchKey(start), benchKey(start+100))
+			require.NoError(b, err)
+			for ; itr.Valid(); itr.Next() {
+				_ = itr.Value()
+			}
+			require.NoError(b, itr.Close())
+		}
+	})
+}
@@ -0,0 +1,322 @@
+package appdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	dbm "github.com/cosmos/cosmos-db"
+)
+
+var (
+	errKeyEmpty    = errors.New("key cannot be empty")
+	errValueNil    = errors.New("value cannot be nil")
+	errBatchClosed = errors.New("batch has been written or closed")
+)
+
+// PebbleDB is a pebble database, opened with the pebble options given to
+// NewPebbleDB rather than the defaults of the cosmos-db pebble backend.
+type PebbleDB struct {
+	db *pebble.DB
+}
+
+var _ dbm.DB = (*PebbleDB)(nil)
+
+// NewPebbleDB opens the pebble database name in dir with opts. The cache of
+// opts, if any, is released once the database holds it.
+func NewPebbleDB(name, dir string, opts *pebble.Options) (*PebbleDB, error) {
+	if opts.Cache != nil {
+		defer opts.Cache.Unref()
+	}
+
+	db, err := pebble.Open(filepath.Join(dir, name+dbm.DBFileSuffix), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &PebbleDB{db: db}, nil
+}
+
+// DB returns the underlying pebble database.
+func (db *PebbleDB) DB() *pebble.DB {
+	return db.db
+}
+
+// Get implements DB.
+func (db *PebbleDB) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errKeyEmpty
+	}
+
+	res, closer, err := db.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer closer.Close()
+
+	return cp(res), nil
+}
+
+// Has implements DB.
+func (db *PebbleDB) Has(key []byte) (bool, error) {
+	bz, err := db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return bz != nil, nil
+}
+
+// Set implements DB.
+func (db *PebbleDB) Set(key, value []byte) error {
+	return db.set(key, value, pebble.NoSync)
+}
+
+// SetSync implements DB.
+func (db *PebbleDB) SetSync(key, value []byte) error {
+	return db.set(key, value, pebble.Sync)
+}
+
+func (db *PebbleDB) set(key, value []byte, wopts *pebble.WriteOptions) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	return db.db.Set(key, value, wopts)
+}
+
+// Delete implements DB.
+func (db *PebbleDB) Delete(key []byte) error {
+	return db.delete(key, pebble.NoSync)
+}
+
+// DeleteSync implements DB.
+func (db *PebbleDB) DeleteSync(key []byte) error {
+	return db.delete(key, pebble.Sync)
+}
+
+func (db *PebbleDB) delete(key []byte, wopts *pebble.WriteOptions) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	return db.db.Delete(key, wopts)
+}
+
+// Close implements DB.
+func (db *PebbleDB) Close() error {
+	return db.db.Close()
+}
+
+// Print implements DB.
+func (db *PebbleDB) Print() error {
+	itr, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", itr.Key(), itr.Value())
+	}
+	return nil
+}
+
+// Stats implements DB.
+func (db *PebbleDB) Stats() map[string]string {
+	return map[string]string{
+		"pebble.metrics": db.db.Metrics().String(),
+	}
+}
+
+// NewBatch implements DB.
+func (db *PebbleDB) NewBatch() dbm.Batch {
+	return &pebbleBatch{batch: db.db.NewBatch()}
+}
+
+// NewBatchWithSize implements DB. Pebble batches cannot be preallocated, so it
+// is NewBatch.
+func (db *PebbleDB) NewBatchWithSize(int) dbm.Batch {
+	return db.NewBatch()
+}
+
+// Iterator implements DB.
+func (db *PebbleDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	return db.newIterator(start, end, false)
+}
+
+// ReverseIterator implements DB.
+func (db *PebbleDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	return db.newIterator(start, end, true)
+}
+
+func (db *PebbleDB) newIterator(start, end []byte, isReverse bool) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+
+	source, err := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	if err != nil {
+		return nil, err
+	}
+	if isReverse {
+		source.Last()
+	} else {
+		source.First()
+	}
+	return &pebbleIterator{source: source, start: start, end: end, isReverse: isReverse}, nil
+}
+
+type pebbleBatch struct {
+	batch *pebble.Batch
+}
+
+var _ dbm.Batch = (*pebbleBatch)(nil)
+
+// Set implements Batch.
+func (b *pebbleBatch) Set(key, value []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	if b.batch == nil {
+		return errBatchClosed
+	}
+	return b.batch.Set(key, value, nil)
+}
+
+// Delete implements Batch.
+func (b *pebbleBatch) Delete(key []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if b.batch == nil {
+		return errBatchClosed
+	}
+	return b.batch.Delete(key, nil)
+}
+
+// Write implements Batch.
+func (b *pebbleBatch) Write() error {
+	return b.commit(pebble.NoSync)
+}
+
+// WriteSync implements Batch.
+func (b *pebbleBatch) WriteSync() error {
+	return b.commit(pebble.Sync)
+}
+
+// commit commits the batch and closes it, so that it cannot be used anymore.
+func (b *pebbleBatch) commit(wopts *pebble.WriteOptions) error {
+	if b.batch == nil {
+		return errBatchClosed
+	}
+	if err := b.batch.Commit(wopts); err != nil {
+		return err
+	}
+	return b.Close()
+}
+
+// Close implements Batch.
+func (b *pebbleBatch) Close() error {
+	if b.batch == nil {
+		return nil
+	}
+	if err := b.batch.Close(); err != nil {
+		return err
+	}
+	b.batch = nil
+	return nil
+}
+
+// GetByteSize implements Batch.
+func (b *pebbleBatch) GetByteSize() (int, error) {
+	if b.batch == nil {
+		return 0, errBatchClosed
+	}
+	return b.batch.Len(), nil
+}
+
+type pebbleIterator struct {
+	source     *pebble.Iterator
+	start, end []byte
+	isReverse  bool
+	isInvalid  bool
+}
+
+var _ dbm.Iterator = (*pebbleIterator)(nil)
+
+// Domain implements Iterator.
+func (itr *pebbleIterator) Domain() ([]byte, []byte) {
+	return itr.start, itr.end
+}
+
+// Valid implements Iterator. Once invalid, the iterator stays invalid.
+func (itr *pebbleIterator) Valid() bool {
+	if itr.isInvalid {
+		return false
+	}
+	if itr.source.Error() != nil || !itr.source.Valid() {
+		itr.isInvalid = true
+		return false
+	}
+
+	key := itr.source.Key()
+	if itr.isReverse && itr.start != nil && bytes.Compare(key, itr.start) < 0 ||
+		!itr.isReverse && itr.end != nil && bytes.Compare(itr.end, key) <= 0 {
+		itr.isInvalid = true
+		return false
+	}
+	return true
+}
+
+// Key implements Iterator.
+func (itr *pebbleIterator) Key() []byte {
+	itr.assertIsValid()
+	return cp(itr.source.Key())
+}
+
+// Value implements Iterator.
+func (itr *pebbleIterator) Value() []byte {
+	itr.assertIsValid()
+	return cp(itr.source.Value())
+}
+
+// Next implements Iterator.
+func (itr *pebbleIterator) Next() {
+	itr.assertIsValid()
+	if itr.isReverse {
+		itr.source.Prev()
+	} else {
+		itr.source.Next()
+	}
+}
+
+// Error implements Iterator.
+func (itr *pebbleIterator) Error() error {
+	return itr.source.Error()
+}
+
+// Close implements Iterator.
+func (itr *pebbleIterator) Close() error {
+	return itr.source.Close()
+}
+
+func (itr *pebbleIterator) assertIsValid() {
+	if !itr.Valid() {
+		panic("iterator is invalid")
+	}
+}
+
+// cp returns a copy of bz, as pebble reuses the buffers it returns.
+func cp(bz []byte) []byte {
+	ret := make([]byte, len(bz))
+	copy(ret, bz)
+	return ret
+}
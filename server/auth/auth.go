@@ -0,0 +1,199 @@
+// Package auth authenticates the callers of the gRPC server with static
+// bearer tokens or JWTs, and authorizes them per method.
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+const bearerPrefix = "bearer "
+
+// Authenticator authenticates callers from the "authorization" metadata of
+// their calls and checks they are allowed to call the method.
+type Authenticator struct {
+	tokens        map[string]string // token to principal
+	jwtParser     *jwt.Parser
+	jwtKey        any
+	publicMethods []string
+	allow         [][]string
+}
+
+// New returns the Authenticator configured by cfg.
+func New(cfg config.AuthConfig) (*Authenticator, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	a := &Authenticator{
+		tokens:        make(map[string]string, len(cfg.Tokens)),
+		publicMethods: cfg.PublicMethods,
+		allow:         cfg.Allow,
+	}
+	for _, token := range cfg.Tokens {
+		a.tokens[token[1]] = token[0]
+	}
+
+	if cfg.JWTKeyFile != "" {
+		key, err := loadJWTKey(cfg.JWTAlgorithm, cfg.JWTKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts := []jwt.ParserOption{jwt.WithValidMethods([]string{cfg.JWTAlgorithm})}
+		if cfg.JWTIssuer != "" {
+			opts = append(opts, jwt.WithIssuer(cfg.JWTIssuer))
+		}
+		if cfg.JWTAudience != "" {
+			opts = append(opts, jwt.WithAudience(cfg.JWTAudience))
+		}
+		a.jwtParser = jwt.NewParser(opts...)
+		a.jwtKey = key
+	}
+
+	return a, nil
+}
+
+func loadJWTKey(algorithm, file string) (any, error) {
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(algorithm, "HS") {
+		secret := bytes.TrimSpace(bz)
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("empty jwt secret in %s", file)
+		}
+		return secret, nil
+	}
+
+	key, err := jwt.ParseECPublicKeyFromPEM(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse jwt public key %s: %w", file, err)
+	}
+	return key, nil
+}
+
+// Authenticate authenticates the caller of fullMethod from the metadata of
+// ctx, and returns ctx carrying its principal. Public methods may be called
+// without credentials, but credentials presented must be valid.
+func (a *Authenticator) Authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+
+	if authorization == "" {
+		if a.isPublic(fullMethod) {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	if len(authorization) < len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "authorization is not a bearer token")
+	}
+	principal, err := a.principal(strings.TrimSpace(authorization[len(bearerPrefix):]))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if !a.isPublic(fullMethod) && !a.isAllowed(fullMethod, principal.Name) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", principal.Name, fullMethod)
+	}
+	return sdktypes.ContextWithPrincipal(ctx, principal), nil
+}
+
+// principal returns the principal of token, a static token or a JWT
+func (a *Authenticator) principal(token string) (sdktypes.Principal, error) {
+	for t, name := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return sdktypes.Principal{Name: name, Scheme: sdktypes.AuthSchemeToken}, nil
+		}
+	}
+
+	if a.jwtParser == nil {
+		return sdktypes.Principal{}, errors.New("invalid bearer token")
+	}
+	claims := jwt.MapClaims{}
+	if _, err := a.jwtParser.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return a.jwtKey, nil
+	}); err != nil {
+		return sdktypes.Principal{}, fmt.Errorf("invalid bearer token: %w", err)
+	}
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return sdktypes.Principal{}, errors.New("invalid bearer token: missing sub claim")
+	}
+	return sdktypes.Principal{Name: subject, Scheme: sdktypes.AuthSchemeJWT, Claims: claims}, nil
+}
+
+func (a *Authenticator) isPublic(fullMethod string) bool {
+	for _, pattern := range a.publicMethods {
+		if config.MatchMethod(pattern, fullMethod) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllowed returns whether principal is listed by the allow entries matching
+// fullMethod, or no entry matches it
+func (a *Authenticator) isAllowed(fullMethod, principal string) bool {
+	matched := false
+	for _, allow := range a.allow {
+		if !config.MatchMethod(allow[0], fullMethod) {
+			continue
+		}
+		matched = true
+		for _, name := range allow[1:] {
+			if name == principal {
+				return true
+			}
+		}
+	}
+	return !matched
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor authenticating the
+// callers of unary methods.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.Authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor authenticating the
+// callers of streaming methods.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.Authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		wrapped := grpcmiddleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+const (
+	publicMethod   = "/grpc.health.v1.Health/Check"
+	queryMethod    = "/pellapp.node.v1.Service/Info"
+	internalMethod = "/pellapp.node.v1.Service/Config"
+)
+
+func newAuthConfig() config.AuthConfig {
+	return config.AuthConfig{
+		Enable:        true,
+		Tokens:        [][]string{{"operator", "operator-token"}, {"reader", "reader-token"}},
+		PublicMethods: config.DefaultPublicMethods,
+		Allow:         [][]string{{internalMethod, "operator", "admin"}},
+	}
+}
+
+func incomingContext(authorization string) context.Context {
+	if authorization == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+}
+
+func writeFile(t *testing.T, name string, bz []byte) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, bz, 0o600))
+	return file
+}
+
+func TestAuthenticateTokens(t *testing.T) {
+	a, err := New(newAuthConfig())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		authorization string
+		method        string
+		expCode       codes.Code
+		expPrincipal  string
+	}{
+		{"public, anonymous", "", publicMethod, codes.OK, ""},
+		{"public, authenticated", "Bearer reader-token", publicMethod, codes.OK, "reader"},
+		{"public, invalid token", "Bearer invalid", publicMethod, codes.Unauthenticated, ""},
+		{"anonymous", "", queryMethod, codes.Unauthenticated, ""},
+		{"not a bearer token", "Basic cmVhZGVy", queryMethod, codes.Unauthenticated, ""},
+		{"invalid token", "Bearer invalid", queryMethod, codes.Unauthenticated, ""},
+		{"authenticated", "Bearer reader-token", queryMethod, codes.OK, "reader"},
+		{"case insensitive scheme", "bearer reader-token", queryMethod, codes.OK, "reader"},
+		{"allowed", "Bearer operator-token", internalMethod, codes.OK, "operator"},
+		{"not allowed", "Bearer reader-token", internalMethod, codes.PermissionDenied, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, err := a.Authenticate(incomingContext(tc.authorization), tc.method)
+			require.Equal(t, tc.expCode, status.Code(err), err)
+			if err != nil {
+				return
+			}
+			principal, ok := sdktypes.PrincipalFromContext(ctx)
+			require.Equal(t, tc.expPrincipal != "", ok)
+			require.Equal(t, tc.expPrincipal, principal.Name)
+			if ok {
+				require.Equal(t, sdktypes.AuthSchemeToken, principal.Scheme)
+			}
+		})
+	}
+}
+
+func TestAuthenticateJWT(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+	secret := []byte("jwt-secret")
+
+	testCases := []struct {
+		name      string
+		algorithm string
+		keyFile   []byte
+		method    jwt.SigningMethod
+		key       any
+	}{
+		{"HS256", "HS256", append(secret, '\n'), jwt.SigningMethodHS256, secret},
+		{"ES256", "ES256", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), jwt.SigningMethodES256, ecKey},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newAuthConfig()
+			cfg.JWTAlgorithm = tc.algorithm
+			cfg.JWTKeyFile = writeFile(t, "jwt.key", tc.keyFile)
+			cfg.JWTIssuer = "issuer"
+			cfg.JWTAudience = "pellapp"
+			a, err := New(cfg)
+			require.NoError(t, err)
+
+			sign := func(claims jwt.MapClaims) string {
+				token, err := jwt.NewWithClaims(tc.method, claims).SignedString(tc.key)
+				require.NoError(t, err)
+				return "Bearer " + token
+			}
+			valid := func() jwt.MapClaims {
+				return jwt.MapClaims{"sub": "admin", "iss": "issuer", "aud": "pellapp", "exp": time.Now().Add(time.Hour).Unix()}
+			}
+
+			ctx, err := a.Authenticate(incomingContext(sign(valid())), internalMethod)
+			require.NoError(t, err)
+			principal, ok := sdktypes.PrincipalFromContext(ctx)
+			require.True(t, ok)
+			require.Equal(t, "admin", principal.Name)
+			require.Equal(t, sdktypes.AuthSchemeJWT, principal.Scheme)
+			require.Equal(t, "issuer", principal.Claims["iss"])
+
+			// static tokens are still accepted
+			_, err = a.Authenticate(incomingContext("Bearer reader-token"), queryMethod)
+			require.NoError(t, err)
+
+			for name, mutate := range map[string]func(jwt.MapClaims){
+				"expired":      func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+				"wrong issuer": func(c jwt.MapClaims) { c["iss"] = "other" },
+				"wrong aud":    func(c jwt.MapClaims) { c["aud"] = "other" },
+				"missing sub":  func(c jwt.MapClaims) { delete(c, "sub") },
+			} {
+				claims := valid()
+				mutate(claims)
+				_, err := a.Authenticate(incomingContext(sign(claims)), queryMethod)
+				require.Equal(t, codes.Unauthenticated, status.Code(err), name)
+			}
+
+			// tokens signed with another algorithm are rejected
+			other, err := jwt.NewWithClaims(jwt.SigningMethodHS384, valid()).SignedString(secret)
+			require.NoError(t, err)
+			_, err = a.Authenticate(incomingContext("Bearer "+other), queryMethod)
+			require.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	testCases := map[string]func(*config.AuthConfig){
+		"no credentials":        func(c *config.AuthConfig) { c.Tokens = nil },
+		"invalid token":         func(c *config.AuthConfig) { c.Tokens = [][]string{{"operator"}} },
+		"unsupported algorithm": func(c *config.AuthConfig) { c.JWTKeyFile, c.JWTAlgorithm = "jwt.key", "RS256" },
+		"invalid method":        func(c *config.AuthConfig) { c.PublicMethods = []string{"pellapp.node.v1.Service"} },
+		"invalid allow":         func(c *config.AuthConfig) { c.Allow = [][]string{{internalMethod}} },
+		"missing key file":      func(c *config.AuthConfig) { c.JWTKeyFile, c.JWTAlgorithm = "missing.key", "HS256" },
+	}
+	for name, mutate := range testCases {
+		cfg := newAuthConfig()
+		mutate(&cfg)
+		_, err := New(cfg)
+		require.Error(t, err, name)
+	}
+}
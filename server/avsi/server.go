@@ -0,0 +1,42 @@
+// Package avsi serves an application over the AVSI socket or gRPC transport
+// of PellDVS, so that PellDVS runs out of the process of the application.
+package avsi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	avsiserver "github.com/0xPellNetwork/pelldvs/avsi/server"
+	avsitypes "github.com/0xPellNetwork/pelldvs/avsi/types"
+)
+
+// AVSI transports, matching the avsi setting of the PellDVS configuration
+const (
+	TransportSocket = "socket"
+	TransportGRPC   = "grpc"
+)
+
+// DefaultAddress is the default address of the AVSI server, matching the
+// default proxy_app setting of the PellDVS configuration.
+const DefaultAddress = "tcp://127.0.0.1:26658"
+
+// StartServer serves app over transport at addr, a TCP or UNIX socket address
+// such as tcp://127.0.0.1:26658 or unix:///var/run/app.sock, until ctx is
+// canceled.
+func StartServer(ctx context.Context, logger log.Logger, addr, transport string, app avsitypes.Application) error {
+	srv, err := avsiserver.NewServer(addr, transport, app)
+	if err != nil {
+		return err
+	}
+	srv.SetLogger(logger)
+
+	logger.Info("starting AVSI server...", "address", addr, "transport", transport)
+	if err := srv.Start(); err != nil {
+		return fmt.Errorf("failed to start AVSI server on %s: %w", addr, err)
+	}
+
+	<-ctx.Done()
+	logger.Info("stopping AVSI server...", "address", addr)
+	return srv.Stop()
+}
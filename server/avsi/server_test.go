@@ -0,0 +1,76 @@
+package avsi
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	avsiclient "github.com/0xPellNetwork/pelldvs/avsi/client"
+	avsitypes "github.com/0xPellNetwork/pelldvs/avsi/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/baseapp"
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
+	"github.com/0xPellNetwork/pellapp-sdk/service/extractor"
+	"github.com/0xPellNetwork/pellapp-sdk/testutil/tasktest"
+)
+
+type taskService struct{}
+
+func (taskService) SubmitTask(_ context.Context, msg *testpb.TestCallMsg) (*testpb.TestCallResult, error) {
+	return &testpb.TestCallResult{TaskId: msg.TaskId, Digest: crypto.Keccak256(msg.Payload), Accepted: true}, nil
+}
+
+func newTaskApp(t *testing.T) *baseapp.BaseApp {
+	app := baseapp.NewBaseApp("test", log.NewLogger(&bytes.Buffer{}), dbm.NewMemDB(), nil)
+	app.SetVersion("v1.2.3")
+	tasktest.Setup(t, app, taskService{})
+	return app
+}
+
+func TestStartServer(t *testing.T) {
+	for _, transport := range []string{TransportSocket, TransportGRPC} {
+		t.Run(transport, func(t *testing.T) {
+			app := newTaskApp(t)
+			addr := "unix://" + filepath.Join(t.TempDir(), "avsi.sock")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			errCh := make(chan error)
+			go func() {
+				errCh <- StartServer(ctx, log.NewLogger(&bytes.Buffer{}), addr, transport, app)
+			}()
+
+			// the client runs as PellDVS would, out of the process of the application
+			var client avsiclient.Client
+			require.Eventually(t, func() bool {
+				c, err := avsiclient.NewClient(addr, transport, true)
+				if !assert.NoError(t, err) || c.Start() != nil {
+					return false
+				}
+				client = c
+				return true
+			}, 5*time.Second, 10*time.Millisecond)
+			defer func() { _ = client.Stop() }()
+
+			info, err := client.Info(context.Background(), &avsitypes.RequestInfo{})
+			require.NoError(t, err)
+			assert.Equal(t, "v1.2.3", info.Version)
+
+			resp, err := client.ProcessDVSRequest(context.Background(), tasktest.Request(t, 7, []byte("payload")))
+			require.NoError(t, err)
+			expected, err := extractor.NewKeccak256().GetDigest(&testpb.TestCallResult{TaskId: 7, Digest: crypto.Keccak256([]byte("payload")), Accepted: true})
+			require.NoError(t, err)
+			assert.Equal(t, expected, resp.ResponseDigest)
+
+			cancel()
+			require.NoError(t, <-errCh)
+		})
+	}
+}
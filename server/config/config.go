@@ -1,10 +1,22 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
+
+	sdktelemetry "github.com/0xPellNetwork/pellapp-sdk/telemetry"
 )
 
 const (
@@ -19,14 +31,27 @@ const (
 	// DefaultGRPCMaxSendMsgSize defines the default gRPC max message size in
 	// bytes the server can send.
 	DefaultGRPCMaxSendMsgSize = math.MaxInt32
+
+	// DefaultGRPCKeepaliveMinTime defines the default minimum interval of the
+	// keepalive pings of gRPC clients, the gRPC default.
+	DefaultGRPCKeepaliveMinTime = 5 * time.Minute
+
+	// DefaultClientRate and DefaultClientBurst define the default rate limit
+	// of each client IP, when rate limits are enabled.
+	DefaultClientRate  = 20
+	DefaultClientBurst = 40
 )
 
 type Config struct {
 	BaseConfig `mapstructure:",squash"`
 
-	API     APIConfig     `mapstructure:"api"`
-	GRPC    GRPCConfig    `mapstructure:"grpc"`
-	GRPCWeb GRPCWebConfig `mapstructure:"grpc-web"`
+	Telemetry telemetry.Config           `mapstructure:"telemetry"`
+	Tracing   sdktelemetry.TracingConfig `mapstructure:"tracing"`
+	API       APIConfig                  `mapstructure:"api"`
+	GRPC      GRPCConfig                 `mapstructure:"grpc"`
+	GRPCWeb   GRPCWebConfig              `mapstructure:"grpc-web"`
+	GoLevelDB GoLevelDBConfig            `mapstructure:"goleveldb"`
+	Pebble    PebbleConfig               `mapstructure:"pebble"`
 }
 
 // BaseConfig defines the server's basic configuration
@@ -34,6 +59,10 @@ type BaseConfig struct {
 	// AppDBBackend defines the type of Database to use for the application and snapshots databases.
 	// An empty string indicates that the PellDVS config's DBBackend value should be used.
 	AppDBBackend string `mapstructure:"app-db-backend"`
+
+	// IndexEvents defines the set of events in the form {eventType}.{attributeKey},
+	// which informs PellDVS what to index. If empty, all events will be indexed.
+	IndexEvents []string `mapstructure:"index-events"`
 }
 
 // APIConfig defines the API listener configuration.
@@ -62,9 +91,14 @@ type APIConfig struct {
 	// RPCMaxBodyBytes defines the PellDVS maximum request body (in bytes)
 	RPCMaxBodyBytes uint `mapstructure:"rpc-max-body-bytes"`
 
-	// TODO: TLS/Proxy configuration.
-	//
-	// Ref: https://github.com/cosmos/cosmos-sdk/issues/6420
+	// RPCMaxHeaderBytes defines the PellDVS maximum request headers size (in bytes)
+	RPCMaxHeaderBytes uint `mapstructure:"rpc-max-header-bytes"`
+
+	// RateLimit defines the rate limits of the API server requests.
+	RateLimit RateLimitConfig `mapstructure:"rate-limit"`
+
+	// TLS defines the TLS configuration of the API server, plaintext when disabled.
+	TLS TLSConfig `mapstructure:"tls"`
 }
 
 // GRPCConfig defines configuration for the gRPC server.
@@ -82,6 +116,316 @@ type GRPCConfig struct {
 	// MaxSendMsgSize defines the max message size in bytes the server can send.
 	// The default value is math.MaxInt32.
 	MaxSendMsgSize int `mapstructure:"max-send-msg-size"`
+
+	// MaxConcurrentStreams defines the max number of concurrent streams, or
+	// calls, of each client connection. 0 means the gRPC default.
+	MaxConcurrentStreams uint32 `mapstructure:"max-concurrent-streams"`
+
+	// Timeout defines the deadline of calls not setting a shorter one, 0
+	// means no deadline.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MethodTimeouts overrides Timeout per method, as [method, timeout] pairs.
+	MethodTimeouts [][]string `mapstructure:"method-timeouts"`
+
+	// Keepalive defines the keepalive parameters and enforcement policy of
+	// the client connections.
+	Keepalive GRPCKeepaliveConfig `mapstructure:"keepalive"`
+
+	// RateLimit defines the rate limits of the gRPC server calls.
+	RateLimit RateLimitConfig `mapstructure:"rate-limit"`
+
+	// TLS defines the TLS configuration of the gRPC server, plaintext when disabled.
+	TLS TLSConfig `mapstructure:"tls"`
+
+	// Auth defines the authentication of the gRPC server calls, also applied
+	// to the gRPC gateway and gRPC-web calls of the API server.
+	Auth AuthConfig `mapstructure:"auth"`
+}
+
+// ParseMethodTimeout parses a [method, timeout] entry of
+// GRPCConfig.MethodTimeouts.
+func ParseMethodTimeout(entry []string) (time.Duration, error) {
+	if len(entry) != 2 {
+		return 0, fmt.Errorf("invalid method timeout %q, expected [method, timeout]", entry)
+	}
+	if err := validateMethodPattern(entry[0]); err != nil {
+		return 0, err
+	}
+	timeout, err := time.ParseDuration(entry[1])
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q of method %s", entry[1], entry[0])
+	}
+	return timeout, nil
+}
+
+// GRPCKeepaliveConfig defines the keepalive parameters of the gRPC server
+// connections, and the keepalive policy enforced on clients. Zero values mean
+// the gRPC defaults.
+type GRPCKeepaliveConfig struct {
+	// MinTime is the minimum interval clients may send keepalive pings at,
+	// connections of clients pinging more often are closed.
+	MinTime time.Duration `mapstructure:"min-time"`
+
+	// PermitWithoutStream allows clients to send keepalive pings without
+	// calls in flight.
+	PermitWithoutStream bool `mapstructure:"permit-without-stream"`
+
+	// MaxConnectionIdle closes connections without calls for this duration.
+	MaxConnectionIdle time.Duration `mapstructure:"max-connection-idle"`
+
+	// MaxConnectionAge closes connections after this duration.
+	MaxConnectionAge time.Duration `mapstructure:"max-connection-age"`
+
+	// MaxConnectionAgeGrace is the time given to the calls in flight of a
+	// connection closed for its age to complete.
+	MaxConnectionAgeGrace time.Duration `mapstructure:"max-connection-age-grace"`
+
+	// Time is the interval of the server keepalive pings of idle connections.
+	Time time.Duration `mapstructure:"time"`
+
+	// Timeout closes connections not acknowledging keepalive pings within
+	// this duration.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// RateLimitConfig defines token bucket rate limits of a server requests, per
+// client IP and per method.
+type RateLimitConfig struct {
+	// Enable enables the rate limits.
+	Enable bool `mapstructure:"enable"`
+
+	// ClientRate is the number of requests per second allowed to each client
+	// IP, 0 means unlimited.
+	ClientRate float64 `mapstructure:"client-rate"`
+
+	// ClientBurst is the number of requests a client IP may burst to.
+	ClientBurst int `mapstructure:"client-burst"`
+
+	// Methods limits the requests of all clients to methods, as [method,
+	// rate, burst] entries. The methods matched by an entry share its bucket,
+	// a method is limited by the first entry matching it.
+	Methods [][]string `mapstructure:"methods"`
+
+	// ExemptClients are the client IPs or CIDRs not rate limited.
+	ExemptClients []string `mapstructure:"exempt-clients"`
+}
+
+// ValidateBasic returns an error if the rate limit configuration is invalid.
+func (c RateLimitConfig) ValidateBasic() error {
+	if !c.Enable {
+		return nil
+	}
+	if c.ClientRate < 0 {
+		return errors.New("client-rate must not be negative")
+	}
+	if c.ClientRate > 0 && c.ClientBurst <= 0 {
+		return errors.New("client-burst must be positive")
+	}
+	for _, method := range c.Methods {
+		if _, _, err := ParseMethodRateLimit(method); err != nil {
+			return err
+		}
+	}
+	for _, client := range c.ExemptClients {
+		if _, err := ParseCIDR(client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseMethodRateLimit parses a [method, rate, burst] entry of
+// RateLimitConfig.Methods.
+func ParseMethodRateLimit(entry []string) (rate float64, burst int, err error) {
+	if len(entry) != 3 {
+		return 0, 0, fmt.Errorf("invalid method rate limit %q, expected [method, rate, burst]", entry)
+	}
+	if err := validateMethodPattern(entry[0]); err != nil {
+		return 0, 0, err
+	}
+	rate, err = strconv.ParseFloat(entry[1], 64)
+	if err != nil || rate <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate %q of method %s", entry[1], entry[0])
+	}
+	burst, err = strconv.Atoi(entry[2])
+	if err != nil || burst <= 0 {
+		return 0, 0, fmt.Errorf("invalid burst %q of method %s", entry[2], entry[0])
+	}
+	return rate, burst, nil
+}
+
+// ParseCIDR parses an IP or a CIDR, an IP being parsed as a single address
+// network.
+func ParseCIDR(s string) (*net.IPNet, error) {
+	if ip := net.ParseIP(s); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client IP or CIDR %q", s)
+	}
+	return ipNet, nil
+}
+
+// TLSConfig defines the TLS configuration of a server. Certificates are
+// reloaded when their files change.
+type TLSConfig struct {
+	// CertFile is the PEM encoded certificate chain of the server, TLS is
+	// enabled when set.
+	CertFile string `mapstructure:"cert-file"`
+
+	// KeyFile is the PEM encoded private key of the server certificate.
+	KeyFile string `mapstructure:"key-file"`
+
+	// ClientCAFile is the PEM encoded bundle of the CAs client certificates
+	// are verified against, when presented.
+	ClientCAFile string `mapstructure:"client-ca-file"`
+
+	// RequireClientCert rejects clients without a certificate signed by one
+	// of the client CAs (mTLS).
+	RequireClientCert bool `mapstructure:"require-client-cert"`
+
+	// LoopbackCertFile is the PEM encoded certificate chain presented to the
+	// server by its clients in the same process, such as the gRPC client of
+	// the node, when client CAs are set. The server certificate is presented
+	// when empty, which must then be usable for client authentication.
+	LoopbackCertFile string `mapstructure:"loopback-cert-file"`
+
+	// LoopbackKeyFile is the PEM encoded private key of the loopback
+	// certificate.
+	LoopbackKeyFile string `mapstructure:"loopback-key-file"`
+}
+
+// Enabled reports whether TLS is enabled.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// ValidateBasic returns an error if the TLS configuration is incomplete.
+func (c TLSConfig) ValidateBasic() error {
+	switch {
+	case c.CertFile == "" && c.KeyFile != "":
+		return errors.New("key-file is set without cert-file")
+	case c.CertFile != "" && c.KeyFile == "":
+		return errors.New("cert-file is set without key-file")
+	case !c.Enabled() && c.ClientCAFile != "":
+		return errors.New("client-ca-file requires cert-file and key-file")
+	case c.RequireClientCert && c.ClientCAFile == "":
+		return errors.New("require-client-cert requires client-ca-file")
+	case (c.LoopbackCertFile == "") != (c.LoopbackKeyFile == ""):
+		return errors.New("loopback-cert-file and loopback-key-file must be set together")
+	case c.LoopbackCertFile != "" && c.ClientCAFile == "":
+		return errors.New("loopback-cert-file requires client-ca-file")
+	}
+	return nil
+}
+
+// AuthConfig defines the authentication of callers, by static bearer tokens
+// or JWTs, and the principals allowed to call methods.
+type AuthConfig struct {
+	// Enable requires callers of non-public methods to authenticate.
+	Enable bool `mapstructure:"enable"`
+
+	// Tokens are the static bearer tokens accepted, as [principal, token]
+	// pairs.
+	Tokens [][]string `mapstructure:"tokens"`
+
+	// JWTAlgorithm is the algorithm JWTs are signed with: HS256, HS384, HS512,
+	// ES256, ES384 or ES512.
+	JWTAlgorithm string `mapstructure:"jwt-algorithm"`
+
+	// JWTKeyFile is the file of the key JWTs are verified with, JWTs are
+	// accepted when set: the shared secret for HS algorithms, the PEM encoded
+	// public key for ES algorithms.
+	JWTKeyFile string `mapstructure:"jwt-key-file"`
+
+	// JWTIssuer, when set, is the required iss claim of JWTs.
+	JWTIssuer string `mapstructure:"jwt-issuer"`
+
+	// JWTAudience, when set, is the required aud claim of JWTs.
+	JWTAudience string `mapstructure:"jwt-audience"`
+
+	// PublicMethods are the methods callable without authentication.
+	PublicMethods []string `mapstructure:"public-methods"`
+
+	// Allow restricts methods to principals, as [method, principal...]
+	// entries. Methods not matched by an entry are callable by any
+	// authenticated principal.
+	Allow [][]string `mapstructure:"allow"`
+}
+
+// DefaultPublicMethods are the methods callable without authentication by
+// default: the gRPC health and reflection services.
+var DefaultPublicMethods = []string{
+	"/grpc.health.v1.Health/*",
+	"/grpc.reflection.v1.ServerReflection/*",
+	"/grpc.reflection.v1alpha.ServerReflection/*",
+}
+
+// ValidateBasic returns an error if the auth configuration is invalid.
+func (c AuthConfig) ValidateBasic() error {
+	if !c.Enable {
+		return nil
+	}
+	if len(c.Tokens) == 0 && c.JWTKeyFile == "" {
+		return errors.New("neither tokens nor jwt-key-file are set")
+	}
+	for _, token := range c.Tokens {
+		if len(token) != 2 || token[0] == "" || token[1] == "" {
+			return fmt.Errorf("invalid token %q, expected a [principal, token] pair", token)
+		}
+	}
+	if c.JWTKeyFile != "" {
+		switch c.JWTAlgorithm {
+		case "HS256", "HS384", "HS512", "ES256", "ES384", "ES512":
+		default:
+			return fmt.Errorf("unsupported jwt-algorithm %q", c.JWTAlgorithm)
+		}
+	}
+	for _, method := range c.PublicMethods {
+		if err := validateMethodPattern(method); err != nil {
+			return err
+		}
+	}
+	for _, allow := range c.Allow {
+		if len(allow) < 2 {
+			return fmt.Errorf("invalid allow entry %q, expected [method, principal...]", allow)
+		}
+		if err := validateMethodPattern(allow[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MatchMethod returns whether fullMethod is matched by pattern: "*", a full
+// method name or "/package.Service/*". HTTP paths are matched the same way,
+// "/prefix/*" matching every path under the prefix.
+func MatchMethod(pattern, fullMethod string) bool {
+	if pattern == "*" || pattern == fullMethod {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(pattern, "/*")
+	return ok && strings.HasPrefix(fullMethod, prefix+"/")
+}
+
+// validateMethodPattern returns an error if pattern is neither "*", a full
+// method name "/package.Service/Method" nor all the methods of a service
+// "/package.Service/*".
+func validateMethodPattern(pattern string) error {
+	if pattern == "*" {
+		return nil
+	}
+	service, method, ok := strings.Cut(strings.TrimPrefix(pattern, "/"), "/")
+	if !strings.HasPrefix(pattern, "/") || !ok || service == "" || method == "" {
+		return fmt.Errorf("invalid method %q, expected /package.Service/Method, /package.Service/* or *", pattern)
+	}
+	return nil
 }
 
 // GRPCWebConfig defines configuration for the gRPC-web server.
@@ -90,21 +434,124 @@ type GRPCWebConfig struct {
 	Enable bool `mapstructure:"enable"`
 }
 
+// GoLevelDBConfig defines the tuning of a goleveldb application database. A
+// zero setting uses the goleveldb default.
+type GoLevelDBConfig struct {
+	// BlockCacheSize is the size of the cache of uncompressed blocks, in MiB.
+	BlockCacheSize int `mapstructure:"block-cache-size"`
+
+	// WriteBufferSize is the size of the memtable, written to a level-0
+	// table once full, in MiB.
+	WriteBufferSize int `mapstructure:"write-buffer-size"`
+
+	// CompactionTableSize is the size of the tables written by compactions,
+	// in MiB.
+	CompactionTableSize int `mapstructure:"compaction-table-size"`
+
+	// CompactionL0Trigger is the number of level-0 tables triggering a
+	// compaction.
+	CompactionL0Trigger int `mapstructure:"compaction-l0-trigger"`
+
+	// BloomFilterBits is the number of bits per key of the bloom filters of
+	// the tables, 0 disabling them.
+	BloomFilterBits int `mapstructure:"bloom-filter-bits"`
+
+	// MaxOpenFiles is the number of table files kept open.
+	MaxOpenFiles int `mapstructure:"max-open-files"`
+}
+
+// PebbleConfig defines the tuning of a pebble application database. A zero
+// setting uses the pebble default.
+type PebbleConfig struct {
+	// CacheSize is the size of the block cache, in MiB.
+	CacheSize int `mapstructure:"cache-size"`
+
+	// MemTableSize is the size of the memtable, flushed to a level-0 table
+	// once full, in MiB.
+	MemTableSize int `mapstructure:"memtable-size"`
+
+	// MaxConcurrentCompactions is the number of compactions run concurrently.
+	MaxConcurrentCompactions int `mapstructure:"max-concurrent-compactions"`
+
+	// L0CompactionThreshold is the number of level-0 tables triggering a
+	// compaction.
+	L0CompactionThreshold int `mapstructure:"l0-compaction-threshold"`
+
+	// BloomFilterBits is the number of bits per key of the bloom filters of
+	// the tables, 0 disabling them.
+	BloomFilterBits int `mapstructure:"bloom-filter-bits"`
+
+	// MaxOpenFiles is the number of table files kept open.
+	MaxOpenFiles int `mapstructure:"max-open-files"`
+}
+
+// ValidateBasic returns an error if a setting is negative.
+func (c GoLevelDBConfig) ValidateBasic() error {
+	return validateNonNegative(map[string]int{
+		"block-cache-size":      c.BlockCacheSize,
+		"write-buffer-size":     c.WriteBufferSize,
+		"compaction-table-size": c.CompactionTableSize,
+		"compaction-l0-trigger": c.CompactionL0Trigger,
+		"bloom-filter-bits":     c.BloomFilterBits,
+		"max-open-files":        c.MaxOpenFiles,
+	})
+}
+
+// ValidateBasic returns an error if a setting is negative.
+func (c PebbleConfig) ValidateBasic() error {
+	return validateNonNegative(map[string]int{
+		"cache-size":                 c.CacheSize,
+		"memtable-size":              c.MemTableSize,
+		"max-concurrent-compactions": c.MaxConcurrentCompactions,
+		"l0-compaction-threshold":    c.L0CompactionThreshold,
+		"bloom-filter-bits":          c.BloomFilterBits,
+		"max-open-files":             c.MaxOpenFiles,
+	})
+}
+
 // GetConfig returns a fully parsed Config object.
 func GetConfig(v *viper.Viper) (Config, error) {
 	conf := DefaultConfig()
-	if err := v.Unmarshal(conf); err != nil {
+	if err := v.Unmarshal(conf, zeroFields); err != nil {
 		return Config{}, fmt.Errorf("error extracting app config: %w", err)
 	}
 	return *conf, nil
 }
 
+// zeroFields replaces the lists and maps of the defaults a config is decoded
+// onto, rather than merging them with the decoded ones.
+func zeroFields(c *mapstructure.DecoderConfig) {
+	c.ZeroFields = true
+}
+
+// reloadableKeys are the settings applied to a running node when the app.toml
+// changes, a key ending with a dot standing for the settings of a table.
+var reloadableKeys = []string{"index-events", "api.enabled-unsafe-cors", "api.rate-limit."}
+
+// IsReloadable reports whether the setting key is applied to a running node
+// when the app.toml changes, rather than requiring a restart.
+func IsReloadable(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range reloadableKeys {
+		if key == k || strings.HasSuffix(k, ".") && strings.HasPrefix(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
 // DefaultConfig returns server's default configuration.
 func DefaultConfig() *Config {
 	return &Config{
 		BaseConfig: BaseConfig{
 			AppDBBackend: "",
+			IndexEvents:  []string{},
+		},
+		Telemetry: telemetry.Config{
+			Enabled:      false,
+			GlobalLabels: [][]string{},
 		},
+		Tracing: sdktelemetry.DefaultTracingConfig(),
 		API: APIConfig{
 			Enable:             true,
 			Swagger:            false,
@@ -112,20 +559,166 @@ func DefaultConfig() *Config {
 			MaxOpenConnections: 1000,
 			RPCReadTimeout:     10,
 			RPCMaxBodyBytes:    1000000,
+			RPCMaxHeaderBytes:  1 << 20,
+			RateLimit: RateLimitConfig{
+				ClientRate:    DefaultClientRate,
+				ClientBurst:   DefaultClientBurst,
+				Methods:       [][]string{},
+				ExemptClients: []string{},
+			},
 		},
 		GRPC: GRPCConfig{
 			Enable:         true,
 			Address:        DefaultGRPCAddress,
 			MaxRecvMsgSize: DefaultGRPCMaxRecvMsgSize,
 			MaxSendMsgSize: DefaultGRPCMaxSendMsgSize,
+			MethodTimeouts: [][]string{},
+			Keepalive: GRPCKeepaliveConfig{
+				MinTime: DefaultGRPCKeepaliveMinTime,
+			},
+			RateLimit: RateLimitConfig{
+				ClientRate:  DefaultClientRate,
+				ClientBurst: DefaultClientBurst,
+				Methods:     [][]string{},
+				// the gRPC gateway of the API server connects from loopback,
+				// its requests are limited by the API server rate limits
+				ExemptClients: []string{"127.0.0.1", "::1"},
+			},
+			Auth: AuthConfig{
+				Tokens:        [][]string{},
+				PublicMethods: append([]string(nil), DefaultPublicMethods...),
+				Allow:         [][]string{},
+			},
 		},
 		GRPCWeb: GRPCWebConfig{
 			Enable: true,
 		},
+		// the tunings of cosmos-db, see the benchmarks of the appdb package
+		GoLevelDB: GoLevelDBConfig{
+			BlockCacheSize:      8,
+			WriteBufferSize:     4,
+			CompactionTableSize: 2,
+			CompactionL0Trigger: 4,
+			BloomFilterBits:     10,
+			MaxOpenFiles:        500,
+		},
+		// unlike cosmos-db, which sets none for pebble, a bloom filter is
+		// used as for goleveldb, for the point reads of the stores
+		Pebble: PebbleConfig{
+			CacheSize:                8,
+			MemTableSize:             4,
+			MaxConcurrentCompactions: 3,
+			L0CompactionThreshold:    4,
+			BloomFilterBits:          10,
+			MaxOpenFiles:             1000,
+		},
 	}
 }
 
-// ValidateBasic returns an error if min-gas-prices field is empty in BaseConfig. Otherwise, it returns nil.
+// ValidateBasic returns an error if the DB backend or its tuning, the server
+// addresses and message sizes, or the TLS, auth, rate limit, timeout or
+// tracing configuration is invalid. Otherwise, it returns nil.
 func (c Config) ValidateBasic() error {
+	if err := validateDBBackend(c.AppDBBackend); err != nil {
+		return err
+	}
+	if c.API.Enable {
+		if err := validateListenAddress(c.API.Address, true); err != nil {
+			return fmt.Errorf("invalid api address: %w", err)
+		}
+	}
+	if c.GRPC.Enable {
+		if err := validateListenAddress(c.GRPC.Address, false); err != nil {
+			return fmt.Errorf("invalid grpc address: %w", err)
+		}
+		if c.GRPC.MaxRecvMsgSize <= 0 {
+			return errors.New("grpc max-recv-msg-size must be positive")
+		}
+		if c.GRPC.MaxSendMsgSize <= 0 {
+			return errors.New("grpc max-send-msg-size must be positive")
+		}
+	}
+	if c.GRPC.Timeout < 0 {
+		return errors.New("grpc timeout must not be negative")
+	}
+	if err := c.API.RateLimit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid api rate limit config: %w", err)
+	}
+	if err := c.GRPC.RateLimit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid grpc rate limit config: %w", err)
+	}
+	for _, timeout := range c.GRPC.MethodTimeouts {
+		if _, err := ParseMethodTimeout(timeout); err != nil {
+			return fmt.Errorf("invalid grpc method timeout: %w", err)
+		}
+	}
+	if err := c.API.TLS.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid api tls config: %w", err)
+	}
+	if err := c.GRPC.TLS.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid grpc tls config: %w", err)
+	}
+	if err := c.GRPC.Auth.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid grpc auth config: %w", err)
+	}
+	if c.Tracing.Enabled {
+		if err := c.Tracing.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid tracing config: %w", err)
+		}
+	}
+	if err := c.GoLevelDB.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid goleveldb config: %w", err)
+	}
+	if err := c.Pebble.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid pebble config: %w", err)
+	}
+	return nil
+}
+
+// validateDBBackend returns an error if backend is neither empty, falling back
+// on the PellDVS db_backend, nor a supported backend.
+func validateDBBackend(backend string) error {
+	switch dbm.BackendType(backend) {
+	case "", dbm.GoLevelDBBackend, dbm.MemDBBackend, dbm.PebbleDBBackend, dbm.RocksDBBackend:
+		return nil
+	}
+	return fmt.Errorf("invalid app-db-backend %q, expected one of %q, %q, %q or %q", backend,
+		dbm.GoLevelDBBackend, dbm.PebbleDBBackend, dbm.RocksDBBackend, dbm.MemDBBackend)
+}
+
+// validateNonNegative returns an error naming the first setting, in key
+// order, of settings which is negative.
+func validateNonNegative(settings map[string]int) error {
+	for _, key := range slices.Sorted(maps.Keys(settings)) {
+		if settings[key] < 0 {
+			return fmt.Errorf("%s must not be negative", key)
+		}
+	}
+	return nil
+}
+
+// validateListenAddress returns an error if address is not a host:port
+// address, or a tcp:// or unix:// URL if withProtocol is set.
+func validateListenAddress(address string, withProtocol bool) error {
+	if withProtocol {
+		protocol, addr, ok := strings.Cut(address, "://")
+		switch {
+		case !ok:
+			return fmt.Errorf("%q is missing the tcp:// or unix:// protocol", address)
+		case protocol == "unix" && addr != "":
+			return nil
+		case protocol != "tcp":
+			return fmt.Errorf("invalid protocol of %q, expected tcp or unix", address)
+		}
+		address = addr
+	}
+
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("invalid port %q", port)
+	}
 	return nil
 }
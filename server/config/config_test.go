@@ -0,0 +1,102 @@
+package config
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigTemplateRoundTrip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.IndexEvents = []string{"task.id", "message.action"}
+	cfg.GRPC.RateLimit.Methods = [][]string{{"/pellapp.node.v1.Service/*", "1", "2"}}
+	cfg.GRPC.MethodTimeouts = [][]string{{"/pellapp.node.v1.Service/*", "5s"}}
+	cfg.GRPC.Auth.Tokens = [][]string{{"operator", "token"}}
+	cfg.GRPC.Auth.Allow = [][]string{{"/pellapp.node.v1.Service/Config", "operator", "admin"}}
+
+	file := filepath.Join(t.TempDir(), "app.toml")
+	WriteConfigFile(file, cfg)
+	v := viper.New()
+	v.SetConfigFile(file)
+	require.NoError(t, v.ReadInConfig())
+
+	parsed, err := GetConfig(v)
+	require.NoError(t, err)
+	require.Equal(t, *cfg, parsed)
+	require.NoError(t, parsed.ValidateBasic())
+}
+
+func TestDefaultConfigPublicMethods(t *testing.T) {
+	cfg := DefaultConfig()
+	require.Equal(t, DefaultPublicMethods, cfg.GRPC.Auth.PublicMethods)
+
+	// editing a config leaves the defaults untouched
+	cfg.GRPC.Auth.PublicMethods[0] = "/edited"
+	require.NotEqual(t, "/edited", DefaultPublicMethods[0])
+	require.Equal(t, DefaultPublicMethods, DefaultConfig().GRPC.Auth.PublicMethods)
+}
+
+func TestMatchMethod(t *testing.T) {
+	const method = "/pellapp.node.v1.Service/Info"
+	require.True(t, MatchMethod("*", method))
+	require.True(t, MatchMethod(method, method))
+	require.True(t, MatchMethod("/pellapp.node.v1.Service/*", method))
+	require.False(t, MatchMethod("/pellapp.node.v1.Service/Status", method))
+	require.False(t, MatchMethod("/pellapp.node.v1.Serv/*", method))
+	require.True(t, MatchMethod("/pellapp/*", "/pellapp/node/v1/info"))
+}
+
+func TestParseMethodRateLimit(t *testing.T) {
+	rate, burst, err := ParseMethodRateLimit([]string{"/pellapp.node.v1.Service/*", "0.5", "2"})
+	require.NoError(t, err)
+	require.Equal(t, 0.5, rate)
+	require.Equal(t, 2, burst)
+
+	for _, entry := range [][]string{
+		{"/pellapp.node.v1.Service/*", "1"},
+		{"pellapp.node.v1.Service", "1", "1"},
+		{"*", "0", "1"},
+		{"*", "1", "-1"},
+	} {
+		_, _, err := ParseMethodRateLimit(entry)
+		require.Error(t, err, entry)
+	}
+}
+
+func TestParseMethodTimeout(t *testing.T) {
+	timeout, err := ParseMethodTimeout([]string{"*", "1m30s"})
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Second, timeout)
+
+	_, err = ParseMethodTimeout([]string{"*", "0s"})
+	require.Error(t, err)
+	_, err = ParseMethodTimeout([]string{"*"})
+	require.Error(t, err)
+}
+
+func TestParseCIDR(t *testing.T) {
+	for s, ip := range map[string]string{"127.0.0.1": "127.0.0.1", "::1": "::1", "10.0.0.0/8": "10.1.2.3"} {
+		ipNet, err := ParseCIDR(s)
+		require.NoError(t, err)
+		require.True(t, ipNet.Contains(net.ParseIP(ip)), s)
+	}
+	ipNet, err := ParseCIDR("127.0.0.1")
+	require.NoError(t, err)
+	require.False(t, ipNet.Contains(net.ParseIP("127.0.0.2")))
+
+	_, err = ParseCIDR("localhost")
+	require.Error(t, err)
+}
+
+func TestIsReloadable(t *testing.T) {
+	for _, key := range []string{"index-events", "api.enabled-unsafe-cors", "api.rate-limit.client-rate", "API.Rate-Limit.Methods"} {
+		require.True(t, IsReloadable(key), key)
+	}
+	for _, key := range []string{"api.address", "api.rate-limit", "grpc.rate-limit.client-rate", "app-db-backend"} {
+		require.False(t, IsReloadable(key), key)
+	}
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Difference is a setting of a config file differing from its default.
+type Difference struct {
+	// Key is the key of the setting, its dotted path.
+	Key string `json:"key"`
+	// Value is the value of the setting, nil if missing from the file.
+	Value any `json:"value"`
+	// Default is the default value of the setting, nil if the setting is
+	// unknown.
+	Default any `json:"default"`
+}
+
+// ReadConfigFile reads the TOML config file at path.
+func ReadConfigFile(path string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("toml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return v, nil
+}
+
+// ReadConfigTOML reads a TOML config from bz.
+func ReadConfigTOML(bz []byte) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigType("toml")
+	if err := v.ReadConfig(bytes.NewReader(bz)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DefaultConfigViper returns the default config rendered with the config
+// template, as read from a config file.
+func DefaultConfigViper() (*viper.Viper, error) {
+	var buffer bytes.Buffer
+	if err := configTemplate.Execute(&buffer, DefaultConfig()); err != nil {
+		return nil, err
+	}
+	return ReadConfigTOML(buffer.Bytes())
+}
+
+// ParseValue parses a value given on the command line as a TOML value, e.g.
+// true, 10 or ["a", "b"], or as a string if it is not one.
+func ParseValue(s string) any {
+	v, err := ReadConfigTOML([]byte("value = " + s))
+	if err != nil {
+		return s
+	}
+	return v.Get("value")
+}
+
+// Diff returns the settings of v differing from those of defaults, the
+// settings missing from v and the unknown ones, sorted by key.
+func Diff(v, defaults *viper.Viper) []Difference {
+	var diffs []Difference
+	for _, key := range unionKeys(v, defaults) {
+		value, defaultValue := lookup(v, key), lookup(defaults, key)
+		if !reflect.DeepEqual(value, defaultValue) {
+			diffs = append(diffs, Difference{Key: key, Value: value, Default: defaultValue})
+		}
+	}
+	return diffs
+}
+
+// UnknownKeys returns the keys of v unknown to defaults, sorted.
+func UnknownKeys(v, defaults *viper.Viper) []string {
+	var unknown []string
+	for _, key := range v.AllKeys() {
+		if !isKey(defaults, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// File is a TOML config file rendered from a template, e.g. the app.toml.
+type File struct {
+	// Name is the name of the file in the config directory.
+	Name string
+	// Defaults returns the default settings of the file.
+	Defaults func() (*viper.Viper, error)
+	// Validate returns an error if the settings of v are invalid.
+	Validate func(v *viper.Viper) error
+	// Write renders the config of the settings of v to path with the
+	// current template.
+	Write func(path string, v *viper.Viper) error
+}
+
+// AppFile is the app.toml, rendered with the config template.
+var AppFile = File{
+	Name:     "app.toml",
+	Defaults: DefaultConfigViper,
+	Validate: func(v *viper.Viper) error {
+		conf, err := GetConfig(v)
+		if err != nil {
+			return err
+		}
+		return conf.ValidateBasic()
+	},
+	Write: func(path string, v *viper.Viper) error {
+		conf, err := GetConfig(v)
+		if err != nil {
+			return err
+		}
+		WriteConfigFile(path, conf)
+		return nil
+	},
+}
+
+// Get returns the value of key, a setting or a table, in the file at path, or
+// its default if the file misses it.
+func (f File) Get(path, key string) (any, error) {
+	v, err := ReadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if v.IsSet(key) {
+		return v.Get(key), nil
+	}
+
+	defaults, err := f.Defaults()
+	if err != nil {
+		return nil, err
+	}
+	if defaults.IsSet(key) {
+		return defaults.Get(key), nil
+	}
+	return nil, fmt.Errorf("unknown key %q of %s", key, f.Name)
+}
+
+// Diff returns the settings of the file at path differing from the defaults.
+func (f File) Diff(path string) ([]Difference, error) {
+	v, err := ReadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := f.Defaults()
+	if err != nil {
+		return nil, err
+	}
+	return Diff(v, defaults), nil
+}
+
+// Set sets key of the file at path to value, parsed with ParseValue, and
+// rewrites the file. The file is not written if key is unknown, the file has
+// unknown settings, e.g. custom sections, which rewriting it with the
+// template would drop, or the resulting settings are invalid.
+func (f File) Set(path, key, value string) error {
+	v, err := ReadConfigFile(path)
+	if err != nil {
+		return err
+	}
+	defaults, err := f.Defaults()
+	if err != nil {
+		return err
+	}
+	if !isKey(defaults, key) {
+		return fmt.Errorf("unknown key %q of %s", key, f.Name)
+	}
+	if unknown := UnknownKeys(v, defaults); len(unknown) > 0 {
+		return fmt.Errorf("%s has unknown settings, which rewriting it would drop: %s; edit it by hand or drop them with migrate",
+			f.Name, strings.Join(unknown, ", "))
+	}
+
+	v.Set(key, ParseValue(value))
+	if err := f.Validate(v); err != nil {
+		return err
+	}
+	return f.Write(path, v)
+}
+
+// Migrate rewrites the file at path with the current template, keeping its
+// values and filling the settings it misses with their defaults. The
+// original file is saved to backupPath, unless empty. It returns the unknown
+// keys of the file, which are dropped.
+func (f File) Migrate(path, backupPath string) ([]string, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := ReadConfigTOML(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defaults, err := f.Defaults()
+	if err != nil {
+		return nil, err
+	}
+
+	if backupPath != "" {
+		if err := os.WriteFile(backupPath, bz, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	if err := f.Write(path, v); err != nil {
+		return nil, err
+	}
+	return UnknownKeys(v, defaults), nil
+}
+
+// unionKeys returns the keys of both v and defaults, sorted
+func unionKeys(v, defaults *viper.Viper) []string {
+	set := make(map[string]struct{})
+	for _, key := range append(v.AllKeys(), defaults.AllKeys()...) {
+		set[key] = struct{}{}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isKey reports whether key is a setting of v
+func isKey(v *viper.Viper, key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range v.AllKeys() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the value of key in v, nil if unset
+func lookup(v *viper.Viper, key string) any {
+	if !v.IsSet(key) {
+		return nil
+	}
+	return v.Get(key)
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeAppFile(t *testing.T, conf *Config) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "app.toml")
+	WriteConfigFile(file, conf)
+	return file
+}
+
+func TestParseValue(t *testing.T) {
+	require.Equal(t, true, ParseValue("true"))
+	require.Equal(t, int64(10), ParseValue("10"))
+	require.Equal(t, []any{"a", "b"}, ParseValue(`["a", "b"]`))
+	require.Equal(t, "0.0.0.0:9090", ParseValue("0.0.0.0:9090"))
+	require.Equal(t, "goleveldb", ParseValue(`"goleveldb"`))
+}
+
+func TestAppFileGetSet(t *testing.T) {
+	file := writeAppFile(t, DefaultConfig())
+
+	value, err := AppFile.Get(file, "grpc.address")
+	require.NoError(t, err)
+	require.Equal(t, DefaultGRPCAddress, value)
+
+	require.NoError(t, AppFile.Set(file, "grpc.address", "127.0.0.1:9999"))
+	require.NoError(t, AppFile.Set(file, "api.enable", "false"))
+	require.NoError(t, AppFile.Set(file, "grpc.rate-limit.exempt-clients", `["10.0.0.0/8"]`))
+
+	v, err := ReadConfigFile(file)
+	require.NoError(t, err)
+	conf, err := GetConfig(v)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:9999", conf.GRPC.Address)
+	require.False(t, conf.API.Enable)
+	require.Equal(t, []string{"10.0.0.0/8"}, conf.GRPC.RateLimit.ExemptClients)
+
+	// invalid settings and unknown keys are not written
+	require.ErrorContains(t, AppFile.Set(file, "grpc.address", "9999"), "invalid grpc address")
+	require.ErrorContains(t, AppFile.Set(file, "app-db-backend", "cleveldb"), "invalid app-db-backend")
+	require.ErrorContains(t, AppFile.Set(file, "grpc.adress", "127.0.0.1:9999"), `unknown key "grpc.adress"`)
+	_, err = AppFile.Get(file, "grpc.adress")
+	require.ErrorContains(t, err, "unknown key")
+
+	value, err = AppFile.Get(file, "grpc.address")
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:9999", value)
+}
+
+func TestAppFileSetUnknownSettings(t *testing.T) {
+	// a custom section of the app, unknown to the template
+	file := writeAppFile(t, DefaultConfig())
+	custom := "\n[oracle]\nfeed = \"eth-usd\"\n"
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(custom)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	before, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, AppFile.Set(file, "grpc.address", "127.0.0.1:9999"), "app.toml has unknown settings, which rewriting it would drop: oracle.feed")
+	after, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, string(before), string(after))
+}
+
+func TestAppFileDiff(t *testing.T) {
+	conf := DefaultConfig()
+	conf.GRPC.Address = "127.0.0.1:9999"
+	file := writeAppFile(t, conf)
+
+	diffs, err := AppFile.Diff(file)
+	require.NoError(t, err)
+	require.Equal(t, []Difference{{Key: "grpc.address", Value: "127.0.0.1:9999", Default: DefaultGRPCAddress}}, diffs)
+}
+
+func TestAppFileMigrate(t *testing.T) {
+	// an old file, missing the grpc timeout, with a removed setting
+	old := `app-db-backend = "pebbledb"
+removed = true
+
+[grpc]
+address = "127.0.0.1:9999"
+`
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.toml")
+	require.NoError(t, os.WriteFile(file, []byte(old), 0o644))
+
+	diffs, err := AppFile.Diff(file)
+	require.NoError(t, err)
+	require.Contains(t, diffs, Difference{Key: "removed", Value: true})
+	require.Contains(t, diffs, Difference{Key: "grpc.timeout", Default: "0s"})
+
+	unknown, err := AppFile.Migrate(file, file+".bak")
+	require.NoError(t, err)
+	require.Equal(t, []string{"removed"}, unknown)
+
+	backup, err := os.ReadFile(file + ".bak")
+	require.NoError(t, err)
+	require.Equal(t, old, string(backup))
+
+	// the user values are kept and the file has every setting
+	diffs, err = AppFile.Diff(file)
+	require.NoError(t, err)
+	require.Equal(t, []Difference{
+		{Key: "app-db-backend", Value: "pebbledb", Default: ""},
+		{Key: "grpc.address", Value: "127.0.0.1:9999", Default: DefaultGRPCAddress},
+	}, diffs)
+	bz, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(bz), "# This is a TOML config file."))
+}
+
+func TestConfigValidateBasic(t *testing.T) {
+	require.NoError(t, DefaultConfig().ValidateBasic())
+
+	for name, tc := range map[string]struct {
+		malleate func(*Config)
+		err      string
+	}{
+		"db backend":        {func(c *Config) { c.AppDBBackend = "boltdb" }, "invalid app-db-backend"},
+		"api protocol":      {func(c *Config) { c.API.Address = "0.0.0.0:8123" }, "invalid api address"},
+		"api port":          {func(c *Config) { c.API.Address = "tcp://0.0.0.0:http" }, "invalid api address"},
+		"grpc address":      {func(c *Config) { c.GRPC.Address = "localhost" }, "invalid grpc address"},
+		"grpc message size": {func(c *Config) { c.GRPC.MaxRecvMsgSize = 0 }, "max-recv-msg-size"},
+		"goleveldb cache":   {func(c *Config) { c.GoLevelDB.BlockCacheSize = -1 }, "invalid goleveldb config: block-cache-size"},
+		"pebble memtable":   {func(c *Config) { c.Pebble.MemTableSize = -1 }, "invalid pebble config: memtable-size"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			conf := DefaultConfig()
+			tc.malleate(conf)
+			require.ErrorContains(t, conf.ValidateBasic(), tc.err)
+		})
+	}
+
+	// the addresses of disabled servers are not validated
+	conf := DefaultConfig()
+	conf.API.Enable, conf.API.Address = false, ""
+	conf.AppDBBackend = "memdb"
+	require.NoError(t, conf.ValidateBasic())
+	conf.API.Enable, conf.API.Address = true, "unix:///tmp/api.sock"
+	require.NoError(t, conf.ValidateBasic())
+}
+
+func TestDiff(t *testing.T) {
+	defaults, err := ReadConfigTOML([]byte(`
+a = 1
+b = "x"
+
+[t]
+c = [1, 2]
+`))
+	require.NoError(t, err)
+	v, err := ReadConfigTOML([]byte(`
+a = 2
+b = "x"
+unknown = true
+`))
+	require.NoError(t, err)
+
+	// changed, missing and unknown settings, sorted by key
+	require.Equal(t, []Difference{
+		{Key: "a", Value: int64(2), Default: int64(1)},
+		{Key: "t.c", Value: nil, Default: []any{int64(1), int64(2)}},
+		{Key: "unknown", Value: true, Default: nil},
+	}, Diff(v, defaults))
+	require.Empty(t, Diff(defaults, defaults))
+	require.Equal(t, []string{"unknown"}, UnknownKeys(v, defaults))
+}
@@ -19,8 +19,88 @@ const DefaultConfigTemplate = `# This is a TOML config file.
 # AppDBBackend defines the database backend type to use for the application and snapshots DBs.
 # An empty string indicates that a fallback will be used.
 # The fallback is the db_backend value set in PellDVS's config.toml.
+# With memdb, the application state is kept in memory and lost on exit.
 app-db-backend = "{{ .BaseConfig.AppDBBackend }}"
 
+# IndexEvents defines the set of events in the form {eventType}.{attributeKey},
+# which informs PellDVS what to index. If empty, all events will be indexed.
+# It is reloaded while the node runs.
+#
+# Example:
+# ["message.sender", "message.recipient"]
+index-events = [{{ range .BaseConfig.IndexEvents }}"{{ . }}", {{ end }}]
+
+
+###############################################################################
+###                         Telemetry Configuration                         ###
+###############################################################################
+
+[telemetry]
+
+# Prefixed with keys to separate services.
+service-name = "{{ .Telemetry.ServiceName }}"
+
+# Enabled enables the application telemetry functionality. When enabled,
+# an in-memory sink is also enabled by default. Operators may also enabled
+# other sinks such as Prometheus.
+# Metrics are served at the API server /metrics endpoint.
+enabled = {{ .Telemetry.Enabled }}
+
+# Enable prefixing gauge values with hostname.
+enable-hostname = {{ .Telemetry.EnableHostname }}
+
+# Enable adding hostname to labels.
+enable-hostname-label = {{ .Telemetry.EnableHostnameLabel }}
+
+# Enable adding service to labels.
+enable-service-label = {{ .Telemetry.EnableServiceLabel }}
+
+# PrometheusRetentionTime, when positive, enables a Prometheus metrics sink.
+prometheus-retention-time = {{ .Telemetry.PrometheusRetentionTime }}
+
+# GlobalLabels defines a global set of name/value label tuples applied to all
+# metrics emitted using the wrapper functions defined in telemetry package.
+#
+# Example:
+# [["chain_id", "1337"]]
+global-labels = [{{ range $k, $v := .Telemetry.GlobalLabels }}
+  ["{{index $v 0 }}", "{{ index $v 1}}"],{{ end }}
+]
+
+# MetricsSink defines the type of metrics sink to use.
+metrics-sink = "{{ .Telemetry.MetricsSink }}"
+
+# StatsdAddr defines the address of a statsd server to send metrics to.
+# Only utilized if MetricsSink is set to "statsd" or "dogstatsd".
+statsd-addr = "{{ .Telemetry.StatsdAddr }}"
+
+# DatadogHostname defines the hostname to use when emitting metrics to
+# Datadog. Only utilized if MetricsSink is set to "dogstatsd".
+datadog-hostname = "{{ .Telemetry.DatadogHostname }}"
+
+###############################################################################
+###                          Tracing Configuration                          ###
+###############################################################################
+
+[tracing]
+
+# Enabled enables OpenTelemetry tracing of AVSI calls, message handlers, store
+# operations and gRPC queries. Trace contexts propagated by gRPC clients in the
+# W3C traceparent header are continued.
+enabled = {{ .Tracing.Enabled }}
+
+# ServiceName is the service.name resource attribute of the spans.
+service-name = "{{ .Tracing.ServiceName }}"
+
+# Exporter defines where spans are exported: "stdout" or "file".
+exporter = "{{ .Tracing.Exporter }}"
+
+# File is the path spans are appended to by the file exporter, as JSON. It is
+# relative to the application home directory unless absolute.
+file = "{{ .Tracing.File }}"
+
+# SampleRatio is the ratio of traces sampled, between 0 and 1.
+sample-ratio = {{ .Tracing.SampleRatio }}
 
 ###############################################################################
 ###                           API Configuration                             ###
@@ -49,9 +129,61 @@ rpc-write-timeout = {{ .API.RPCWriteTimeout }}
 # RPCMaxBodyBytes defines the PellDVS maximum request body (in bytes).
 rpc-max-body-bytes = {{ .API.RPCMaxBodyBytes }}
 
+# RPCMaxHeaderBytes defines the PellDVS maximum request headers size (in bytes).
+rpc-max-header-bytes = {{ .API.RPCMaxHeaderBytes }}
+
 # EnableUnsafeCORS defines if CORS should be enabled (unsafe - use it at your own risk).
 enabled-unsafe-cors = {{ .API.EnableUnsafeCORS }}
 
+# TLS enables TLS when cert-file and key-file are set, certificates are
+# reloaded when their files change.
+[api.tls]
+
+# CertFile is the PEM encoded certificate chain of the server.
+cert-file = "{{ .API.TLS.CertFile }}"
+
+# KeyFile is the PEM encoded private key of the server certificate.
+key-file = "{{ .API.TLS.KeyFile }}"
+
+# ClientCAFile is the PEM encoded bundle of the CAs client certificates are
+# verified against, when presented.
+client-ca-file = "{{ .API.TLS.ClientCAFile }}"
+
+# RequireClientCert rejects clients without a certificate signed by one of the
+# client CAs (mTLS).
+require-client-cert = {{ .API.TLS.RequireClientCert }}
+
+# RateLimit limits the rate of requests with token buckets, per client IP and
+# per URL path. Rejected requests are answered with 429 Too Many Requests and
+# counted by the rate_limit_rejected metric.
+[api.rate-limit]
+
+# Enable enables the rate limits.
+enable = {{ .API.RateLimit.Enable }}
+
+# ClientRate is the number of requests per second allowed to each client IP,
+# 0 means unlimited.
+client-rate = {{ .API.RateLimit.ClientRate }}
+
+# ClientBurst is the number of requests a client IP may burst to.
+client-burst = {{ .API.RateLimit.ClientBurst }}
+
+# Methods limits the requests of all clients to URL paths, as [path, rate,
+# burst] entries. The paths matched by an entry share its bucket, a path is
+# limited by the first entry matching it. "/prefix/*" matches every path under
+# the prefix.
+#
+# Example:
+# [["/pellapp/*", "50", "100"]]
+methods = [{{ range .API.RateLimit.Methods }}
+  [{{ range $i, $v := . }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}],{{ end }}
+]
+
+# ExemptClients are the client IPs or CIDRs not rate limited.
+exempt-clients = [{{ range .API.RateLimit.ExemptClients }}
+  "{{ . }}",{{ end }}
+]
+
 ###############################################################################
 ###                           gRPC Configuration                            ###
 ###############################################################################
@@ -72,6 +204,159 @@ max-recv-msg-size = "{{ .GRPC.MaxRecvMsgSize }}"
 # The default value is math.MaxInt32.
 max-send-msg-size = "{{ .GRPC.MaxSendMsgSize }}"
 
+# MaxConcurrentStreams defines the max number of concurrent streams, or calls,
+# of each client connection. 0 means the gRPC default.
+max-concurrent-streams = {{ .GRPC.MaxConcurrentStreams }}
+
+# Timeout defines the deadline of calls not setting a shorter one, 0 means no
+# deadline.
+timeout = "{{ .GRPC.Timeout }}"
+
+# MethodTimeouts overrides the timeout per method, as [method, timeout] pairs.
+# A method is given the timeout of the first entry matching it.
+#
+# Example:
+# [["/pellapp.node.v1.Service/*", "5s"]]
+method-timeouts = [{{ range $k, $v := .GRPC.MethodTimeouts }}
+  ["{{ index $v 0 }}", "{{ index $v 1 }}"],{{ end }}
+]
+
+# TLS enables TLS when cert-file and key-file are set, certificates are
+# reloaded when their files change. When client certificates are required,
+# the server certificate must also be valid as a client certificate signed by
+# one of the client CAs: the API server gRPC gateway connects with it.
+[grpc.tls]
+
+# CertFile is the PEM encoded certificate chain of the server.
+cert-file = "{{ .GRPC.TLS.CertFile }}"
+
+# KeyFile is the PEM encoded private key of the server certificate.
+key-file = "{{ .GRPC.TLS.KeyFile }}"
+
+# ClientCAFile is the PEM encoded bundle of the CAs client certificates are
+# verified against, when presented.
+client-ca-file = "{{ .GRPC.TLS.ClientCAFile }}"
+
+# RequireClientCert rejects clients without a certificate signed by one of the
+# client CAs (mTLS).
+require-client-cert = {{ .GRPC.TLS.RequireClientCert }}
+
+# LoopbackCertFile is the PEM encoded certificate chain presented by the gRPC
+# client of the node itself when client CAs are set, e.g. issued for client
+# authentication by one of the client CAs. The server certificate is presented
+# when empty, which must then be valid for client authentication.
+loopback-cert-file = "{{ .GRPC.TLS.LoopbackCertFile }}"
+
+# LoopbackKeyFile is the PEM encoded private key of the loopback certificate.
+loopback-key-file = "{{ .GRPC.TLS.LoopbackKeyFile }}"
+
+# Keepalive defines the keepalive parameters of the client connections, and the
+# keepalive policy enforced on clients. 0 means the gRPC default.
+[grpc.keepalive]
+
+# MinTime is the minimum interval clients may send keepalive pings at, the
+# connections of clients pinging more often are closed.
+min-time = "{{ .GRPC.Keepalive.MinTime }}"
+
+# PermitWithoutStream allows clients to send keepalive pings without calls in
+# flight.
+permit-without-stream = {{ .GRPC.Keepalive.PermitWithoutStream }}
+
+# MaxConnectionIdle closes connections without calls for this duration.
+max-connection-idle = "{{ .GRPC.Keepalive.MaxConnectionIdle }}"
+
+# MaxConnectionAge closes connections after this duration.
+max-connection-age = "{{ .GRPC.Keepalive.MaxConnectionAge }}"
+
+# MaxConnectionAgeGrace is the time given to the calls in flight of a
+# connection closed for its age to complete.
+max-connection-age-grace = "{{ .GRPC.Keepalive.MaxConnectionAgeGrace }}"
+
+# Time is the interval of the server keepalive pings of idle connections.
+time = "{{ .GRPC.Keepalive.Time }}"
+
+# Timeout closes connections not acknowledging keepalive pings within this
+# duration.
+timeout = "{{ .GRPC.Keepalive.Timeout }}"
+
+# RateLimit limits the rate of calls with token buckets, per client IP and per
+# method. Rejected calls fail with RESOURCE_EXHAUSTED and are counted by the
+# rate_limit_rejected metric.
+[grpc.rate-limit]
+
+# Enable enables the rate limits.
+enable = {{ .GRPC.RateLimit.Enable }}
+
+# ClientRate is the number of calls per second allowed to each client IP, 0
+# means unlimited.
+client-rate = {{ .GRPC.RateLimit.ClientRate }}
+
+# ClientBurst is the number of calls a client IP may burst to.
+client-burst = {{ .GRPC.RateLimit.ClientBurst }}
+
+# Methods limits the calls of all clients to methods, as [method, rate, burst]
+# entries. The methods matched by an entry share its bucket, a method is
+# limited by the first entry matching it.
+#
+# Example:
+# [["/pellapp.node.v1.Service/*", "10", "20"]]
+methods = [{{ range .GRPC.RateLimit.Methods }}
+  [{{ range $i, $v := . }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}],{{ end }}
+]
+
+# ExemptClients are the client IPs or CIDRs not rate limited. The gRPC gateway
+# of the API server connects from loopback, its requests being limited by the
+# API server rate limits.
+exempt-clients = [{{ range .GRPC.RateLimit.ExemptClients }}
+  "{{ . }}",{{ end }}
+]
+
+# Auth requires callers to authenticate with an "authorization: Bearer <token>"
+# header, the token being a static token or a JWT. It also applies to the API
+# server gRPC gateway and gRPC-web, which forward the Authorization header.
+# Methods are matched as /package.Service/Method, /package.Service/* or *.
+[grpc.auth]
+
+# Enable requires callers of non-public methods to authenticate.
+enable = {{ .GRPC.Auth.Enable }}
+
+# Tokens are the static bearer tokens accepted, as [principal, token] pairs.
+#
+# Example:
+# [["operator", "<token>"]]
+tokens = [{{ range $k, $v := .GRPC.Auth.Tokens }}
+  ["{{ index $v 0 }}", "{{ index $v 1 }}"],{{ end }}
+]
+
+# JWTAlgorithm is the algorithm JWTs are signed with: HS256, HS384, HS512,
+# ES256, ES384 or ES512.
+jwt-algorithm = "{{ .GRPC.Auth.JWTAlgorithm }}"
+
+# JWTKeyFile is the file of the key JWTs are verified with, JWTs are accepted
+# when set: the shared secret for HS algorithms, the PEM encoded public key for
+# ES algorithms. The sub claim of a JWT is its principal.
+jwt-key-file = "{{ .GRPC.Auth.JWTKeyFile }}"
+
+# JWTIssuer, when set, is the required iss claim of JWTs.
+jwt-issuer = "{{ .GRPC.Auth.JWTIssuer }}"
+
+# JWTAudience, when set, is the required aud claim of JWTs.
+jwt-audience = "{{ .GRPC.Auth.JWTAudience }}"
+
+# PublicMethods are the methods callable without authentication.
+public-methods = [{{ range .GRPC.Auth.PublicMethods }}
+  "{{ . }}",{{ end }}
+]
+
+# Allow restricts methods to principals, as [method, principal...] entries.
+# Methods not matched by an entry are callable by any authenticated principal.
+#
+# Example:
+# [["/pellapp.node.v1.Service/Config", "operator"]]
+allow = [{{ range .GRPC.Auth.Allow }}
+  [{{ range $i, $v := . }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}],{{ end }}
+]
+
 ###############################################################################
 ###                        gRPC Web Configuration                           ###
 ###############################################################################
@@ -83,6 +368,61 @@ max-send-msg-size = "{{ .GRPC.MaxSendMsgSize }}"
 # NOTE: gRPC-Web uses the same address as the API server.
 enable = {{ .GRPCWeb.Enable }}
 
+###############################################################################
+###                         GoLevelDB Configuration                         ###
+###############################################################################
+
+# The tuning of the application database with the goleveldb backend.
+# A zero setting uses the goleveldb default.
+[goleveldb]
+
+# BlockCacheSize is the size of the cache of uncompressed blocks, in MiB.
+block-cache-size = {{ .GoLevelDB.BlockCacheSize }}
+
+# WriteBufferSize is the size of the memtable, written to a level-0 table once
+# full, in MiB.
+write-buffer-size = {{ .GoLevelDB.WriteBufferSize }}
+
+# CompactionTableSize is the size of the tables written by compactions, in MiB.
+compaction-table-size = {{ .GoLevelDB.CompactionTableSize }}
+
+# CompactionL0Trigger is the number of level-0 tables triggering a compaction.
+compaction-l0-trigger = {{ .GoLevelDB.CompactionL0Trigger }}
+
+# BloomFilterBits is the number of bits per key of the bloom filters of the
+# tables, 0 disabling them.
+bloom-filter-bits = {{ .GoLevelDB.BloomFilterBits }}
+
+# MaxOpenFiles is the number of table files kept open.
+max-open-files = {{ .GoLevelDB.MaxOpenFiles }}
+
+###############################################################################
+###                          Pebble Configuration                           ###
+###############################################################################
+
+# The tuning of the application database with the pebbledb backend.
+# A zero setting uses the pebble default.
+[pebble]
+
+# CacheSize is the size of the block cache, in MiB.
+cache-size = {{ .Pebble.CacheSize }}
+
+# MemTableSize is the size of the memtable, flushed to a level-0 table once
+# full, in MiB.
+memtable-size = {{ .Pebble.MemTableSize }}
+
+# MaxConcurrentCompactions is the number of compactions run concurrently.
+max-concurrent-compactions = {{ .Pebble.MaxConcurrentCompactions }}
+
+# L0CompactionThreshold is the number of level-0 tables triggering a compaction.
+l0-compaction-threshold = {{ .Pebble.L0CompactionThreshold }}
+
+# BloomFilterBits is the number of bits per key of the bloom filters of the
+# tables, 0 disabling them.
+bloom-filter-bits = {{ .Pebble.BloomFilterBits }}
+
+# MaxOpenFiles is the number of table files kept open.
+max-open-files = {{ .Pebble.MaxOpenFiles }}
 `
 
 var configTemplate *template.Template
@@ -101,7 +441,7 @@ func init() {
 // application.
 func ParseConfig(v *viper.Viper) (*Config, error) {
 	conf := DefaultConfig()
-	err := v.Unmarshal(conf)
+	err := v.Unmarshal(conf, zeroFields)
 
 	return conf, err
 }
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	pelldvscfg "github.com/0xPellNetwork/pelldvs/config"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+)
+
+const (
+	appConfigName     = "app"
+	pellDVSConfigName = "config"
+)
+
+// pellDVSFile is the config.toml of PellDVS, rendered with its config
+// template.
+var pellDVSFile = config.File{
+	Name: "config.toml",
+	Defaults: func() (*viper.Viper, error) {
+		f, err := os.CreateTemp("", "config-*.toml")
+		if err != nil {
+			return nil, err
+		}
+		f.Close()
+		defer os.Remove(f.Name())
+
+		pelldvscfg.WriteConfigFile(f.Name(), pelldvscfg.DefaultConfig())
+		return config.ReadConfigFile(f.Name())
+	},
+	Validate: func(v *viper.Viper) error {
+		conf, err := parsePellDVSConfig(v)
+		if err != nil {
+			return err
+		}
+		return conf.ValidateBasic()
+	},
+	Write: func(path string, v *viper.Viper) error {
+		conf, err := parsePellDVSConfig(v)
+		if err != nil {
+			return err
+		}
+		pelldvscfg.WriteConfigFile(path, conf)
+		return nil
+	},
+}
+
+// ConfigCmd returns the command reading, editing, validating and migrating
+// the app.toml and the PellDVS config.toml of the home directory.
+func ConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Get, set, diff, migrate and validate the app.toml and config.toml",
+		Long: `Get, set, diff, migrate and validate the configs of the home directory: the
+app.toml, named "app", and the PellDVS config.toml, named "config".
+
+The app.toml is rendered with the default app config template, so apps with a
+custom app config template should not set or migrate it with these commands.`,
+	}
+
+	cmd.AddCommand(
+		configGetCmd(),
+		configSetCmd(),
+		configDiffCmd(),
+		configMigrateCmd(),
+		configValidateCmd(),
+	)
+
+	return cmd
+}
+
+func configGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "get [app|config] [key]",
+		Short:   "Print a setting, or a table, of a config",
+		Example: "get app grpc.address",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, path, err := configFile(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			value, err := file.Get(path, args[1])
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd, value)
+		},
+	}
+}
+
+func configSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set [app|config] [key] [value]",
+		Short: "Set a setting of a config",
+		Long: `Set a setting of a config and rewrite it. The value is parsed as a TOML value,
+e.g. true, 10 or '["10.0.0.0/8"]', or else as a string. The config is not
+written if the key is unknown, the config has unknown settings, e.g. custom
+sections, which rewriting it would drop, or the resulting settings are invalid.`,
+		Example: "set app grpc.address 127.0.0.1:9090",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, path, err := configFile(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			return file.Set(path, args[1], args[2])
+		},
+	}
+}
+
+func configDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff [app|config]",
+		Short: "Print the settings of a config differing from the defaults",
+		Long: `Print the settings of a config differing from the defaults, the settings it
+misses, with a null value, and the unknown ones, with a null default.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, path, err := configFile(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			diffs, err := file.Diff(path)
+			if err != nil {
+				return err
+			}
+			if diffs == nil {
+				diffs = []config.Difference{}
+			}
+			return printJSON(cmd, diffs)
+		},
+	}
+}
+
+func configMigrateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate [app|config]",
+		Short: "Rewrite a config with the current template",
+		Long: `Rewrite a config with the current template, keeping its values and filling
+the settings it misses with their defaults. The original config is saved with
+a .bak suffix, and its unknown settings, which are dropped, are printed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, path, err := configFile(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			unknown, err := file.Migrate(path, path+".bak")
+			if err != nil {
+				return err
+			}
+			for _, key := range unknown {
+				cmd.PrintErrf("dropped unknown setting %s of %s\n", key, file.Name)
+			}
+			cmd.Printf("migrated %s, saved the original to %s.bak\n", path, path)
+			return nil
+		},
+	}
+}
+
+func configValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [app|config]",
+		Short: "Validate a config, or both if none is given",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := []string{appConfigName, pellDVSConfigName}
+			if len(args) == 1 {
+				names = args
+			}
+
+			for _, name := range names {
+				file, path, err := configFile(cmd, name)
+				if err != nil {
+					return err
+				}
+				v, err := config.ReadConfigFile(path)
+				if err != nil {
+					return err
+				}
+				if err := file.Validate(v); err != nil {
+					return fmt.Errorf("invalid %s: %w", path, err)
+				}
+
+				defaults, err := file.Defaults()
+				if err != nil {
+					return err
+				}
+				for _, key := range config.UnknownKeys(v, defaults) {
+					cmd.PrintErrf("unknown setting %s of %s\n", key, file.Name)
+				}
+				cmd.Printf("%s is valid\n", path)
+			}
+			return nil
+		},
+	}
+}
+
+// configFile returns the config named name and its path in the config
+// directory of the home directory.
+func configFile(cmd *cobra.Command, name string) (config.File, string, error) {
+	var file config.File
+	switch name {
+	case appConfigName:
+		file = config.AppFile
+	case pellDVSConfigName:
+		file = pellDVSFile
+	default:
+		return config.File{}, "", fmt.Errorf("unknown config %q, expected %q or %q", name, appConfigName, pellDVSConfigName)
+	}
+
+	rootDir := GetServerContextFromCmd(cmd).Config.RootDir
+	return file, filepath.Join(rootDir, pelldvscfg.DefaultConfigDir, file.Name), nil
+}
+
+// parsePellDVSConfig returns the PellDVS config of the settings of v.
+func parsePellDVSConfig(v *viper.Viper) (*pelldvscfg.Config, error) {
+	conf := pelldvscfg.DefaultConfig()
+	err := v.Unmarshal(conf, func(c *mapstructure.DecoderConfig) {
+		c.ZeroFields = true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error extracting PellDVS config: %w", err)
+	}
+	return conf, nil
+}
+
+func printJSON(cmd *cobra.Command, v any) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	cmd.Println(string(out))
+	return nil
+}
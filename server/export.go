@@ -0,0 +1,79 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPellNetwork/pellapp-sdk/client/flags"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/server/types"
+)
+
+const (
+	flagHeight          = "height"
+	flagModulesToExport = "modules-to-export"
+	flagOutputDocument  = "output-document"
+)
+
+// ExportCmd dumps the application state as JSON, the genesis states of its
+// modules along with the height they were exported at.
+func ExportCmd(appExporter types.AppExporter, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export state to JSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			serverCtx := GetServerContextFromCmd(cmd)
+
+			if appExporter == nil {
+				return errors.New("app exporter is not set")
+			}
+
+			cfg, err := config.GetConfig(serverCtx.Viper)
+			if err != nil {
+				return err
+			}
+			db, err := NewDBOpener(cfg)(serverCtx.Config.RootDir, GetAppDBBackend(serverCtx.Viper))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			traceWriter, cleanup, err := setupTraceWriter(serverCtx)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			height, _ := cmd.Flags().GetInt64(flagHeight)
+			modulesToExport, _ := cmd.Flags().GetStringSlice(flagModulesToExport)
+			outputDocument, _ := cmd.Flags().GetString(flagOutputDocument)
+
+			exported, err := appExporter(serverCtx.Logger, db, traceWriter, height, serverCtx.Viper, modulesToExport)
+			if err != nil {
+				return fmt.Errorf("error exporting state: %w", err)
+			}
+
+			out, err := json.MarshalIndent(exported, "", "  ")
+			if err != nil {
+				return err
+			}
+			if outputDocument == "" {
+				cmd.Println(string(out))
+				return nil
+			}
+			return os.WriteFile(outputDocument, out, 0o600)
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Int64(flagHeight, -1, "Export state from a particular height (-1 means latest height)")
+	cmd.Flags().StringSlice(flagModulesToExport, []string{}, "Comma-separated list of modules to export. If empty, will export all modules")
+	cmd.Flags().String(flagOutputDocument, "", "Exported state is written to the given file instead of STDOUT")
+
+	return cmd
+}
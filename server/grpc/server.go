@@ -4,20 +4,35 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/0xPellNetwork/pelldvs-libs/log"
 	"github.com/cosmos/cosmos-sdk/codec"
 	_ "github.com/cosmos/cosmos-sdk/types/tx/amino" // Import amino.proto file for reflection
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 
 	"github.com/0xPellNetwork/pellapp-sdk/client"
+	"github.com/0xPellNetwork/pellapp-sdk/server/auth"
 	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/server/ratelimit"
+	"github.com/0xPellNetwork/pellapp-sdk/server/tlsconfig"
 	"github.com/0xPellNetwork/pellapp-sdk/server/types"
 )
 
 // NewGRPCServer returns a correctly configured and initialized gRPC server.
 // Note, the caller is responsible for starting the server. See StartGRPCServer.
-func NewGRPCServer(clientCtx client.Context, app types.Application, cfg config.GRPCConfig) (*grpc.Server, error) {
+// When TLS is enabled in cfg, reloading certificates failures are logged to
+// logger.
+func NewGRPCServer(clientCtx client.Context, logger log.Logger, app types.Application, cfg config.GRPCConfig) (*grpc.Server, error) {
 	maxSendMsgSize := cfg.MaxSendMsgSize
 	if maxSendMsgSize == 0 {
 		maxSendMsgSize = config.DefaultGRPCMaxSendMsgSize
@@ -28,16 +43,156 @@ func NewGRPCServer(clientCtx client.Context, app types.Application, cfg config.G
 		maxRecvMsgSize = config.DefaultGRPCMaxRecvMsgSize
 	}
 
-	grpcSrv := grpc.NewServer(
+	opts := []grpc.ServerOption{
 		grpc.ForceServerCodec(codec.NewProtoCodec(clientCtx.InterfaceRegistry).GRPCCodec()),
 		grpc.MaxSendMsgSize(maxSendMsgSize),
 		grpc.MaxRecvMsgSize(maxRecvMsgSize),
+	}
+	if cfg.TLS.Enabled() {
+		tlsCfg, err := tlsconfig.New(cfg.TLS, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load grpc tls config: %w", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+	if cfg.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(cfg.MaxConcurrentStreams))
+	}
+	opts = append(opts,
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.Keepalive.MinTime,
+			PermitWithoutStream: cfg.Keepalive.PermitWithoutStream,
+		}),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     cfg.Keepalive.MaxConnectionIdle,
+			MaxConnectionAge:      cfg.Keepalive.MaxConnectionAge,
+			MaxConnectionAgeGrace: cfg.Keepalive.MaxConnectionAgeGrace,
+			Time:                  cfg.Keepalive.Time,
+			Timeout:               cfg.Keepalive.Timeout,
+		}),
 	)
 
+	// calls are rate limited first, before any work is done for them
+	if cfg.RateLimit.Enable {
+		limiter, err := ratelimit.New(cfg.RateLimit, "grpc")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load grpc rate limit config: %w", err)
+		}
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(limiter.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(limiter.StreamServerInterceptor()),
+		)
+	}
+	if cfg.Timeout > 0 || len(cfg.MethodTimeouts) > 0 {
+		interceptor, err := timeoutInterceptor(cfg.Timeout, cfg.MethodTimeouts)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.ChainUnaryInterceptor(interceptor))
+	}
+	if cfg.Auth.Enable {
+		authenticator, err := auth.New(cfg.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load grpc auth config: %w", err)
+		}
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(authenticator.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(authenticator.StreamServerInterceptor()),
+		)
+	}
+
+	grpcSrv := grpc.NewServer(opts...)
+
 	app.RegisterGRPCServer(grpcSrv)
+
+	// register the standard reflection services, resolving the descriptors
+	// registered with gogoproto as well as protoregistry
+	reflectionOpts := reflection.ServerOptions{
+		Services:           grpcSrv,
+		DescriptorResolver: gogoproto.HybridResolver,
+	}
+	reflectionv1.RegisterServerReflectionServer(grpcSrv, reflection.NewServerV1(reflectionOpts))
+	reflectionv1alpha.RegisterServerReflectionServer(grpcSrv, reflection.NewServer(reflectionOpts))
+
 	return grpcSrv, nil
 }
 
+// RegisterHealthServer registers the standard health service on grpcSrv,
+// reporting every service registered so far along with the server as a whole
+// ("") as serving. Shutdown of the returned server reports them as not
+// serving, e.g. once the node starts draining.
+func RegisterHealthServer(grpcSrv *grpc.Server) *health.Server {
+	healthSrv := health.NewServer()
+	healthgrpc.RegisterHealthServer(grpcSrv, healthSrv)
+	for name := range grpcSrv.GetServiceInfo() {
+		healthSrv.SetServingStatus(name, healthgrpc.HealthCheckResponse_SERVING)
+	}
+	return healthSrv
+}
+
+// NewGatewayInterceptor returns the interceptor of the gRPC gateway calls,
+// which are routed to the app in process rather than through the gRPC server.
+// It applies the timeouts and authentication of the gRPC server, but not its
+// rate limit, the API server having its own. It returns nil if there is none.
+func NewGatewayInterceptor(cfg config.GRPCConfig) (grpc.UnaryServerInterceptor, error) {
+	var interceptors []grpc.UnaryServerInterceptor
+	if cfg.Timeout > 0 || len(cfg.MethodTimeouts) > 0 {
+		interceptor, err := timeoutInterceptor(cfg.Timeout, cfg.MethodTimeouts)
+		if err != nil {
+			return nil, err
+		}
+		interceptors = append(interceptors, interceptor)
+	}
+	if cfg.Auth.Enable {
+		authenticator, err := auth.New(cfg.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load grpc auth config: %w", err)
+		}
+		interceptors = append(interceptors, authenticator.UnaryServerInterceptor())
+	}
+
+	if len(interceptors) == 0 {
+		return nil, nil
+	}
+	return grpcmiddleware.ChainUnaryServer(interceptors...), nil
+}
+
+// timeoutInterceptor returns an interceptor setting the deadline of unary
+// calls to the timeout of their method, unless they set a shorter one. The
+// timeout of a method is the first of methodTimeouts matching it, otherwise
+// timeout.
+func timeoutInterceptor(timeout time.Duration, methodTimeouts [][]string) (grpc.UnaryServerInterceptor, error) {
+	timeouts := make([]time.Duration, len(methodTimeouts))
+	for i, entry := range methodTimeouts {
+		t, err := config.ParseMethodTimeout(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grpc method timeout: %w", err)
+		}
+		timeouts[i] = t
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		methodTimeout := timeout
+		for i, entry := range methodTimeouts {
+			if config.MatchMethod(entry[0], info.FullMethod) {
+				methodTimeout = timeouts[i]
+				break
+			}
+		}
+		if methodTimeout <= 0 {
+			return handler(ctx, req)
+		}
+
+		deadline := time.Now().Add(methodTimeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithDeadline(ctx, deadline)
+		defer cancel()
+		return handler(ctx, req)
+	}, nil
+}
+
 // StartGRPCServer starts the provided gRPC server on the address specified in cfg.
 //
 // Note, this creates a blocking process if the server is started successfully.
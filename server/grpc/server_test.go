@@ -0,0 +1,342 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/0xPellNetwork/pellapp-sdk/baseapp"
+	"github.com/0xPellNetwork/pellapp-sdk/client"
+	"github.com/0xPellNetwork/pellapp-sdk/client/grpc/node"
+	nodev1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/node/v1"
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
+	"github.com/0xPellNetwork/pellapp-sdk/server/api"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/testutil/tlstest"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+type testApp struct {
+	*baseapp.BaseApp
+}
+
+func (testApp) RegisterAPIRoutes(*api.Server, config.APIConfig) {}
+
+func (app testApp) RegisterNodeService(_ client.Context, cfg config.Config) {
+	node.RegisterNodeService(app.GRPCQueryRouter(), app, cfg)
+}
+
+func newTestApp() (testApp, *config.Config) {
+	app := testApp{baseapp.NewBaseApp("test", log.NewLogger(&bytes.Buffer{}), dbm.NewMemDB(), nil)}
+	router := baseapp.NewGRPCQueryRouter()
+	router.SetInterfaceRegistry(codectypes.NewInterfaceRegistry())
+	app.SetGRPCQueryRouter(router)
+	return app, config.DefaultConfig()
+}
+
+// startTestServer serves app over gRPC and returns a connection to it
+func startTestServer(t *testing.T, app testApp, cfg *config.Config) *grpc.ClientConn {
+	t.Helper()
+	return dialTestServer(t, serveTestServer(t, app, cfg), insecure.NewCredentials())
+}
+
+// serveTestServer serves app over gRPC and returns the server address
+func serveTestServer(t *testing.T, app testApp, cfg *config.Config) string {
+	t.Helper()
+	addr, _ := serveTestServerHealth(t, app, cfg)
+	return addr
+}
+
+// serveTestServerHealth serves app over gRPC and returns the server address
+// along with its health service
+func serveTestServerHealth(t *testing.T, app testApp, cfg *config.Config) (string, *health.Server) {
+	t.Helper()
+	app.RegisterNodeService(client.Context{}, *cfg)
+
+	grpcSrv, err := NewGRPCServer(client.Context{InterfaceRegistry: codectypes.NewInterfaceRegistry()}, log.NewNopLogger(), app, cfg.GRPC)
+	require.NoError(t, err)
+	healthSrv := RegisterHealthServer(grpcSrv)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = grpcSrv.Serve(lis) }()
+	t.Cleanup(grpcSrv.Stop)
+	return lis.Addr().String(), healthSrv
+}
+
+func dialTestServer(t *testing.T, addr string, creds credentials.TransportCredentials) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec.NewProtoCodec(codectypes.NewInterfaceRegistry()).GRPCCodec())),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestGRPCServerNodeService(t *testing.T) {
+	app, cfg := newTestApp()
+	app.SetVersion("v1.2.3")
+	app.SetIndexEvents([]string{"task.id", "message.action"})
+	conn := startTestServer(t, app, cfg)
+	ctx := context.Background()
+
+	node := nodev1.NewServiceClient(conn)
+	info, err := node.Info(ctx, &nodev1.InfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "test", info.AppName)
+	assert.Equal(t, "v1.2.3", info.AppVersion)
+	assert.NotNil(t, info.VersionInfo)
+
+	status, err := node.Status(ctx, &nodev1.StatusRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, app.LastBlockHeight(), status.Height)
+
+	nodeCfg, err := node.Config(ctx, &nodev1.ConfigRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"message.action", "task.id"}, nodeCfg.IndexEvents)
+	assert.Equal(t, cfg.GRPC.Address, nodeCfg.GrpcAddress)
+
+	healthClient := healthgrpc.NewHealthClient(conn)
+	for _, service := range []string{"", "pellapp.node.v1.Service"} {
+		res, err := healthClient.Check(ctx, &healthgrpc.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthgrpc.HealthCheckResponse_SERVING, res.Status)
+	}
+}
+
+func TestGRPCServerHealthShutdown(t *testing.T) {
+	app, cfg := newTestApp()
+	addr, healthSrv := serveTestServerHealth(t, app, cfg)
+	healthClient := healthgrpc.NewHealthClient(dialTestServer(t, addr, insecure.NewCredentials()))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the server reports not serving once draining starts, while serving calls
+	healthSrv.Shutdown()
+	for _, service := range []string{"", "pellapp.node.v1.Service"} {
+		res, err := healthClient.Check(ctx, &healthgrpc.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthgrpc.HealthCheckResponse_NOT_SERVING, res.Status)
+	}
+}
+
+func TestGRPCServerReflection(t *testing.T) {
+	app, cfg := newTestApp()
+	conn := startTestServer(t, app, cfg)
+
+	stream, err := reflectionv1.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&reflectionv1.ServerReflectionRequest{
+		MessageRequest: &reflectionv1.ServerReflectionRequest_ListServices{},
+	}))
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range res.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, "pellapp.node.v1.Service")
+	assert.Contains(t, services, "grpc.health.v1.Health")
+
+	// descriptors registered with gogoproto are resolved
+	require.NoError(t, stream.Send(&reflectionv1.ServerReflectionRequest{
+		MessageRequest: &reflectionv1.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "pellapp.node.v1.Service"},
+	}))
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.NotEmpty(t, res.GetFileDescriptorResponse().GetFileDescriptorProto())
+	var file descriptorpb.FileDescriptorProto
+	require.NoError(t, protov2.Unmarshal(res.GetFileDescriptorResponse().GetFileDescriptorProto()[0], &file))
+	assert.Equal(t, "pellapp/node/v1/query.proto", file.GetName())
+}
+
+func TestGRPCServerMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t, "ca")
+	certFile, keyFile, caFile := ca.WriteFiles(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, err := tls.X509KeyPair(ca.Issue(t, "client", x509.ExtKeyUsageClientAuth))
+	require.NoError(t, err)
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.Cert)
+
+	app, cfg := newTestApp()
+	cfg.GRPC.TLS = config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, RequireClientCert: true}
+	addr := serveTestServer(t, app, cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	testCases := []struct {
+		name    string
+		creds   credentials.TransportCredentials
+		wantErr bool
+	}{
+		{"plaintext", insecure.NewCredentials(), true},
+		{"no client cert", credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: rootCAs}), true},
+		{"client cert", credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: rootCAs, Certificates: []tls.Certificate{clientCert}}), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := dialTestServer(t, addr, tc.creds)
+			_, err := healthgrpc.NewHealthClient(conn).Check(ctx, &healthgrpc.HealthCheckRequest{})
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// whoamiServiceDesc echoes the name of the principal calling it
+var whoamiServiceDesc = &grpc.ServiceDesc{
+	ServiceName: "test.service.TestService",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Echo",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				in := new(testpb.TestMsg)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.service.TestService/Echo"}
+				return interceptor(ctx, in, info, func(ctx context.Context, _ any) (any, error) {
+					principal, _ := sdktypes.UnwrapContext(ctx).Principal()
+					return &testpb.TestMsg{TypeUrl: principal.Name}, nil
+				})
+			},
+		},
+	},
+}
+
+func TestGRPCServerAuth(t *testing.T) {
+	app, cfg := newTestApp()
+	app.GRPCQueryRouter().RegisterService(whoamiServiceDesc, struct{}{})
+	cfg.GRPC.Auth.Enable = true
+	cfg.GRPC.Auth.Tokens = [][]string{{"operator", "operator-token"}, {"reader", "reader-token"}}
+	cfg.GRPC.Auth.Allow = [][]string{{"/pellapp.node.v1.Service/Config", "operator"}}
+	conn := startTestServer(t, app, cfg)
+
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	}
+
+	// health is public
+	_, err := healthgrpc.NewHealthClient(conn).Check(context.Background(), &healthgrpc.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// the principal is carried by the sdk context of queries
+	echo := func(ctx context.Context) (*testpb.TestMsg, error) {
+		res := new(testpb.TestMsg)
+		err := conn.Invoke(ctx, "/test.service.TestService/Echo", &testpb.TestMsg{}, res)
+		return res, err
+	}
+	_, err = echo(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	res, err := echo(withToken("reader-token"))
+	require.NoError(t, err)
+	assert.Equal(t, "reader", res.TypeUrl)
+
+	node := nodev1.NewServiceClient(conn)
+	_, err = node.Info(withToken("reader-token"), &nodev1.InfoRequest{})
+	require.NoError(t, err)
+	_, err = node.Config(withToken("reader-token"), &nodev1.ConfigRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = node.Config(withToken("operator-token"), &nodev1.ConfigRequest{})
+	require.NoError(t, err)
+}
+
+func TestGRPCServerRateLimit(t *testing.T) {
+	app, cfg := newTestApp()
+	cfg.GRPC.RateLimit = config.RateLimitConfig{Enable: true, ClientRate: 0.001, ClientBurst: 2}
+	conn := startTestServer(t, app, cfg)
+	ctx := context.Background()
+
+	healthClient := healthgrpc.NewHealthClient(conn)
+	_, err := healthClient.Check(ctx, &healthgrpc.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = nodev1.NewServiceClient(conn).Info(ctx, &nodev1.InfoRequest{})
+	require.NoError(t, err)
+	_, err = healthClient.Check(ctx, &healthgrpc.HealthCheckRequest{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	interceptor, err := timeoutInterceptor(time.Minute, [][]string{
+		{"/pellapp.node.v1.Service/*", "5s"},
+	})
+	require.NoError(t, err)
+
+	remaining := func(ctx context.Context, method string) time.Duration {
+		var remaining time.Duration
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ any) (any, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			remaining = time.Until(deadline)
+			return nil, nil
+		})
+		require.NoError(t, err)
+		return remaining
+	}
+
+	assert.InDelta(t, 5*time.Second, remaining(context.Background(), "/pellapp.node.v1.Service/Info"), float64(time.Second))
+	assert.InDelta(t, time.Minute, remaining(context.Background(), "/grpc.health.v1.Health/Check"), float64(time.Second))
+
+	// shorter deadlines of callers are kept
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.LessOrEqual(t, remaining(ctx, "/pellapp.node.v1.Service/Info"), time.Second)
+
+	_, err = timeoutInterceptor(0, [][]string{{"*", "soon"}})
+	require.Error(t, err)
+}
+
+func TestNewGatewayInterceptor(t *testing.T) {
+	cfg := config.DefaultConfig().GRPC
+	cfg.Timeout = 0
+	cfg.MethodTimeouts = nil
+	interceptor, err := NewGatewayInterceptor(cfg)
+	require.NoError(t, err)
+	assert.Nil(t, interceptor)
+
+	cfg.Timeout = time.Minute
+	cfg.Auth.Enable = true
+	cfg.Auth.Tokens = [][]string{{"reader", "reader-token"}}
+	interceptor, err = NewGatewayInterceptor(cfg)
+	require.NoError(t, err)
+
+	call := func(ctx context.Context) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pellapp.node.v1.Service/Info"}, func(ctx context.Context, _ any) (any, error) {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok)
+			return nil, nil
+		})
+		return err
+	}
+	assert.Equal(t, codes.Unauthenticated, status.Code(call(context.Background())))
+	require.NoError(t, call(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer reader-token"))))
+}
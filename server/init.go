@@ -0,0 +1,193 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pelldvscfg "github.com/0xPellNetwork/pelldvs/config"
+	"github.com/0xPellNetwork/pelldvs/crypto/bls"
+	cmtos "github.com/0xPellNetwork/pelldvs/libs/os"
+	"github.com/0xPellNetwork/pelldvs/p2p"
+	"github.com/0xPellNetwork/pelldvs/privval"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/spf13/cobra"
+
+	"github.com/0xPellNetwork/pellapp-sdk/client/flags"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/server/types"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+	"github.com/0xPellNetwork/pellapp-sdk/version"
+)
+
+const (
+	flagOverwrite = "overwrite"
+	flagRecover   = "recover"
+
+	// keysDir is the directory of the operator keys in the home directory
+	keysDir = "keys"
+)
+
+// initInfo is printed once the home directory is initialized
+type initInfo struct {
+	Moniker     string `json:"moniker"`
+	NodeID      string `json:"node_id"`
+	BLSPubKey   string `json:"bls_pub_key"`
+	GenesisFile string `json:"genesis_file"`
+}
+
+// InitCmd initializes the home directory of a node: it writes the PellDVS and
+// app configs, generates the node key and the BLS operator key, and writes
+// the app genesis from the default genesis states of the modules of mm.
+func InitCmd(mm *sdktypes.ModuleManager, cdc codec.JSONCodec, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init [moniker]",
+		Short: "Initialize the configs, keys and genesis of a node",
+		Long: `Initialize the home directory of a node: write the PellDVS config.toml and
+the app.toml, generate the node key and the BLS operator key, unless they exist,
+and write the app genesis from the default genesis states of the modules.
+
+The existing genesis is only replaced with --overwrite. With --recover, the BLS
+operator key is read from the standard input rather than generated.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serverCtx := GetServerContextFromCmd(cmd)
+			pellDVSConfig := serverCtx.Config
+
+			home, _ := cmd.Flags().GetString(flags.FlagHome)
+			overwrite, _ := cmd.Flags().GetBool(flagOverwrite)
+			recoverKey, _ := cmd.Flags().GetBool(flagRecover)
+
+			pellDVSConfig.SetRoot(home)
+			pellDVSConfig.Moniker = args[0]
+			// the config keeps the key paths relative to the keys directory
+			blsKeyFile := resolveKeyPaths(pellDVSConfig).Pell.OperatorBLSPrivateKeyStorePath
+
+			genFile := pellDVSConfig.GenesisFile()
+			if !overwrite && cmtos.FileExists(genFile) {
+				return fmt.Errorf("genesis file %s already exists, use --%s to replace it", genFile, flagOverwrite)
+			}
+
+			pelldvscfg.EnsureRoot(home)
+			if err := cmtos.EnsureDir(filepath.Join(home, keysDir), pelldvscfg.DefaultDirPerm); err != nil {
+				return err
+			}
+
+			nodeKey, err := p2p.LoadOrGenNodeKey(pellDVSConfig.NodeKeyFile())
+			if err != nil {
+				return fmt.Errorf("failed to load or generate node key: %w", err)
+			}
+
+			var pv *privval.FilePV
+			if recoverKey {
+				pv, err = recoverFilePV(cmd, blsKeyFile, overwrite)
+			} else {
+				pv, err = privval.LoadOrGenFilePV(blsKeyFile)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to set up BLS operator key: %w", err)
+			}
+
+			if err := writeAppGenesis(genFile, mm.DefaultGenesis(cdc)); err != nil {
+				return err
+			}
+
+			configPath := filepath.Join(home, pelldvscfg.DefaultConfigDir)
+			pelldvscfg.WriteConfigFile(filepath.Join(configPath, "config.toml"), pellDVSConfig)
+			appCfgFilePath := filepath.Join(configPath, "app.toml")
+			if _, err := os.Stat(appCfgFilePath); os.IsNotExist(err) {
+				config.WriteConfigFile(appCfgFilePath, config.DefaultConfig())
+			}
+
+			out, err := json.MarshalIndent(initInfo{
+				Moniker:     pellDVSConfig.Moniker,
+				NodeID:      string(nodeKey.ID()),
+				BLSPubKey:   pv.Key.KeyPair.PubKey.String(),
+				GenesisFile: genFile,
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			cmd.Println(string(out))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Bool(flagOverwrite, false, "Overwrite the genesis file, and the BLS operator key with --recover")
+	cmd.Flags().Bool(flagRecover, false, "Read the BLS operator private key from the standard input rather than generating it")
+
+	return cmd
+}
+
+// resolveKeyPaths returns a copy of pellDVSConfig whose relative paths of
+// the operator keys are resolved against the keys directory of the home
+// directory rather than the working directory. The paths are kept relative in
+// the config file, so that the home directory can be moved.
+func resolveKeyPaths(pellDVSConfig *pelldvscfg.Config) *pelldvscfg.Config {
+	resolved := *pellDVSConfig
+	pell := *pellDVSConfig.Pell
+	for _, path := range []*string{
+		&pell.OperatorBLSPrivateKeyStorePath,
+		&pell.OperatorECDSAPrivateKeyStorePath,
+	} {
+		if *path != "" && !filepath.IsAbs(*path) {
+			*path = filepath.Join(pellDVSConfig.RootDir, keysDir, *path)
+		}
+	}
+	resolved.Pell = &pell
+	return &resolved
+}
+
+// recoverFilePV saves the BLS operator private key read from the input of cmd
+// to keyFile, which is only replaced if overwrite is set.
+func recoverFilePV(cmd *cobra.Command, keyFile string, overwrite bool) (*privval.FilePV, error) {
+	if !overwrite && cmtos.FileExists(keyFile) {
+		return nil, fmt.Errorf("BLS operator key %s already exists, use --%s to replace it", keyFile, flagOverwrite)
+	}
+
+	cmd.PrintErrln("Enter the BLS operator private key, as a decimal or 0x-prefixed hex number:")
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && line == "" {
+		return nil, fmt.Errorf("failed to read the private key: %w", err)
+	}
+	privKey := strings.TrimSpace(line)
+	if privKey == "" {
+		return nil, errors.New("empty private key")
+	}
+
+	keyPair, err := bls.NewKeyPairFromString(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	// the key file is not encrypted, as the node loads it
+	if err := keyPair.SaveToFile(keyFile, ""); err != nil {
+		return nil, err
+	}
+	return privval.NewFilePV(*keyPair, keyFile), nil
+}
+
+// writeAppGenesis writes the genesis file of the application with the
+// genesis states of its modules.
+func writeAppGenesis(genFile string, genesis map[string]json.RawMessage) error {
+	appState, err := json.Marshal(genesis)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(types.AppGenesis{
+		AppName:     version.AppName,
+		AppVersion:  version.Version,
+		GenesisTime: time.Now().UTC(),
+		AppState:    appState,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(genFile, out, 0o600)
+}
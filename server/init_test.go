@@ -0,0 +1,100 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/0xPellNetwork/pelldvs/crypto/bls"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+// runInit runs the init command in home with stdin as input
+func runInit(t *testing.T, home, stdin string, args ...string) (initInfo, error) {
+	t.Helper()
+	cmd := InitCmd(sdktypes.NewManager(), codec.NewProtoCodec(codectypes.NewInterfaceRegistry()), home)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(append([]string{"node0"}, args...))
+	if err := cmd.Execute(); err != nil {
+		return initInfo{}, err
+	}
+
+	var info initInfo
+	require.NoError(t, json.Unmarshal(out.Bytes(), &info))
+	return info, nil
+}
+
+func TestInitCmd(t *testing.T) {
+	home := t.TempDir()
+
+	info, err := runInit(t, home, "")
+	require.NoError(t, err)
+	assert.Equal(t, "node0", info.Moniker)
+	assert.Equal(t, filepath.Join(home, "config", "genesis.json"), info.GenesisFile)
+	assert.FileExists(t, filepath.Join(home, "config", "app.toml"))
+	assert.FileExists(t, filepath.Join(home, keysDir, "operator.bls.key.json"))
+
+	// the key paths are kept relative to the keys directory
+	bz, err := os.ReadFile(filepath.Join(home, "config", "config.toml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(bz), `operator_bls_private_key_store_path = "operator.bls.key.json"`)
+	assert.NotContains(t, string(bz), filepath.Join(home, keysDir))
+
+	// the genesis of an initialized home is not replaced
+	genesis, err := os.ReadFile(info.GenesisFile)
+	require.NoError(t, err)
+	_, err = runInit(t, home, "")
+	require.ErrorContains(t, err, "already exists, use --overwrite to replace it")
+
+	// the keys are kept when the genesis is overwritten
+	overwritten, err := runInit(t, home, "", "--overwrite")
+	require.NoError(t, err)
+	assert.Equal(t, info.NodeID, overwritten.NodeID)
+	assert.Equal(t, info.BLSPubKey, overwritten.BLSPubKey)
+	bz, err = os.ReadFile(info.GenesisFile)
+	require.NoError(t, err)
+	assert.NotEqual(t, string(genesis), string(bz))
+}
+
+func TestInitCmdRecover(t *testing.T) {
+	home := t.TempDir()
+	keyPair, err := bls.NewKeyPairFromString("12345")
+	require.NoError(t, err)
+
+	info, err := runInit(t, home, "12345\n", "--recover")
+	require.NoError(t, err)
+	assert.Equal(t, keyPair.PubKey.String(), info.BLSPubKey)
+
+	// an existing key is only replaced with --overwrite
+	require.NoError(t, os.Remove(info.GenesisFile))
+	_, err = runInit(t, home, "67890\n", "--recover")
+	require.ErrorContains(t, err, "already exists, use --overwrite to replace it")
+
+	keyPair, err = bls.NewKeyPairFromString("0x10932")
+	require.NoError(t, err)
+	info, err = runInit(t, home, "0x10932\n", "--recover", "--overwrite")
+	require.NoError(t, err)
+	assert.Equal(t, keyPair.PubKey.String(), info.BLSPubKey)
+
+	// the recovered key is loaded when the home is initialized again
+	rerun, err := runInit(t, home, "", "--overwrite")
+	require.NoError(t, err)
+	assert.Equal(t, info.BLSPubKey, rerun.BLSPubKey)
+
+	_, err = runInit(t, home, "\n", "--recover", "--overwrite")
+	require.ErrorContains(t, err, "empty private key")
+	_, err = runInit(t, home, "not a key\n", "--recover", "--overwrite")
+	require.ErrorContains(t, err, "invalid private key")
+}
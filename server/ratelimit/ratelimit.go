@@ -0,0 +1,220 @@
+// Package ratelimit limits the rate of the requests of the gRPC and API
+// servers with token buckets, per client IP and per method.
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-metrics"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/telemetry"
+)
+
+// Limits hit by rejected requests, reported in the limit label of the
+// rejection metrics
+const (
+	LimitClient = "client"
+	LimitMethod = "method"
+)
+
+// clientTTL is the time the bucket of a client is kept after its last request
+const clientTTL = 10 * time.Minute
+
+// Limiter rate limits requests with a token bucket per client IP and per
+// method matched by the configured method limits.
+type Limiter struct {
+	server      string
+	clientRate  rate.Limit
+	clientBurst int
+	methods     []methodLimit
+	exempt      []*net.IPNet
+
+	mtx       sync.Mutex
+	clients   map[string]*clientBucket
+	lastSweep time.Time
+}
+
+type methodLimit struct {
+	pattern string
+	bucket  *rate.Limiter
+}
+
+type clientBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// New returns the Limiter configured by cfg. Server names the limited
+// server in the rejection metrics, "grpc" or "api".
+func New(cfg config.RateLimitConfig, server string) (*Limiter, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	l := &Limiter{
+		server:      server,
+		clientRate:  rate.Limit(cfg.ClientRate),
+		clientBurst: cfg.ClientBurst,
+		clients:     make(map[string]*clientBucket),
+		lastSweep:   time.Now(),
+	}
+	for _, entry := range cfg.Methods {
+		r, burst, err := config.ParseMethodRateLimit(entry)
+		if err != nil {
+			return nil, err
+		}
+		l.methods = append(l.methods, methodLimit{pattern: entry[0], bucket: rate.NewLimiter(rate.Limit(r), burst)})
+	}
+	for _, client := range cfg.ExemptClients {
+		ipNet, err := config.ParseCIDR(client)
+		if err != nil {
+			return nil, err
+		}
+		l.exempt = append(l.exempt, ipNet)
+	}
+	return l, nil
+}
+
+// Allow reports whether a request of client to method is allowed, consuming
+// a token of the client bucket and of the bucket of the first method limit
+// matching method. Otherwise, it returns the limit hit and emits a rejection
+// metric.
+func (l *Limiter) Allow(client net.IP, method string) (bool, string) {
+	if client != nil {
+		for _, ipNet := range l.exempt {
+			if ipNet.Contains(client) {
+				return true, ""
+			}
+		}
+	}
+
+	var limit *methodLimit
+	for i := range l.methods {
+		if config.MatchMethod(l.methods[i].pattern, method) {
+			limit = &l.methods[i]
+			break
+		}
+	}
+	// methods are reported by the pattern of their limit, if any, to bound
+	// the cardinality of the metrics
+	var pattern string
+	if limit != nil {
+		pattern = limit.pattern
+	}
+
+	if l.clientRate > 0 && client != nil && !l.clientBucket(client.String()).Allow() {
+		l.reject(LimitClient, pattern)
+		return false, LimitClient
+	}
+	// the method bucket is only consumed by requests the client limit allows
+	if limit != nil && !limit.bucket.Allow() {
+		l.reject(LimitMethod, pattern)
+		return false, LimitMethod
+	}
+	return true, ""
+}
+
+// clientBucket returns the bucket of client, sweeping the buckets of the
+// clients idle for clientTTL
+func (l *Limiter) clientBucket(client string) *rate.Limiter {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) >= clientTTL {
+		for ip, bucket := range l.clients {
+			if now.Sub(bucket.lastSeen) >= clientTTL {
+				delete(l.clients, ip)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	bucket := l.clients[client]
+	if bucket == nil {
+		bucket = &clientBucket{limiter: rate.NewLimiter(l.clientRate, l.clientBurst)}
+		l.clients[client] = bucket
+	}
+	bucket.lastSeen = now
+	return bucket.limiter
+}
+
+func (l *Limiter) reject(limit, method string) {
+	telemetry.IncrCounterWithLabels(
+		[]string{telemetry.MetricKeyRateLimit, telemetry.MetricKeyRejected},
+		1,
+		[]metrics.Label{
+			telemetry.NewLabel(telemetry.MetricLabelServer, l.server),
+			telemetry.NewLabel(telemetry.MetricLabelLimit, limit),
+			telemetry.NewLabel(telemetry.MetricLabelMethod, method),
+		},
+	)
+}
+
+// peerIP returns the IP of the peer of a gRPC call, nil if unknown
+func peerIP(ctx context.Context) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+	return hostIP(p.Addr.String())
+}
+
+func hostIP(addr string) net.IP {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	return net.ParseIP(host)
+}
+
+func (l *Limiter) check(ctx context.Context, fullMethod string) error {
+	if ok, limit := l.Allow(peerIP(ctx), fullMethod); !ok {
+		return status.Errorf(codes.ResourceExhausted, "%s rate limit exceeded", limit)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor rate limiting unary calls.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := l.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor rate limiting streaming
+// calls, the opening of a stream counting as one request.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.check(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// Middleware returns an HTTP middleware rate limiting requests per client IP
+// and per URL path, rejected requests are answered with 429 Too Many Requests.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, limit := l.Allow(hostIP(r.RemoteAddr), r.URL.Path); !ok {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, limit+" rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
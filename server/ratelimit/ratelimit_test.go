@@ -0,0 +1,148 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cosmostelemetry "github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/telemetry"
+)
+
+const (
+	infoMethod   = "/pellapp.node.v1.Service/Info"
+	statusMethod = "/pellapp.node.v1.Service/Status"
+	healthMethod = "/grpc.health.v1.Health/Check"
+)
+
+var (
+	dashboard = net.ParseIP("10.0.0.1")
+	operator  = net.ParseIP("10.0.0.2")
+	loopback  = net.ParseIP("127.0.0.1")
+)
+
+func newLimiter(t *testing.T, cfg config.RateLimitConfig) *Limiter {
+	t.Helper()
+	cfg.Enable = true
+	l, err := New(cfg, "grpc")
+	require.NoError(t, err)
+	return l
+}
+
+func TestLimiterClients(t *testing.T) {
+	l := newLimiter(t, config.RateLimitConfig{
+		ClientRate:    0.001,
+		ClientBurst:   2,
+		ExemptClients: []string{"127.0.0.1"},
+	})
+
+	// a misbehaving client exhausting its bucket does not limit the others
+	for i := 0; i < 2; i++ {
+		ok, _ := l.Allow(dashboard, infoMethod)
+		require.True(t, ok)
+	}
+	ok, limit := l.Allow(dashboard, healthMethod)
+	require.False(t, ok)
+	require.Equal(t, LimitClient, limit)
+
+	ok, _ = l.Allow(operator, infoMethod)
+	require.True(t, ok)
+	for i := 0; i < 10; i++ {
+		ok, _ = l.Allow(loopback, infoMethod)
+		require.True(t, ok)
+	}
+}
+
+func TestLimiterMethods(t *testing.T) {
+	l := newLimiter(t, config.RateLimitConfig{
+		Methods: [][]string{
+			{infoMethod, "0.001", "1"},
+			{"/pellapp.node.v1.Service/*", "0.001", "2"},
+		},
+	})
+
+	// the first entry matching a method limits it
+	ok, _ := l.Allow(dashboard, infoMethod)
+	require.True(t, ok)
+	ok, limit := l.Allow(operator, infoMethod)
+	require.False(t, ok)
+	require.Equal(t, LimitMethod, limit)
+
+	// the methods matched by an entry share its bucket
+	ok, _ = l.Allow(dashboard, statusMethod)
+	require.True(t, ok)
+	ok, _ = l.Allow(dashboard, "/pellapp.node.v1.Service/Config")
+	require.True(t, ok)
+	ok, _ = l.Allow(dashboard, statusMethod)
+	require.False(t, ok)
+
+	// unmatched methods are not limited
+	ok, _ = l.Allow(dashboard, healthMethod)
+	require.True(t, ok)
+}
+
+func TestLimiterInterceptor(t *testing.T) {
+	l := newLimiter(t, config.RateLimitConfig{ClientRate: 0.001, ClientBurst: 1})
+	interceptor := l.UnaryServerInterceptor()
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: dashboard, Port: 1234}})
+	info := &grpc.UnaryServerInfo{FullMethod: infoMethod}
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	res, err := interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", res)
+
+	_, err = interceptor(ctx, nil, info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLimiterMiddleware(t *testing.T) {
+	l := newLimiter(t, config.RateLimitConfig{ClientRate: 0.001, ClientBurst: 1})
+	handler := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/pellapp/node/v1/info", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:1234").Code)
+	rec := serve("10.0.0.1:5678")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("Retry-After"))
+	require.Equal(t, http.StatusOK, serve("10.0.0.2:1234").Code)
+}
+
+func TestLimiterMetrics(t *testing.T) {
+	m, err := telemetry.New(cosmostelemetry.Config{Enabled: true, PrometheusRetentionTime: 60})
+	require.NoError(t, err)
+
+	l := newLimiter(t, config.RateLimitConfig{
+		ClientRate:  0.001,
+		ClientBurst: 1,
+		Methods:     [][]string{{"/pellapp.node.v1.Service/*", "0.001", "1"}},
+	})
+	l.Allow(dashboard, infoMethod)
+	l.Allow(dashboard, infoMethod)
+	l.Allow(operator, statusMethod)
+
+	res, err := m.Gather(cosmostelemetry.FormatPrometheus)
+	require.NoError(t, err)
+	out := string(res.Metrics)
+	assert.Contains(t, out, `rate_limit_rejected{limit="client",method="/pellapp.node.v1.Service/*",server="grpc"} 1`)
+	assert.Contains(t, out, `rate_limit_rejected{limit="method",method="/pellapp.node.v1.Service/*",server="grpc"} 1`)
+}
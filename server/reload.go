@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	pelldvscfg "github.com/0xPellNetwork/pelldvs/config"
+	"github.com/spf13/viper"
+
+	"github.com/0xPellNetwork/pellapp-sdk/client/flags"
+	"github.com/0xPellNetwork/pellapp-sdk/server/api"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/server/types"
+)
+
+// ConfigReloadInterval is the interval between the checks of the modification
+// times of the configs by the config watcher of the start command.
+var ConfigReloadInterval = time.Second
+
+// configFiles are the configs of the config directory read by the reloader, the
+// settings of the latter overriding those of the former
+var configFiles = []string{"config.toml", "app.toml"}
+
+// configReloader applies the settings of the configs of the home directory
+// reloadable while the node runs, when the configs change or on SIGHUP: the
+// log level of the config.toml, the index events of the app and the CORS and
+// rate limit settings of the API server, see config.IsReloadable. The other
+// changed settings are logged as requiring a restart. A setting given as a
+// flag is overridden once changed in the configs.
+type configReloader struct {
+	svrCtx *Context
+	app    types.Application
+
+	mtx      sync.Mutex
+	apiSrv   *api.Server          // nil until started, or if disabled
+	settings *viper.Viper         // the settings last applied
+	stamps   map[string]fileStamp // the stamps of the configs last checked
+}
+
+// fileStamp identifies the version of a file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func newConfigReloader(svrCtx *Context, app types.Application) (*configReloader, error) {
+	r := &configReloader{svrCtx: svrCtx, app: app}
+	// the configs are stamped first, so that a change racing with their
+	// reading is applied by the next check
+	r.changed()
+
+	settings, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	r.settings = settings
+	return r, nil
+}
+
+// setAPIServer sets the API server the settings are applied to.
+func (r *configReloader) setAPIServer(apiSrv *api.Server) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.apiSrv = apiSrv
+}
+
+// watch reloads the configs when they change until ctx is done.
+func (r *configReloader) watch(ctx context.Context) {
+	ticker := time.NewTicker(ConfigReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if r.changed() {
+				r.reload()
+			}
+		}
+	}
+}
+
+// changed reports whether the configs changed since the last check.
+func (r *configReloader) changed() bool {
+	stamps := make(map[string]fileStamp, len(configFiles))
+	for _, path := range r.paths() {
+		// a missing config, e.g. while it is replaced, is stamped as empty
+		if info, err := os.Stat(path); err == nil {
+			stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+
+	changed := !maps.Equal(stamps, r.stamps)
+	r.stamps = stamps
+	return changed
+}
+
+func (r *configReloader) paths() []string {
+	configPath := filepath.Join(r.svrCtx.Config.RootDir, pelldvscfg.DefaultConfigDir)
+	paths := make([]string, len(configFiles))
+	for i, name := range configFiles {
+		paths[i] = filepath.Join(configPath, name)
+	}
+	return paths
+}
+
+// read returns the settings of the configs.
+func (r *configReloader) read() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigType("toml")
+	for _, path := range r.paths() {
+		v.SetConfigFile(path)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+	return v, nil
+}
+
+// reload applies the reloadable settings of the configs which changed since
+// the last reload, unless the configs are invalid.
+func (r *configReloader) reload() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	logger := r.svrCtx.Logger
+	if err := r.apply(); err != nil {
+		logger.Error("failed to reload configs, keeping the current settings", "err", err)
+	}
+}
+
+func (r *configReloader) apply() error {
+	settings, err := r.read()
+	if err != nil {
+		return err
+	}
+	diffs := config.Diff(settings, r.settings)
+	if len(diffs) == 0 {
+		r.svrCtx.Logger.Info("configs unchanged")
+		return nil
+	}
+
+	appCfg, err := config.GetConfig(settings)
+	if err != nil {
+		return err
+	}
+	if err := appCfg.ValidateBasic(); err != nil {
+		return err
+	}
+	logLevel := settings.GetString(flags.FlagLogLevel)
+	if logLevel != "" {
+		if _, _, err := parseLogLevel(logLevel); err != nil {
+			return err
+		}
+	}
+
+	var reloadLogLevel, reloadApp, reloadAPI bool
+	for _, diff := range diffs {
+		switch {
+		case diff.Key == flags.FlagLogLevel:
+			reloadLogLevel = true
+		case config.IsReloadable(diff.Key):
+			reloadAPI = reloadAPI || strings.HasPrefix(diff.Key, "api.")
+			reloadApp = reloadApp || !strings.HasPrefix(diff.Key, "api.")
+		default:
+			r.svrCtx.Logger.Info("setting changed, restart the node to apply it", "key", diff.Key)
+		}
+	}
+
+	var errs []error
+	if reloadLogLevel {
+		if err := SetLogLevel(logLevel); err != nil {
+			errs = append(errs, err)
+		} else {
+			r.svrCtx.Logger.Info("reloaded log level", flags.FlagLogLevel, logLevel)
+		}
+	}
+	if reloadApp {
+		r.app.ReloadIndexEvents(appCfg.IndexEvents)
+	}
+	if reloadAPI && r.apiSrv != nil {
+		if err := r.apiSrv.Reload(appCfg.API); err != nil {
+			errs = append(errs, err)
+		} else {
+			r.svrCtx.Logger.Info("reloaded API server CORS and rate limit settings")
+		}
+	}
+
+	r.settings = settings
+	return errors.Join(errs...)
+}
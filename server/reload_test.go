@@ -0,0 +1,100 @@
+package server
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	pelldvscfg "github.com/0xPellNetwork/pelldvs/config"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/server/types"
+)
+
+// reloadTestApp records the index events reloaded
+type reloadTestApp struct {
+	types.Application
+	indexEvents []string
+}
+
+func (app *reloadTestApp) ReloadIndexEvents(ie []string) {
+	app.indexEvents = ie
+}
+
+// setupReloader returns a reloader of the default configs of a home
+// directory, with the path of its config directory and its log output
+func setupReloader(t *testing.T) (*configReloader, *reloadTestApp, string, *bytes.Buffer) {
+	t.Helper()
+	cfg := pelldvscfg.DefaultConfig().SetRoot(t.TempDir())
+	configDir := filepath.Join(cfg.RootDir, pelldvscfg.DefaultConfigDir)
+	require.NoError(t, os.MkdirAll(configDir, 0o755))
+	pelldvscfg.WriteConfigFile(filepath.Join(configDir, "config.toml"), cfg)
+	config.WriteConfigFile(filepath.Join(configDir, "app.toml"), config.DefaultConfig())
+
+	var out bytes.Buffer
+	app := &reloadTestApp{}
+	r, err := newConfigReloader(NewContext(viper.New(), cfg, log.NewLogger(&out)), app)
+	require.NoError(t, err)
+	return r, app, configDir, &out
+}
+
+func TestConfigReloaderApply(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetLogLevel("")) })
+	r, app, configDir, out := setupReloader(t)
+	appFile := filepath.Join(configDir, "app.toml")
+
+	require.NoError(t, r.apply())
+	assert.Contains(t, out.String(), "configs unchanged")
+	assert.False(t, r.changed())
+
+	// the index events are reloaded
+	require.NoError(t, config.AppFile.Set(appFile, "index-events", `["task.id"]`))
+	assert.True(t, r.changed())
+	require.NoError(t, r.apply())
+	assert.Equal(t, []string{"task.id"}, app.indexEvents)
+
+	// the other settings require a restart
+	app.indexEvents = nil
+	require.NoError(t, config.AppFile.Set(appFile, "grpc.address", "127.0.0.1:9999"))
+	require.NoError(t, r.apply())
+	assert.Nil(t, app.indexEvents)
+	assert.Contains(t, out.String(), "restart the node to apply it")
+	assert.Contains(t, out.String(), "grpc.address")
+
+	// the log level of the config.toml is reloaded
+	configFile := filepath.Join(configDir, "config.toml")
+	setLogLevel := func(level string) {
+		t.Helper()
+		bz, err := os.ReadFile(configFile)
+		require.NoError(t, err)
+		lines := strings.Split(string(bz), "\n")
+		for i, line := range lines {
+			if strings.HasPrefix(line, "log_level = ") {
+				lines[i] = `log_level = "` + level + `"`
+			}
+		}
+		require.NoError(t, os.WriteFile(configFile, []byte(strings.Join(lines, "\n")), 0o644))
+	}
+	setLogLevel("*:error,baseapp:debug")
+	require.NoError(t, r.apply())
+	assert.Equal(t, zerolog.DebugLevel, sdkLogLevel.Load().min)
+	assert.NotNil(t, sdkLogLevel.Load().filter)
+
+	// invalid configs are not applied, and their changes are applied once
+	// they are fixed
+	setLogLevel("baseapp:loud")
+	require.NoError(t, config.AppFile.Set(appFile, "index-events", `["message.action"]`))
+	require.ErrorContains(t, r.apply(), "invalid log level")
+	assert.Nil(t, app.indexEvents)
+	setLogLevel("info")
+	require.NoError(t, r.apply())
+	assert.Equal(t, []string{"message.action"}, app.indexEvents)
+	assert.Equal(t, zerolog.InfoLevel, sdkLogLevel.Load().min)
+}
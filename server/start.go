@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net"
 	"os"
@@ -11,18 +13,24 @@ import (
 	pelldvscfg "github.com/0xPellNetwork/pelldvs/config"
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/0xPellNetwork/pellapp-sdk/client"
 	"github.com/0xPellNetwork/pellapp-sdk/client/flags"
 	"github.com/0xPellNetwork/pellapp-sdk/pelldvs"
 	"github.com/0xPellNetwork/pellapp-sdk/server/api"
+	"github.com/0xPellNetwork/pellapp-sdk/server/appdb"
+	"github.com/0xPellNetwork/pellapp-sdk/server/avsi"
 	serverconfig "github.com/0xPellNetwork/pellapp-sdk/server/config"
 	servergrpc "github.com/0xPellNetwork/pellapp-sdk/server/grpc"
+	"github.com/0xPellNetwork/pellapp-sdk/server/tlsconfig"
 	"github.com/0xPellNetwork/pellapp-sdk/server/types"
+	sdktelemetry "github.com/0xPellNetwork/pellapp-sdk/telemetry"
 )
 
 const (
@@ -33,6 +41,12 @@ const (
 	FlagInterBlockCache = "inter-block-cache"
 	FlagTrace           = "trace"
 	FlagShutdownGrace   = "shutdown-grace"
+	FlagIndexEvents     = "index-events"
+	FlagInMemory        = "in-memory"
+
+	// stand-alone AVSI server flags
+	flagAddress   = "address"
+	flagTransport = "transport"
 
 	// api-related flags
 	FlagAPIEnable             = "api.enable"
@@ -54,7 +68,7 @@ const (
 // StartCmdOptions defines options that can be customized in `StartCmdWithOptions`,
 type StartCmdOptions struct {
 	// DBOpener can be used to customize db opening, for example customize db options or support different db backends,
-	// default to the builtin db opener.
+	// default to the builtin db opener of NewDBOpener, tuned with the goleveldb and pebble sections of the app.toml.
 	DBOpener func(rootDir string, backendType dbm.BackendType) (dbm.DB, error)
 
 	// PostSetup can be used to setup extra services under the same cancellable context,
@@ -78,10 +92,6 @@ func StartCmd(appCreator types.AppCreator, defaultNodeHome string) *cobra.Comman
 // StartCmdWithOptions runs the service passed in, either stand-alone or in-process with
 // PellDVS.
 func StartCmdWithOptions(appCreator types.AppCreator, defaultNodeHome string, opts StartCmdOptions) *cobra.Command {
-	if opts.DBOpener == nil {
-		opts.DBOpener = openDB
-	}
-
 	if opts.StartCommandHandler == nil {
 		opts.StartCommandHandler = start
 	}
@@ -99,6 +109,10 @@ The node may be started in a 'query only' mode where only the gRPC and JSON HTTP
 API services are enabled via the 'grpc-only' flag. In this mode, PellDVS is
 bypassed and can be used when legacy queries are needed after an on-chain upgrade
 is performed. Note, when enabled, gRPC will also be automatically enabled.
+
+The log level of the config.toml, and the index events, API CORS and API rate
+limit settings of the app.toml, are reloaded when the configs change or on
+SIGHUP. The other changed settings are logged as requiring a restart.
 `,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			serverCtx := GetServerContextFromCmd(cmd)
@@ -123,19 +137,9 @@ is performed. Note, when enabled, gRPC will also be automatically enabled.
 				serverCtx.Logger.Info("starting AVSI without PellDVS")
 			}
 
-			err = wrapCPUProfile(serverCtx, func() error {
+			return wrapCPUProfile(serverCtx, func() error {
 				return opts.StartCommandHandler(serverCtx, clientCtx, appCreator, withPellDVSNode, opts)
 			})
-
-			serverCtx.Logger.Debug("received quit signal")
-			graceDuration, _ := cmd.Flags().GetDuration(FlagShutdownGrace)
-			if graceDuration > 0 {
-				serverCtx.Logger.Info("graceful shutdown start", FlagShutdownGrace, graceDuration)
-				<-time.After(graceDuration)
-				serverCtx.Logger.Info("graceful shutdown complete")
-			}
-
-			return err
 		},
 	}
 
@@ -150,46 +154,160 @@ func start(svrCtx *Context, clientCtx client.Context, appCreator types.AppCreato
 		return err
 	}
 
-	app, appCleanupFn, err := setupApp(svrCtx, appCreator, opts)
+	// telemetry is enabled before the app is created so that its stores are instrumented
+	metrics, err := startTelemetry(svrCfg)
+	if err != nil {
+		return err
+	}
+
+	shutdownTracing, err := sdktelemetry.StartTracing(svrCfg.Tracing, svrCtx.Config.RootDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			svrCtx.Logger.Error("failed to shutdown tracing", "err", err)
+		}
+	}()
+
+	// the node service reports the backend actually used, e.g. memdb with --in-memory
+	svrCfg.AppDBBackend = string(GetAppDBBackend(svrCtx.Viper))
+
+	app, appCleanupFn, err := setupApp(svrCtx, svrCfg, appCreator, opts)
 	if err != nil {
 		return err
 	}
 	defer appCleanupFn()
 
-	return startInProcess(svrCtx, svrCfg, clientCtx, app, opts)
+	if err := app.ValidateResultExtractors(); err != nil {
+		return fmt.Errorf("invalid result extractors: %w", err)
+	}
+
+	if app.LastBlockHeight() == 0 {
+		if err := initGenesis(svrCtx, app); err != nil {
+			return err
+		}
+	}
+
+	if !withPellDVSNode {
+		return startStandAlone(svrCtx, svrCfg, clientCtx, app, metrics, opts)
+	}
+	return startInProcess(svrCtx, svrCfg, clientCtx, app, metrics, opts)
+}
+
+// startStandAlone starts the AVSI server of the application for PellDVS to
+// connect to from another process, so that both can be restarted and upgraded
+// independently, along with the gRPC server and the API server. Everything is
+// shut down in order on a quit signal or a failure of a server, see shutdown.
+func startStandAlone(svrCtx *Context, svrCfg serverconfig.Config, clientCtx client.Context, app types.Application,
+	metrics *telemetry.Metrics, opts StartCmdOptions,
+) error {
+	addr := svrCtx.Viper.GetString(flagAddress)
+	transport := svrCtx.Viper.GetString(flagTransport)
+
+	reloader, err := newConfigReloader(svrCtx, app)
+	if err != nil {
+		return err
+	}
+
+	// ctx is canceled on quit signals, or when a service of g fails
+	g, ctx := getCtx(svrCtx, false, reloader.reload)
+
+	app.RegisterNodeService(clientCtx, svrCfg)
+
+	grpcSrv, clientCtx, grpcStep, err := startGrpcServer(g, svrCfg.GRPC, clientCtx, svrCtx, app)
+	if err != nil {
+		return err
+	}
+	defer grpcStep.stop()
+
+	apiSrv, stopAPI, err := startAPIServer(g, svrCfg, clientCtx, svrCtx, app, svrCtx.Config.RootDir, grpcSrv, metrics)
+	if err != nil {
+		return err
+	}
+	defer stopAPI()
+
+	reloader.setAPIServer(apiSrv)
+	g.Go(func() error {
+		reloader.watch(ctx)
+		return nil
+	})
+
+	avsiCtx, cancel := context.WithCancel(context.Background())
+	stopAVSI := goStoppable(g, cancel, func() error {
+		return avsi.StartServer(avsiCtx, svrCtx.Logger.With("module", "avsi-server"), addr, transport, app)
+	})
+	defer stopAVSI()
+
+	if opts.PostSetupStandalone != nil {
+		if err := opts.PostSetupStandalone(svrCtx, clientCtx, ctx, g); err != nil {
+			return err
+		}
+	}
+
+	<-ctx.Done()
+	shutdown(svrCtx, app,
+		shutdownStep{name: "API server", stop: stopAPI},
+		grpcStep,
+		shutdownStep{name: "AVSI server", stop: stopAVSI},
+	)
+
+	return g.Wait()
 }
 
 // startInProcess starts the server in-process with PellDVS, currently we only support
 // starting the server in-process with PellDVS. The server will start the gRPC server
+// and the API server, and shut everything down in order on a quit signal or a
+// failure of a server, see shutdown.
 func startInProcess(svrCtx *Context, svrCfg serverconfig.Config, clientCtx client.Context, app types.Application,
-	opts StartCmdOptions,
+	metrics *telemetry.Metrics, opts StartCmdOptions,
 ) error {
 	cmtCfg := svrCtx.Config
 	gRPCOnly := svrCtx.Viper.GetBool(flagGRPCOnly)
 
-	g, ctx := getCtx(svrCtx, true)
+	reloader, err := newConfigReloader(svrCtx, app)
+	if err != nil {
+		return err
+	}
 
+	// ctx is canceled on quit signals, or when a service of g fails
+	g, ctx := getCtx(svrCtx, false, reloader.reload)
+
+	stopNode := func() {}
 	if gRPCOnly {
 		// TODO: Generalize logic so that gRPC only is really in startStandAlone
 		svrCtx.Logger.Info("starting node in gRPC only mode; PellDVS is disabled")
 		svrCfg.GRPC.Enable = true
 	} else {
 		svrCtx.Logger.Info("starting node with AVSI PellDVS in-process")
-		_, _, err := startPellDVSNode(ctx, cmtCfg, app, svrCtx)
+		_, cleanupFn, err := startPellDVSNode(ctx, cmtCfg, app, svrCtx)
 		if err != nil {
 			return err
 		}
+		stopNode = cleanupFn
 	}
+	// the node is stopped even if the servers fail to start
+	defer stopNode()
+
+	app.RegisterNodeService(clientCtx, svrCfg)
 
-	grpcSrv, clientCtx, err := startGrpcServer(ctx, g, svrCfg.GRPC, clientCtx, svrCtx, app)
+	grpcSrv, clientCtx, grpcStep, err := startGrpcServer(g, svrCfg.GRPC, clientCtx, svrCtx, app)
 	if err != nil {
 		return err
 	}
+	defer grpcStep.stop()
 
-	err = startAPIServer(ctx, g, svrCfg, clientCtx, svrCtx, app, cmtCfg.RootDir, grpcSrv)
+	apiSrv, stopAPI, err := startAPIServer(g, svrCfg, clientCtx, svrCtx, app, cmtCfg.RootDir, grpcSrv, metrics)
 	if err != nil {
 		return err
 	}
+	defer stopAPI()
+
+	reloader.setAPIServer(apiSrv)
+	g.Go(func() error {
+		reloader.watch(ctx)
+		return nil
+	})
 
 	if opts.PostSetup != nil {
 		if err := opts.PostSetup(svrCtx, clientCtx, ctx, app, g); err != nil {
@@ -197,11 +315,56 @@ func startInProcess(svrCtx *Context, svrCfg serverconfig.Config, clientCtx clien
 		}
 	}
 
-	// wait for signal capture and gracefully return
-	// we are guaranteed to be waiting for the "ListenForQuitSignals" goroutine.
+	<-ctx.Done()
+	shutdown(svrCtx, app,
+		shutdownStep{name: "API server", stop: stopAPI},
+		grpcStep,
+		shutdownStep{name: "PellDVS node", stop: stopNode},
+	)
+
 	return g.Wait()
 }
 
+// shutdownStep is a component stopped by shutdown
+type shutdownStep struct {
+	name string
+	// drain, if set, is called when the draining starts, e.g. to report the
+	// component as not serving
+	drain func()
+	stop  func()
+}
+
+// shutdown stops the application in order: the steps are notified of the
+// draining, then the DVS requests in flight are drained, waiting at most the
+// shutdown grace duration, and the steps are stopped in order, e.g. the API
+// server, the gRPC server and the PellDVS node. The stores are committed and
+// the DB closed afterwards by the cleanup of the application, see setupApp,
+// unless requests are still in flight, see types.Application.Close.
+func shutdown(svrCtx *Context, app types.Application, steps ...shutdownStep) {
+	grace := svrCtx.Viper.GetDuration(FlagShutdownGrace)
+
+	svrCtx.Logger.Info("graceful shutdown start", FlagShutdownGrace, grace)
+	for _, step := range steps {
+		if step.drain != nil {
+			step.drain()
+		}
+	}
+
+	svrCtx.Logger.Info("draining DVS requests in flight")
+	drainCtx, cancel := context.WithTimeout(context.Background(), grace)
+	if err := app.Drain(drainCtx); err != nil {
+		svrCtx.Logger.Error("shutting down with DVS requests in flight, the application stores will not be committed", "err", err)
+	}
+	cancel()
+
+	for _, step := range steps {
+		svrCtx.Logger.Info("stopping " + step.name)
+		step.stop()
+	}
+
+	svrCtx.Logger.Info("graceful shutdown complete")
+}
+
 func startPellDVSNode(
 	ctx context.Context,
 	cfg *pelldvscfg.Config,
@@ -209,7 +372,7 @@ func startPellDVSNode(
 	svrCtx *Context,
 ) (dvsNode *pelldvs.Node, cleanupFn func(), err error) {
 	logger := svrCtx.Logger.With("module", "node")
-	dvsNode, err = pelldvs.NewNode(logger, app, cfg)
+	dvsNode, err = pelldvs.NewNode(logger, app, resolveKeyPaths(cfg))
 	if err != nil {
 		return dvsNode, cleanupFn, err
 	}
@@ -218,6 +381,11 @@ func startPellDVSNode(
 		return dvsNode, cleanupFn, err
 	}
 
+	cleanupFn = func() {
+		if err := dvsNode.Stop(); err != nil {
+			logger.Error("failed to stop PellDVS node", "err", err)
+		}
+	}
 	return dvsNode, cleanupFn, nil
 }
 
@@ -255,21 +423,24 @@ func setupTraceWriter(svrCtx *Context) (traceWriter io.WriteCloser, cleanup func
 	return traceWriter, cleanup, nil
 }
 
+// startGrpcServer starts the gRPC server in g, if enabled, returning its
+// shutdown step, reporting it as not serving on draining and gracefully
+// stopping it and waiting for it to stop.
 func startGrpcServer(
-	ctx context.Context,
 	g *errgroup.Group,
 	config serverconfig.GRPCConfig,
 	clientCtx client.Context,
 	svrCtx *Context,
 	app types.Application,
-) (*grpc.Server, client.Context, error) {
+) (*grpc.Server, client.Context, shutdownStep, error) {
+	step := shutdownStep{name: "gRPC server", stop: func() {}}
 	if !config.Enable {
 		// return grpcServer as nil if gRPC is disabled
-		return nil, clientCtx, nil
+		return nil, clientCtx, step, nil
 	}
 	_, _, err := net.SplitHostPort(config.Address)
 	if err != nil {
-		return nil, clientCtx, err
+		return nil, clientCtx, step, err
 	}
 
 	maxSendMsgSize := config.MaxSendMsgSize
@@ -282,10 +453,19 @@ func startGrpcServer(
 		maxRecvMsgSize = serverconfig.DefaultGRPCMaxRecvMsgSize
 	}
 
+	creds := insecure.NewCredentials()
+	if config.TLS.Enabled() {
+		tlsCfg, err := tlsconfig.NewLoopback(config.TLS, svrCtx.Logger.With("module", "grpc-client"))
+		if err != nil {
+			return nil, clientCtx, step, err
+		}
+		creds = credentials.NewTLS(tlsCfg)
+	}
+
 	// if gRPC is enabled, configure gRPC client for gRPC gateway
 	grpcClient, err := grpc.Dial( //nolint: staticcheck // ignore this line for this linter
 		config.Address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultCallOptions(
 			grpc.ForceCodec(codec.NewProtoCodec(clientCtx.InterfaceRegistry).GRPCCodec()),
 			grpc.MaxCallRecvMsgSize(maxRecvMsgSize),
@@ -293,27 +473,31 @@ func startGrpcServer(
 		),
 	)
 	if err != nil {
-		return nil, clientCtx, err
+		return nil, clientCtx, step, err
 	}
 
 	clientCtx = clientCtx.WithGRPCClient(grpcClient)
 	svrCtx.Logger.Debug("gRPC client assigned to client context", "target", config.Address)
 
-	grpcSrv, err := servergrpc.NewGRPCServer(clientCtx, app, config)
+	grpcSrv, err := servergrpc.NewGRPCServer(clientCtx, svrCtx.Logger.With("module", "grpc-server"), app, config)
 	if err != nil {
-		return nil, clientCtx, err
+		return nil, clientCtx, step, err
 	}
+	healthSrv := servergrpc.RegisterHealthServer(grpcSrv)
 
-	// Start the gRPC server in a goroutine. Note, the provided ctx will ensure
+	// Start the gRPC server in a goroutine. Note, canceling ctx will ensure
 	// that the server is gracefully shut down.
-	g.Go(func() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	step.drain = healthSrv.Shutdown
+	step.stop = goStoppable(g, cancel, func() error {
 		return servergrpc.StartGRPCServer(ctx, svrCtx.Logger.With("module", "grpc-server"), config, grpcSrv)
 	})
-	return grpcSrv, clientCtx, nil
+	return grpcSrv, clientCtx, step, nil
 }
 
+// startAPIServer starts the API server in g, if enabled, returning it and a
+// function stopping it and waiting for it to stop.
 func startAPIServer(
-	ctx context.Context,
 	g *errgroup.Group,
 	svrCfg serverconfig.Config,
 	clientCtx client.Context,
@@ -321,20 +505,52 @@ func startAPIServer(
 	app types.Application,
 	home string,
 	grpcSrv *grpc.Server,
-) error {
+	metrics *telemetry.Metrics,
+) (*api.Server, func(), error) {
 	if !svrCfg.API.Enable {
-		return nil
+		return nil, func() {}, nil
 	}
 
 	clientCtx = clientCtx.WithHomeDir(home)
 
 	apiSrv := api.New(clientCtx, svrCtx.Logger.With("module", "api-server"), grpcSrv)
+
+	// the gateway calls skip the gRPC server, so its interceptors run in process
+	interceptor, err := servergrpc.NewGatewayInterceptor(svrCfg.GRPC)
+	if err != nil {
+		return nil, nil, err
+	}
+	app.RegisterGRPCGatewayRoutes(apiSrv.GRPCGatewayRouter, interceptor)
 	app.RegisterAPIRoutes(apiSrv, svrCfg.API)
 
-	g.Go(func() error {
+	if svrCfg.Telemetry.Enabled {
+		apiSrv.SetTelemetry(metrics)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := goStoppable(g, cancel, func() error {
 		return apiSrv.Start(ctx, svrCfg)
 	})
-	return nil
+	return apiSrv, stop, nil
+}
+
+// goStoppable runs fn in g, returning a function calling cancel, which must
+// make fn return, and waiting for fn to return. The returned function may be
+// called multiple times.
+func goStoppable(g *errgroup.Group, cancel context.CancelFunc, fn func() error) func() {
+	done := make(chan struct{})
+	g.Go(func() error {
+		defer close(done)
+		return fn()
+	})
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func startTelemetry(cfg serverconfig.Config) (*telemetry.Metrics, error) {
+	return sdktelemetry.New(cfg.Telemetry)
 }
 
 // wrapCPUProfile starts CPU profiling, if enabled, and executes the provided
@@ -368,25 +584,36 @@ func wrapCPUProfile(svrCtx *Context, callbackFn func() error) error {
 	return callbackFn()
 }
 
-func getCtx(svrCtx *Context, block bool) (*errgroup.Group, context.Context) {
+func getCtx(svrCtx *Context, block bool, reloadFns ...func()) (*errgroup.Group, context.Context) {
 	ctx, cancelFn := context.WithCancel(context.Background())
 	g, ctx := errgroup.WithContext(ctx)
-	// listen for quit signals so the calling parent process can gracefully exit
-	ListenForQuitSignals(g, block, cancelFn, svrCtx.Logger)
+	// listen for quit signals so the calling parent process can gracefully exit,
+	// and for SIGHUP to reload
+	ListenForQuitSignals(g, block, cancelFn, svrCtx.Logger, reloadFns...)
 	return g, ctx
 }
 
-func setupApp(svrCtx *Context, appCreator types.AppCreator, opts StartCmdOptions) (app types.Application, cleanupFn func(), err error) {
+func setupApp(svrCtx *Context, svrCfg serverconfig.Config, appCreator types.AppCreator, opts StartCmdOptions) (app types.Application, cleanupFn func(), err error) {
 	traceWriter, traceCleanupFn, err := setupTraceWriter(svrCtx)
 	if err != nil {
 		return app, traceCleanupFn, err
 	}
 
+	dbOpener := opts.DBOpener
+	if dbOpener == nil {
+		dbOpener = NewDBOpener(svrCfg)
+	}
+
 	home := svrCtx.Config.RootDir
-	db, err := opts.DBOpener(home, GetAppDBBackend(svrCtx.Viper))
+	backend := dbm.BackendType(svrCfg.AppDBBackend)
+	db, err := dbOpener(home, backend)
 	if err != nil {
 		return app, traceCleanupFn, err
 	}
+	if backend == dbm.MemDBBackend {
+		svrCtx.Logger.Info("the application database is in memory, its state is lost on exit")
+	}
+	svrCtx.Logger.Info("opened the application database", "backend", backend, "options", appdb.Options(backend, svrCfg))
 
 	app = appCreator(svrCtx.Logger, db, traceWriter, svrCtx.Viper)
 
@@ -399,8 +626,35 @@ func setupApp(svrCtx *Context, appCreator types.AppCreator, opts StartCmdOptions
 	return app, cleanupFn, nil
 }
 
+// initGenesis initializes the empty state of app from the genesis file of
+// the home directory, written by the init command. Without a genesis file, the
+// modules start from an empty state.
+func initGenesis(svrCtx *Context, app types.Application) error {
+	genFile := svrCtx.Config.GenesisFile()
+	bz, err := os.ReadFile(genFile)
+	if os.IsNotExist(err) {
+		svrCtx.Logger.Info("no genesis file, the modules start from an empty state", "file", genFile)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read genesis file: %w", err)
+	}
+
+	var genesis types.AppGenesis
+	if err := json.Unmarshal(bz, &genesis); err != nil {
+		return fmt.Errorf("failed to unmarshal genesis file %s: %w", genFile, err)
+	}
+	if err := app.InitGenesis(genesis.AppState); err != nil {
+		return fmt.Errorf("failed to initialize genesis from %s: %w", genFile, err)
+	}
+	return nil
+}
+
 // addStartNodeFlags should be added to any CLI commands that start the network.
 func addStartNodeFlags(cmd *cobra.Command, opts StartCmdOptions) {
+	cmd.Flags().Bool(flagWithComet, true, "Run PellDVS in-process with the application, otherwise serve AVSI for an out-of-process PellDVS")
+	cmd.Flags().String(flagAddress, avsi.DefaultAddress, "Listen address of the AVSI server, when PellDVS runs out of process")
+	cmd.Flags().String(flagTransport, avsi.TransportSocket, "Transport protocol of the AVSI server: socket or grpc")
 	cmd.Flags().Bool(FlagInterBlockCache, true, "Enable inter-block caching")
 	cmd.Flags().String(flagCPUProfile, "", "Enable CPU profiling and write to the provided file")
 	cmd.Flags().Bool(FlagTrace, false, "Provide full stack traces for errors in AVSI Log")
@@ -416,7 +670,8 @@ func addStartNodeFlags(cmd *cobra.Command, opts StartCmdOptions) {
 	cmd.Flags().Bool(flagGRPCEnable, true, "Define if the gRPC server should be enabled")
 	cmd.Flags().String(flagGRPCAddress, serverconfig.DefaultGRPCAddress, "the gRPC server address to listen on")
 	cmd.Flags().Bool(flagGRPCWebEnable, true, "Define if the gRPC-Web server should be enabled. (Note: gRPC must also be enabled)")
-	cmd.Flags().Duration(FlagShutdownGrace, 0*time.Second, "On Shutdown, duration to wait for resource clean up")
+	cmd.Flags().Duration(FlagShutdownGrace, 30*time.Second, "On shutdown, maximum duration to wait for the DVS requests in flight to complete")
+	cmd.Flags().Bool(FlagInMemory, false, "Keep the application state in memory rather than in the app-db-backend, losing it on exit")
 
 	if opts.AddFlags != nil {
 		opts.AddFlags(cmd)
@@ -0,0 +1,223 @@
+// Package tlsconfig builds the TLS configuration of the gRPC and API servers,
+// reloading their certificates when the files change.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+)
+
+// ReloadInterval is the minimum interval between checks of the certificate
+// files for changes, checks are made on TLS handshakes.
+var ReloadInterval = time.Second
+
+// New returns a server TLS configuration serving the certificate of cfg and
+// verifying client certificates against its client CAs, if any. Both are
+// reloaded when their files change. A failed reload is logged and the
+// previous certificates are kept.
+func New(cfg config.TLSConfig, logger log.Logger) (*tls.Config, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled() {
+		return nil, errors.New("tls is not enabled, cert-file is not set")
+	}
+
+	r := &reloader{cfg: cfg, logger: logger}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.getCertificate,
+	}
+	// client certificates are verified against the reloaded CAs rather than
+	// by crypto/tls against a fixed pool
+	if cfg.ClientCAFile != "" {
+		tlsCfg.ClientAuth = tls.RequestClientCert
+		if cfg.RequireClientCert {
+			tlsCfg.ClientAuth = tls.RequireAnyClientCert
+		}
+		tlsCfg.VerifyPeerCertificate = r.verifyClientCert
+	}
+	return tlsCfg, nil
+}
+
+// reloader holds the certificates loaded from the files of a TLS
+// configuration, and reloads them when their modification times change.
+type reloader struct {
+	cfg    config.TLSConfig
+	logger log.Logger
+
+	mtx       sync.Mutex
+	checked   time.Time
+	modTimes  map[string]time.Time
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+func (r *reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+// load loads the certificates, r.mtx must be held unless r is not shared yet
+func (r *reloader) load() error {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		bz, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(bz) {
+			return fmt.Errorf("no certificate found in %s", r.cfg.ClientCAFile)
+		}
+	}
+
+	r.modTimes, r.cert, r.clientCAs = modTimes, &cert, clientCAs
+	return nil
+}
+
+// current returns the current certificates, reloading them if any of their
+// files changed since the last check
+func (r *reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if time.Since(r.checked) < ReloadInterval {
+		return r.cert, r.clientCAs
+	}
+	r.checked = time.Now()
+
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil || !info.ModTime().Equal(r.modTimes[file]) {
+			if err := r.load(); err != nil {
+				r.logger.Error("failed to reload TLS certificates, keeping the previous ones", "err", err)
+			} else {
+				r.logger.Info("reloaded TLS certificates", "cert", r.cfg.CertFile)
+			}
+			break
+		}
+	}
+	return r.cert, r.clientCAs
+}
+
+func (r *reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, _ := r.current()
+	return cert, nil
+}
+
+// verifyClientCert verifies the certificate presented by a client, if any,
+// against the client CAs
+func (r *reloader) verifyClientCert(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return nil
+	}
+	_, clientCAs := r.current()
+	return verifyClientChain(rawCerts, clientCAs)
+}
+
+// verifyClientChain verifies the certificate chain rawCerts for client
+// authentication against clientCAs
+func verifyClientChain(rawCerts [][]byte, clientCAs *x509.CertPool) error {
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse client certificate: %w", err)
+		}
+		certs[i] = cert
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(opts); err != nil {
+		return fmt.Errorf("failed to verify client certificate: %w", err)
+	}
+	return nil
+}
+
+// NewLoopback returns the TLS configuration of a client connecting to a
+// server of the same process running with cfg, such as the gRPC client of the
+// node. The server certificate is not verified, the connection being local.
+// When client CAs are configured, the loopback certificate, or the server
+// certificate if unset, is presented as client certificate. It must verify
+// against the client CAs for client authentication, otherwise an error is
+// returned when client certificates are required, and no certificate is
+// presented when they are optional.
+func NewLoopback(cfg config.TLSConfig, logger log.Logger) (*tls.Config, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled() {
+		return nil, errors.New("tls is not enabled, cert-file is not set")
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true, //nolint:gosec // the server is the local process
+	}
+	if cfg.ClientCAFile == "" {
+		return tlsCfg, nil
+	}
+
+	certCfg := cfg
+	if cfg.LoopbackCertFile != "" {
+		certCfg.CertFile, certCfg.KeyFile = cfg.LoopbackCertFile, cfg.LoopbackKeyFile
+	}
+	r := &reloader{cfg: certCfg, logger: logger}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	if err := verifyClientChain(r.cert.Certificate, r.clientCAs); err != nil {
+		switch {
+		case cfg.LoopbackCertFile != "":
+			return nil, fmt.Errorf("invalid loopback-cert-file: %w", err)
+		case cfg.RequireClientCert:
+			return nil, fmt.Errorf("the server certificate cannot authenticate the clients of the node, set loopback-cert-file and loopback-key-file: %w", err)
+		}
+		logger.Info("the server certificate cannot authenticate the clients of the node, connecting without client certificate", "err", err)
+		return tlsCfg, nil
+	}
+
+	tlsCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		cert, _ := r.current()
+		return cert, nil
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,260 @@
+package tlsconfig
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	"github.com/0xPellNetwork/pellapp-sdk/testutil/tlstest"
+)
+
+// serve serves tlsCfg on a local listener, writing a byte to the clients
+// completing the handshake, and returns its address
+func serve(t *testing.T, tlsCfg *tls.Config) string {
+	t.Helper()
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", tlsCfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			if err := conn.(*tls.Conn).Handshake(); err == nil {
+				_, _ = conn.Write([]byte{1})
+			}
+			_ = conn.Close()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// dial connects to addr and returns the common name of the server
+// certificate, once the server accepted the connection
+func dial(addr string, rootCAs *x509.CertPool, certs ...tls.Certificate) (string, error) {
+	conn, err := tls.Dial("tcp", addr, &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		RootCAs:      rootCAs,
+		Certificates: certs,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	// client certificates are verified by the server after the client
+	// completed a TLS 1.3 handshake, wait for the server to accept
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return "", err
+	}
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName, nil
+}
+
+func certPool(ca *tlstest.CA) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+	return pool
+}
+
+func TestNewValidation(t *testing.T) {
+	_, err := New(config.TLSConfig{}, log.NewNopLogger())
+	require.ErrorContains(t, err, "not enabled")
+
+	_, err = New(config.TLSConfig{CertFile: "server.crt"}, log.NewNopLogger())
+	require.ErrorContains(t, err, "without key-file")
+
+	_, err = New(config.TLSConfig{CertFile: "server.crt", KeyFile: "server.key", RequireClientCert: true}, log.NewNopLogger())
+	require.ErrorContains(t, err, "requires client-ca-file")
+
+	_, err = New(config.TLSConfig{CertFile: "missing.crt", KeyFile: "missing.key"}, log.NewNopLogger())
+	require.Error(t, err)
+}
+
+func TestServerTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t, "ca")
+	certFile, keyFile, _ := ca.WriteFiles(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	tlsCfg, err := New(config.TLSConfig{CertFile: certFile, KeyFile: keyFile}, log.NewNopLogger())
+	require.NoError(t, err)
+	addr := serve(t, tlsCfg)
+
+	cn, err := dial(addr, certPool(ca))
+	require.NoError(t, err)
+	require.Equal(t, "server", cn)
+
+	// the server certificate is not trusted by the system roots
+	_, err = dial(addr, nil)
+	require.Error(t, err)
+}
+
+func TestServerMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t, "ca")
+	certFile, keyFile, caFile := ca.WriteFiles(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, err := tls.X509KeyPair(ca.Issue(t, "client", x509.ExtKeyUsageClientAuth))
+	require.NoError(t, err)
+	otherCert, err := tls.X509KeyPair(tlstest.NewCA(t, "other").Issue(t, "client", x509.ExtKeyUsageClientAuth))
+	require.NoError(t, err)
+
+	cfg := config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile}
+	tlsCfg, err := New(cfg, log.NewNopLogger())
+	require.NoError(t, err)
+	optional := serve(t, tlsCfg)
+
+	cfg.RequireClientCert = true
+	tlsCfg, err = New(cfg, log.NewNopLogger())
+	require.NoError(t, err)
+	required := serve(t, tlsCfg)
+
+	testCases := []struct {
+		name    string
+		addr    string
+		certs   []tls.Certificate
+		wantErr bool
+	}{
+		{"optional, no client cert", optional, nil, false},
+		{"optional, trusted client cert", optional, []tls.Certificate{clientCert}, false},
+		{"optional, untrusted client cert", optional, []tls.Certificate{otherCert}, true},
+		{"required, no client cert", required, nil, true},
+		{"required, trusted client cert", required, []tls.Certificate{clientCert}, false},
+		{"required, untrusted client cert", required, []tls.Certificate{otherCert}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := dial(tc.addr, certPool(ca), tc.certs...)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServerTLSReload(t *testing.T) {
+	interval := ReloadInterval
+	ReloadInterval = 0
+	t.Cleanup(func() { ReloadInterval = interval })
+
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t, "ca")
+	certFile, keyFile, _ := ca.WriteFiles(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	logs := &bytes.Buffer{}
+	tlsCfg, err := New(config.TLSConfig{CertFile: certFile, KeyFile: keyFile}, log.NewLogger(logs))
+	require.NoError(t, err)
+	addr := serve(t, tlsCfg)
+
+	cn, err := dial(addr, certPool(ca))
+	require.NoError(t, err)
+	require.Equal(t, "server", cn)
+
+	// rewrite the files, bumping their modification time which may not
+	// change within the filesystem timestamp granularity otherwise
+	rewrite := func(certPEM, keyPEM []byte) {
+		mtime := time.Now().Add(time.Minute)
+		if info, err := os.Stat(certFile); err == nil && !info.ModTime().Before(mtime) {
+			mtime = info.ModTime().Add(time.Minute)
+		}
+		for file, bz := range map[string][]byte{certFile: certPEM, keyFile: keyPEM} {
+			require.NoError(t, os.WriteFile(file, bz, 0o600))
+			require.NoError(t, os.Chtimes(file, mtime, mtime))
+		}
+	}
+
+	rewrite(ca.Issue(t, "renewed", x509.ExtKeyUsageServerAuth))
+	cn, err = dial(addr, certPool(ca))
+	require.NoError(t, err)
+	require.Equal(t, "renewed", cn)
+	require.Contains(t, logs.String(), "reloaded TLS certificates")
+
+	// invalid files are reported and the previous certificate kept
+	rewrite([]byte("invalid"), []byte("invalid"))
+	cn, err = dial(addr, certPool(ca))
+	require.NoError(t, err)
+	require.Equal(t, "renewed", cn)
+	require.Contains(t, logs.String(), "failed to reload TLS certificates")
+}
+
+func TestLoopback(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t, "ca")
+	certFile, keyFile, caFile := ca.WriteFiles(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	dualCertFile, dualKeyFile, _ := ca.WriteFiles(t, dir, "dual", x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)
+	loopbackCertFile, loopbackKeyFile, _ := ca.WriteFiles(t, dir, "loopback", x509.ExtKeyUsageClientAuth)
+	otherCertFile, otherKeyFile, _ := tlstest.NewCA(t, "other").WriteFiles(t, t.TempDir(), "loopback", x509.ExtKeyUsageClientAuth)
+
+	testCases := []struct {
+		name    string
+		cfg     config.TLSConfig
+		expErr  string
+		expCert bool
+	}{
+		{
+			name:   "server certificate without client auth usage",
+			cfg:    config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, RequireClientCert: true},
+			expErr: "set loopback-cert-file and loopback-key-file",
+		},
+		{
+			name: "optional client certificate",
+			cfg:  config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile},
+		},
+		{
+			name:    "dual use server certificate",
+			cfg:     config.TLSConfig{CertFile: dualCertFile, KeyFile: dualKeyFile, ClientCAFile: caFile, RequireClientCert: true},
+			expCert: true,
+		},
+		{
+			name: "loopback certificate",
+			cfg: config.TLSConfig{
+				CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, RequireClientCert: true,
+				LoopbackCertFile: loopbackCertFile, LoopbackKeyFile: loopbackKeyFile,
+			},
+			expCert: true,
+		},
+		{
+			name: "loopback certificate of another CA",
+			cfg: config.TLSConfig{
+				CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, RequireClientCert: true,
+				LoopbackCertFile: otherCertFile, LoopbackKeyFile: otherKeyFile,
+			},
+			expErr: "invalid loopback-cert-file",
+		},
+		{
+			name:   "loopback certificate without key",
+			cfg:    config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, LoopbackCertFile: loopbackCertFile},
+			expErr: "must be set together",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientCfg, err := NewLoopback(tc.cfg, log.NewNopLogger())
+			if tc.expErr != "" {
+				require.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expCert, clientCfg.GetClientCertificate != nil)
+
+			serverCfg, err := New(tc.cfg, log.NewNopLogger())
+			require.NoError(t, err)
+			conn, err := tls.Dial("tcp", serve(t, serverCfg), clientCfg)
+			require.NoError(t, err)
+			defer conn.Close()
+			_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			_, err = conn.Read(make([]byte, 1))
+			require.NoError(t, err)
+		})
+	}
+}
@@ -1,15 +1,20 @@
 package types
 
 import (
+	"context"
 	"encoding/json"
 	"io"
+	"time"
 
 	storetypes "cosmossdk.io/store/types"
 	"github.com/0xPellNetwork/pelldvs-libs/log"
 	dbm "github.com/cosmos/cosmos-db"
-	"github.com/cosmos/gogoproto/grpc"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 
+	"github.com/0xPellNetwork/pellapp-sdk/client"
 	"github.com/0xPellNetwork/pellapp-sdk/server/api"
 	"github.com/0xPellNetwork/pellapp-sdk/server/config"
 )
@@ -36,10 +41,30 @@ type (
 
 		// RegisterGRPCServer registers gRPC services directly with the gRPC
 		// server.
-		RegisterGRPCServer(grpc.Server)
+		RegisterGRPCServer(gogogrpc.Server)
 
-		//// RegisterNodeService registers the node gRPC Query service.
-		//RegisterNodeService(client.Context, config.Config)
+		// RegisterGRPCGatewayRoutes registers the gRPC gateway routes of the
+		// modules, routing the calls to the app in process through
+		// interceptor.
+		RegisterGRPCGatewayRoutes(*runtime.ServeMux, grpc.UnaryServerInterceptor)
+
+		// RegisterNodeService registers the node gRPC service, implemented
+		// by the app with node.RegisterNodeService on its gRPC query router.
+		RegisterNodeService(client.Context, config.Config)
+
+		// ValidateResultExtractors returns an error if the response of a
+		// request handler of the modules has no result extractor. It is
+		// called on start.
+		ValidateResultExtractors() error
+
+		// LastBlockHeight returns the version of the last committed state, 0
+		// when the state is empty.
+		LastBlockHeight() int64
+
+		// InitGenesis initializes the state of the modules from appState,
+		// their genesis states by module name, and commits it. It is called
+		// on the first start, when the state is empty.
+		InitGenesis(appState json.RawMessage) error
 
 		// CommitMultiStore return the multistore instance
 		CommitMultiStore() storetypes.CommitMultiStore
@@ -47,7 +72,19 @@ type (
 		// QueryMultiStore returns the multistore instance
 		QueryMultiStore() storetypes.MultiStore
 
-		// Close is called in start cmd to gracefully cleanup resources.
+		// ReloadIndexEvents replaces the events indexed by PellDVS while the
+		// application runs, on a change of the index-events of the app
+		// config, see config.IsReloadable.
+		ReloadIndexEvents(indexEvents []string)
+
+		// Drain stops accepting new DVS requests and waits for the requests
+		// in flight to complete or ctx to be done.
+		Drain(ctx context.Context) error
+
+		// Close is called in start cmd to gracefully cleanup resources, once
+		// the servers and node are stopped. It must not commit the stores nor
+		// close the DB while DVS requests are in flight, e.g. when Drain timed
+		// out, returning an error instead.
 		// Must be safe to be called multiple times.
 		Close() error
 	}
@@ -63,20 +100,31 @@ type (
 	// validators, consensus params and latest app height.
 	ExportedApp struct {
 		// AppState is the application state as JSON.
-		AppState json.RawMessage
+		AppState json.RawMessage `json:"app_state"`
 		// Height is the app's latest block height.
-		Height int64
+		Height int64 `json:"height"`
+	}
+
+	// AppGenesis is the genesis file of an application, written by the init
+	// command.
+	AppGenesis struct {
+		// AppName is the name of the application binary.
+		AppName string `json:"app_name"`
+		// AppVersion is the version of the application binary.
+		AppVersion string `json:"app_version"`
+		// GenesisTime is the time the genesis file was written at.
+		GenesisTime time.Time `json:"genesis_time"`
+		// AppState is the genesis states of the modules, by module name.
+		AppState json.RawMessage `json:"app_state"`
 	}
 
-	// AppExporter is a function that dumps all app state to
-	// JSON-serializable structure and returns the current validator set.
+	// AppExporter is a function that dumps the app state at height, the
+	// latest one if -1, to a JSON-serializable structure.
 	AppExporter func(
 		logger log.Logger,
 		db dbm.DB,
 		traceWriter io.Writer,
 		height int64,
-		forZeroHeight bool,
-		jailAllowedAddrs []string,
 		opts AppOptions,
 		modulesToExport []string,
 	) (ExportedApp, error)
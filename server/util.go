@@ -11,7 +11,9 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/0xPellNetwork/pelldvs-libs/log"
 	cmtcmd "github.com/0xPellNetwork/pelldvs/cmd/pelldvs/commands"
@@ -27,6 +29,8 @@ import (
 
 	"github.com/0xPellNetwork/pellapp-sdk/baseapp"
 	"github.com/0xPellNetwork/pellapp-sdk/client/flags"
+	"github.com/0xPellNetwork/pellapp-sdk/client/grpc/descriptors"
+	"github.com/0xPellNetwork/pellapp-sdk/server/appdb"
 	"github.com/0xPellNetwork/pellapp-sdk/server/config"
 	"github.com/0xPellNetwork/pellapp-sdk/server/types"
 	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
@@ -159,39 +163,129 @@ func InterceptConfigsAndCreateContext(cmd *cobra.Command, customAppConfigTemplat
 }
 
 // CreateSDKLogger creates a the default SDK logger.
-// It reads the log level and format from the server context.
+// It reads the log level and format from the server context. The level of
+// the loggers it creates is changed by SetLogLevel.
 func CreateSDKLogger(ctx *Context, out io.Writer) (log.Logger, error) {
-	var opts []log.Option
-	if ctx.Viper.GetString(flags.FlagLogFormat) == flags.OutputFormatJSON {
-		opts = append(opts, log.OutputJSONOption())
-	}
-	opts = append(opts,
-		log.ColorOption(!ctx.Viper.GetBool(flags.FlagLogNoColor)),
-		// We use PellDVS flag (cmtcli.TraceFlag) for trace logging.
-		//log.TraceOption(ctx.Viper.GetBool(FlagTrace)),
-	)
+	if err := SetLogLevel(ctx.Viper.GetString(flags.FlagLogLevel)); err != nil {
+		return nil, err
+	}
 
-	// check and set filter level or keys for the logger if any
-	logLvlStr := ctx.Viper.GetString(flags.FlagLogLevel)
-	if logLvlStr == "" {
-		return log.NewLogger(out, opts...), nil
+	// entries are filtered by module before they are formatted, so the
+	// console writer wraps the output here rather than in the logger
+	if ctx.Viper.GetString(flags.FlagLogFormat) != flags.OutputFormatJSON {
+		out = zerolog.ConsoleWriter{
+			Out:        out,
+			NoColor:    ctx.Viper.GetBool(flags.FlagLogNoColor),
+			TimeFormat: time.DateTime,
+		}
 	}
 
-	logLvl, err := zerolog.ParseLevel(logLvlStr)
-	switch {
-	case err != nil:
-		// If the log level is not a valid zerolog level, then we try to parse it as a key filter.
-		filterFunc, err := log.ParseLogLevel(logLvlStr)
-		if err != nil {
-			return nil, err
+	return log.NewLogger(levelFilterWriter{out}, log.OutputJSONOption()), nil
+}
+
+// sdkLogLevel is the level of the loggers created by CreateSDKLogger, nil if
+// all entries pass, see SetLogLevel. Other loggers, e.g. those of libraries
+// logging with zerolog, are not affected.
+var sdkLogLevel atomic.Pointer[logLevel]
+
+// logLevel is a level parsed by parseLogLevel
+type logLevel struct {
+	// min is the level below which entries are discarded
+	min zerolog.Level
+	// filter filters entries by module, nil if they are not filtered
+	filter log.FilterFunc
+}
+
+// SetLogLevel sets the level of the loggers created by CreateSDKLogger, in the
+// format of the --log_level flag, e.g. "info" or "*:info,baseapp:debug". An
+// empty level lets all entries pass.
+func SetLogLevel(levelStr string) error {
+	if levelStr == "" {
+		sdkLogLevel.Store(nil)
+		return nil
+	}
+
+	logLvl, filterFunc, err := parseLogLevel(levelStr)
+	if err != nil {
+		return err
+	}
+	if logLvl == zerolog.NoLevel {
+		logLvl = zerolog.TraceLevel
+	}
+	sdkLogLevel.Store(&logLevel{min: logLvl, filter: filterFunc})
+	return nil
+}
+
+// levelFilterWriter writes the JSON log entries passing sdkLogLevel
+type levelFilterWriter struct {
+	parent io.Writer
+}
+
+var _ zerolog.LevelWriter = levelFilterWriter{}
+
+func (w levelFilterWriter) Write(p []byte) (int, error) {
+	lvl := sdkLogLevel.Load()
+	if lvl == nil || lvl.filter == nil {
+		return w.parent.Write(p)
+	}
+	return log.NewFilterWriter(w.parent, lvl.filter).Write(p)
+}
+
+// WriteLevel discards the entries below the level before filtering them by
+// module.
+func (w levelFilterWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if lvl := sdkLogLevel.Load(); lvl != nil && level < lvl.min {
+		return len(p), nil
+	}
+	return w.Write(p)
+}
+
+// parseLogLevel parses the --log_level flag, either a single level or a list
+// of <module>:<level> pairs where "*" sets the level of the modules that are
+// not listed, e.g. "*:info,baseapp:debug". Pairs are matched against the
+// module key of the log entries. Spaces and case are ignored and "none" is an
+// alias of "disabled".
+//
+// It returns the level below which entries are discarded before filtering,
+// zerolog.NoLevel if none, and the module filter, nil for a single level.
+func parseLogLevel(levelStr string) (zerolog.Level, log.FilterFunc, error) {
+	items := strings.Split(strings.ToLower(strings.ReplaceAll(levelStr, " ", "")), ",")
+	var hasDefault bool
+	minLevel := zerolog.Disabled
+	for i, item := range items {
+		module, level, ok := strings.Cut(item, ":")
+		if !ok {
+			module, level = "*", item
+		}
+		if level == "none" {
+			level = zerolog.Disabled.String()
 		}
+		items[i] = module + ":" + level
 
-		opts = append(opts, log.FilterOption(filterFunc))
-	default:
-		opts = append(opts, log.LevelOption(logLvl))
+		lvl, err := zerolog.ParseLevel(level)
+		if err != nil || level == "" {
+			return zerolog.NoLevel, nil, fmt.Errorf("invalid log level %q in %q", level, levelStr)
+		}
+		minLevel = min(minLevel, lvl)
+		hasDefault = hasDefault || module == "*"
+	}
+
+	// a single level applies to all modules
+	if len(items) == 1 && hasDefault {
+		return minLevel, nil, nil
 	}
 
-	return log.NewLogger(out, opts...), nil
+	filterFunc, err := log.ParseLogLevel(strings.Join(items, ","))
+	if err != nil {
+		return zerolog.NoLevel, nil, err
+	}
+
+	// entries of unlisted modules pass unfiltered unless there is a default
+	// level, the lowest level then applies to all entries
+	if !hasDefault {
+		return zerolog.NoLevel, filterFunc, nil
+	}
+	return minLevel, filterFunc, nil
 }
 
 // GetServerContextFromCmd returns a Context from a command or an empty Context
@@ -300,7 +394,7 @@ func interceptConfigs(rootViper *viper.Viper, customAppTemplate string, customCo
 }
 
 // add server commands
-func AddCommands(rootCmd *cobra.Command, defaultNodeHome string, appCreator types.AppCreator, addStartFlags types.ModuleInitFlags) {
+func AddCommands(rootCmd *cobra.Command, defaultNodeHome string, appCreator types.AppCreator, appExport types.AppExporter, addStartFlags types.ModuleInitFlags) {
 	pelldvsCmds := &cobra.Command{
 		Use:   "dvs",
 		Short: "PellDVS subcommands",
@@ -317,17 +411,23 @@ func AddCommands(rootCmd *cobra.Command, defaultNodeHome string, appCreator type
 	rootCmd.AddCommand(
 		startCmd,
 		pelldvsCmds,
+		ExportCmd(appExport, defaultNodeHome),
+		ConfigCmd(),
 		version.NewVersionCommand(),
+		descriptors.NewCommand(),
 	)
 }
 
 // AddCommandsWithStartCmdOptions adds server commands with the provided StartCmdOptions.
-func AddCommandsWithStartCmdOptions(rootCmd *cobra.Command, defaultNodeHome string, appCreator types.AppCreator, opts StartCmdOptions) {
+func AddCommandsWithStartCmdOptions(rootCmd *cobra.Command, defaultNodeHome string, appCreator types.AppCreator, appExport types.AppExporter, opts StartCmdOptions) {
 	startCmd := StartCmdWithOptions(appCreator, defaultNodeHome, opts)
 
 	rootCmd.AddCommand(
 		startCmd,
+		ExportCmd(appExport, defaultNodeHome),
+		ConfigCmd(),
 		version.NewVersionCommand(),
+		descriptors.NewCommand(),
 	)
 }
 
@@ -365,19 +465,38 @@ func ExternalIP() (string, error) {
 
 // ListenForQuitSignals listens for SIGINT and SIGTERM. When a signal is received,
 // the cleanup function is called, indicating the caller can gracefully exit or
-// return.
+// return. If reload functions are given, they are called on SIGHUP, e.g. to
+// reload the configs.
 //
 // Note, the blocking behavior of this depends on the block argument.
 // The caller must ensure the corresponding context derived from the cancelFn is used correctly.
-func ListenForQuitSignals(g *errgroup.Group, block bool, cancelFn context.CancelFunc, logger log.Logger) {
+func ListenForQuitSignals(g *errgroup.Group, block bool, cancelFn context.CancelFunc, logger log.Logger, reloadFns ...func()) {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 
-	f := func() {
-		sig := <-sigCh
-		cancelFn()
+	// a nil channel never receives, SIGHUP keeping its default behavior
+	var hupCh chan os.Signal
+	if len(reloadFns) > 0 {
+		hupCh = make(chan os.Signal, 1)
+		signal.Notify(hupCh, syscall.SIGHUP)
+	}
 
-		logger.Info("caught signal", "signal", sig.String())
+	f := func() {
+		for {
+			select {
+			case sig := <-hupCh:
+				logger.Info("caught signal, reloading", "signal", sig.String())
+				for _, reload := range reloadFns {
+					reload()
+				}
+
+			case sig := <-sigCh:
+				cancelFn()
+
+				logger.Info("caught signal", "signal", sig.String())
+				return
+			}
+		}
 	}
 
 	if block {
@@ -390,8 +509,13 @@ func ListenForQuitSignals(g *errgroup.Group, block bool, cancelFn context.Cancel
 	}
 }
 
-// GetAppDBBackend gets the backend type to use for the application DBs.
+// GetAppDBBackend gets the backend type to use for the application DBs, memdb
+// if the --in-memory flag is set.
 func GetAppDBBackend(opts types.AppOptions) dbm.BackendType {
+	if cast.ToBool(opts.Get(FlagInMemory)) {
+		return dbm.MemDBBackend
+	}
+
 	rv := cast.ToString(opts.Get("app-db-backend"))
 	if len(rv) == 0 {
 		rv = cast.ToString(opts.Get("db_backend"))
@@ -435,9 +559,13 @@ func addrToIP(addr net.Addr) net.IP {
 	return ip
 }
 
-func openDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
-	dataDir := filepath.Join(rootDir, "data")
-	return dbm.NewDB("application", backendType, dataDir)
+// NewDBOpener returns the builtin opener of the application database, in the
+// data directory of the root directory, tuned with the goleveldb and pebble
+// sections of cfg.
+func NewDBOpener(cfg config.Config) func(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
+	return func(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
+		return appdb.Open(appdb.Name, filepath.Join(rootDir, "data"), backendType, cfg)
+	}
 }
 
 func openTraceWriter(traceWriterFile string) (w io.WriteCloser, err error) {
@@ -453,5 +581,7 @@ func openTraceWriter(traceWriterFile string) (w io.WriteCloser, err error) {
 
 // DefaultBaseappOptions returns the default baseapp options provided by the Cosmos SDK
 func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
-	return []func(*baseapp.BaseApp){}
+	return []func(*baseapp.BaseApp){
+		baseapp.SetIndexEvents(cast.ToStringSlice(appOpts.Get(FlagIndexEvents))),
+	}
 }
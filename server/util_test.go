@@ -0,0 +1,87 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/client/flags"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	for _, tc := range []struct {
+		levelStr string
+		minLevel zerolog.Level
+		filtered bool
+	}{
+		{levelStr: "info", minLevel: zerolog.InfoLevel},
+		{levelStr: " DEBUG ", minLevel: zerolog.DebugLevel},
+		{levelStr: "none", minLevel: zerolog.Disabled},
+		{levelStr: "*:info", minLevel: zerolog.InfoLevel},
+		{levelStr: "*:error,baseapp:debug", minLevel: zerolog.DebugLevel, filtered: true},
+		// unlisted modules pass without a default level
+		{levelStr: "baseapp:error", minLevel: zerolog.NoLevel, filtered: true},
+	} {
+		t.Run(tc.levelStr, func(t *testing.T) {
+			minLevel, filterFunc, err := parseLogLevel(tc.levelStr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.minLevel, minLevel)
+			assert.Equal(t, tc.filtered, filterFunc != nil)
+		})
+	}
+
+	_, filterFunc, err := parseLogLevel("*:error,baseapp:debug")
+	require.NoError(t, err)
+	assert.False(t, filterFunc("baseapp", "debug"))
+	assert.True(t, filterFunc("server", "info"))
+	assert.False(t, filterFunc("server", "error"))
+
+	for _, levelStr := range []string{"", "loud", "baseapp:", "baseapp:loud,*:info"} {
+		_, _, err := parseLogLevel(levelStr)
+		require.ErrorContains(t, err, "invalid log level", levelStr)
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetLogLevel("")) })
+	globalLevel := zerolog.GlobalLevel()
+
+	v := viper.New()
+	v.Set(flags.FlagLogLevel, "info")
+	v.Set(flags.FlagLogFormat, flags.OutputFormatJSON)
+	var out bytes.Buffer
+	logger, err := CreateSDKLogger(NewContext(v, nil, nil), &out)
+	require.NoError(t, err)
+
+	logger.Debug("hidden")
+	logger.Info("shown")
+	assert.NotContains(t, out.String(), "hidden")
+	assert.Contains(t, out.String(), "shown")
+
+	// the level is scoped to the SDK loggers
+	assert.Equal(t, globalLevel, zerolog.GlobalLevel())
+	var other bytes.Buffer
+	otherLogger := zerolog.New(&other)
+	otherLogger.Debug().Msg("other")
+	assert.Contains(t, other.String(), "other")
+
+	// the level of the loggers created is changed
+	require.NoError(t, SetLogLevel("*:error,baseapp:debug"))
+	out.Reset()
+	logger.With("module", "baseapp").Debug("baseapp debug")
+	logger.With("module", "server").Info("server info")
+	logger.With("module", "server").Error("server error")
+	assert.Contains(t, out.String(), "baseapp debug")
+	assert.NotContains(t, out.String(), "server info")
+	assert.Contains(t, out.String(), "server error")
+
+	require.ErrorContains(t, SetLogLevel("loud"), "invalid log level")
+	require.NoError(t, SetLogLevel(""))
+	out.Reset()
+	logger.Debug("all entries pass")
+	assert.Contains(t, out.String(), "all entries pass")
+}
@@ -0,0 +1,224 @@
+package abicodec
+
+import (
+	"fmt"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/0xPellNetwork/pellapp-sdk/service/tx"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+var _ tx.MsgEncoder = &Coder{}
+
+// Route binds a contract method to the message its calldata decodes into.
+// Inputs of the method are matched to message fields by name (camelCase and
+// snake_case are equivalent), unnamed inputs are matched by position.
+type Route struct {
+	// Method is the name of the method in the contract ABI.
+	Method string
+	// Msg is the message the calldata of Method decodes into.
+	Msg sdk.Msg
+	// Response is optional, when set results of this type are ABI encoded with
+	// the outputs of Method.
+	Response proto.Message
+}
+
+// route is a validated Route.
+type route struct {
+	method   abi.Method
+	msgType  reflect.Type
+	response proto.Message
+}
+
+// Option configures a Coder.
+type Option func(*Coder)
+
+// WithFallback sets the encoder used for data that does not start with a
+// known method selector and for messages that are not routed.
+func WithFallback(encoder tx.MsgEncoder) Option {
+	return func(c *Coder) {
+		c.fallback = encoder
+	}
+}
+
+// Coder is a tx.MsgEncoder that bridges Solidity ABI-encoded calldata to
+// protobuf messages. The first four bytes of the calldata select the route
+// and the remaining bytes are unpacked into the routed message.
+type Coder struct {
+	contract   abi.ABI
+	routes     []route // in the order they were declared
+	bySelector map[[4]byte]route
+	byMsgType  map[string]route
+	fallback   tx.MsgEncoder
+}
+
+// NewCoder creates a Coder for the methods of contract declared in routes. It
+// fails if a method is unknown, routed more than once, if a message or
+// Response is routed more than once, or if one of the inputs of a method, or
+// outputs when Response is set, cannot be bound to a message field.
+func NewCoder(contract abi.ABI, routes []Route, opts ...Option) (*Coder, error) {
+	c := &Coder{
+		contract:   contract,
+		bySelector: make(map[[4]byte]route, len(routes)),
+		byMsgType:  make(map[string]route, len(routes)),
+	}
+
+	responses := make(map[string]struct{}, len(routes))
+	for _, r := range routes {
+		method, ok := contract.Methods[r.Method]
+		if !ok {
+			return nil, fmt.Errorf("method %s not found in contract ABI", r.Method)
+		}
+		if _, ok := c.bySelector[[4]byte(method.ID)]; ok {
+			return nil, fmt.Errorf("method %s is routed more than once", method.Sig)
+		}
+		if r.Msg == nil {
+			return nil, fmt.Errorf("no message routed for method %s", r.Method)
+		}
+
+		msgType := sdk.MsgTypeURL(r.Msg)
+		if _, ok := c.byMsgType[msgType]; ok {
+			return nil, fmt.Errorf("message %s is routed more than once", msgType)
+		}
+
+		m, err := ReflectMessage(r.Msg)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateFields(m.Descriptor(), argumentFields(method.Inputs)); err != nil {
+			return nil, fmt.Errorf("method %s: %w", method.Sig, err)
+		}
+
+		if r.Response != nil {
+			// the results of a type are digested with the outputs of a
+			// single method
+			responseType := sdk.MsgTypeURL(r.Response)
+			if _, ok := responses[responseType]; ok {
+				return nil, fmt.Errorf("response %s is routed more than once", responseType)
+			}
+			responses[responseType] = struct{}{}
+
+			res, err := ReflectMessage(r.Response)
+			if err != nil {
+				return nil, err
+			}
+			if err := validateFields(res.Descriptor(), argumentFields(method.Outputs)); err != nil {
+				return nil, fmt.Errorf("method %s outputs: %w", method.Sig, err)
+			}
+		}
+
+		rt := route{
+			method:   method,
+			msgType:  reflect.TypeOf(r.Msg),
+			response: r.Response,
+		}
+		c.routes = append(c.routes, rt)
+		c.bySelector[[4]byte(method.ID)] = rt
+		c.byMsgType[msgType] = rt
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Decode unpacks calldata into the message routed for its method selector.
+func (c *Coder) Decode(data []byte) (sdk.Tx, error) {
+	var rt route
+	ok := len(data) >= 4
+	if ok {
+		rt, ok = c.bySelector[[4]byte(data[:4])]
+	}
+	if !ok {
+		if c.fallback != nil {
+			return c.fallback.Decode(data)
+		}
+		return nil, fmt.Errorf("no route for calldata selector %x", data[:min(len(data), 4)])
+	}
+
+	values, err := rt.method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s calldata: %w", rt.method.Sig, err)
+	}
+
+	msg := reflect.New(rt.msgType.Elem()).Interface().(sdk.Msg)
+	m, err := ReflectMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	if err := fillMessage(m, argumentFields(rt.method.Inputs), func(i int) reflect.Value {
+		return reflect.ValueOf(values[i])
+	}); err != nil {
+		return nil, err
+	}
+	if err := writeBack(msg, m); err != nil {
+		return nil, err
+	}
+
+	return callTx{msg: msg}, nil
+}
+
+// Encode packs the single message of tx into calldata.
+func (c *Coder) Encode(tx sdk.Tx) ([]byte, error) {
+	return c.EncodeMsgs(tx.GetMsgs()...)
+}
+
+// EncodeMsgs packs msgs into calldata. A contract call carries exactly one
+// message.
+func (c *Coder) EncodeMsgs(msgs ...sdk.Msg) ([]byte, error) {
+	if len(msgs) != 1 {
+		if c.fallback != nil {
+			return c.fallback.EncodeMsgs(msgs...)
+		}
+		return nil, fmt.Errorf("calldata carries exactly one message, got %d", len(msgs))
+	}
+
+	rt, ok := c.byMsgType[sdk.MsgTypeURL(msgs[0])]
+	if !ok {
+		if c.fallback != nil {
+			return c.fallback.EncodeMsgs(msgs...)
+		}
+		return nil, fmt.Errorf("no route for message %s", sdk.MsgTypeURL(msgs[0]))
+	}
+
+	data, err := PackMessage(rt.method.Inputs, msgs[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s calldata: %w", rt.method.Sig, err)
+	}
+	return append(append([]byte{}, rt.method.ID...), data...), nil
+}
+
+// RegisterResultMsgExtractors registers a ResultExtractor built from the
+// method outputs for every route that declares a Response, in the order of
+// the routes.
+func (c *Coder) RegisterResultMsgExtractors(configurator sdktypes.Configurator) {
+	for _, rt := range c.routes {
+		if rt.response != nil {
+			configurator.RegisterResultMsgExtractor(rt.response, NewResultExtractor(rt.method.Outputs))
+		}
+	}
+}
+
+// callTx is the sdk.Tx decoded from a single contract call.
+type callTx struct {
+	msg sdk.Msg
+}
+
+func (t callTx) GetMsgs() []sdk.Msg {
+	return []sdk.Msg{t.msg}
+}
+
+func (t callTx) GetMsgsV2() ([]protov2.Message, error) {
+	m, err := ReflectMessage(t.msg)
+	if err != nil {
+		return nil, err
+	}
+	return []protov2.Message{m.Interface()}, nil
+}
@@ -0,0 +1,350 @@
+package abicodec
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	protov2 "google.golang.org/protobuf/proto"
+
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
+	"github.com/0xPellNetwork/pellapp-sdk/service"
+	"github.com/0xPellNetwork/pellapp-sdk/service/result"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+const testContractABI = `[
+	{
+		"type": "function",
+		"name": "submitTask",
+		"inputs": [
+			{"name": "taskId", "type": "uint64"},
+			{"name": "operator", "type": "address"},
+			{"name": "payload", "type": "bytes"},
+			{"name": "urgent", "type": "bool"},
+			{"name": "amount", "type": "uint256"},
+			{"name": "groupNumbers", "type": "uint32[]"},
+			{"name": "digest", "type": "bytes32"},
+			{"name": "info", "type": "tuple", "components": [
+				{"name": "name", "type": "string"},
+				{"name": "weight", "type": "int64"}
+			]}
+		],
+		"outputs": [
+			{"name": "taskId", "type": "uint64"},
+			{"name": "digest", "type": "bytes32"},
+			{"name": "accepted", "type": "bool"}
+		]
+	},
+	{
+		"type": "function",
+		"name": "submitTx",
+		"inputs": [
+			{"name": "bodyBytes", "type": "bytes"},
+			{"name": "authInfoBytes", "type": "bytes"},
+			{"name": "signatures", "type": "bytes[]"}
+		],
+		"outputs": []
+	},
+	{
+		"type": "function",
+		"name": "misnamed",
+		"inputs": [{"name": "unknownField", "type": "uint64"}],
+		"outputs": []
+	}
+]`
+
+// MockMsgEncoder implements tx.MsgEncoder for testing
+type MockMsgEncoder struct {
+	mock.Mock
+}
+
+func (m *MockMsgEncoder) Decode(txBytes []byte) (sdk.Tx, error) {
+	args := m.Called(txBytes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(sdk.Tx), args.Error(1)
+}
+
+func (m *MockMsgEncoder) Encode(tx sdk.Tx) ([]byte, error) {
+	args := m.Called(tx)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockMsgEncoder) EncodeMsgs(msgs ...sdk.Msg) ([]byte, error) {
+	args := m.Called(msgs)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func testContract(t *testing.T) abi.ABI {
+	contract, err := abi.JSON(strings.NewReader(testContractABI))
+	require.NoError(t, err)
+	return contract
+}
+
+func testCoder(t *testing.T, opts ...Option) *Coder {
+	coder, err := NewCoder(testContract(t), []Route{
+		{Method: "submitTask", Msg: &testpb.TestCallMsg{}, Response: &testpb.TestCallResult{}},
+		{Method: "submitTx", Msg: &txtypes.TxRaw{}},
+	}, opts...)
+	require.NoError(t, err)
+	return coder
+}
+
+func testCallMsg() *testpb.TestCallMsg {
+	return &testpb.TestCallMsg{
+		TaskId:       42,
+		Operator:     "0x00000000000000000000000000000000000000AA",
+		Payload:      []byte("payload"),
+		Urgent:       true,
+		Amount:       "1000000000000000000000",
+		GroupNumbers: []uint32{0, 1, 3},
+		Digest:       crypto.Keccak256([]byte("digest")),
+		Info:         &testpb.TestCallInfo{Name: "task", Weight: -7},
+	}
+}
+
+func TestCoderRoundTrip(t *testing.T) {
+	coder := testCoder(t)
+	msg := testCallMsg()
+
+	data, err := coder.EncodeMsgs(msg)
+	require.NoError(t, err)
+	assert.Equal(t, testContract(t).Methods["submitTask"].ID, data[:4])
+
+	decoded, err := coder.Decode(data)
+	require.NoError(t, err)
+	require.Len(t, decoded.GetMsgs(), 1)
+
+	got := decoded.GetMsgs()[0].(*testpb.TestCallMsg)
+	// addresses are decoded in their checksummed form
+	assert.Equal(t, common.HexToAddress(msg.Operator).Hex(), got.Operator)
+	got.Operator = msg.Operator
+	assert.True(t, protov2.Equal(msg, got))
+
+	msgsV2, err := decoded.GetMsgsV2()
+	require.NoError(t, err)
+	require.Len(t, msgsV2, 1)
+	assert.True(t, protov2.Equal(got, msgsV2[0]))
+}
+
+func TestCoderDecodeCalldata(t *testing.T) {
+	contract := testContract(t)
+	coder := testCoder(t)
+
+	amount, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	var digest [32]byte
+	copy(digest[:], crypto.Keccak256([]byte("digest")))
+	info := struct {
+		Name   string
+		Weight int64
+	}{Name: "task", Weight: -7}
+
+	data, err := contract.Pack("submitTask",
+		uint64(42),
+		common.HexToAddress("0xAA"),
+		[]byte("payload"),
+		true,
+		amount,
+		[]uint32{0, 1, 3},
+		digest,
+		info,
+	)
+	require.NoError(t, err)
+
+	decoded, err := coder.Decode(data)
+	require.NoError(t, err)
+
+	got := decoded.GetMsgs()[0].(*testpb.TestCallMsg)
+	assert.Equal(t, uint64(42), got.TaskId)
+	assert.Equal(t, common.HexToAddress("0xAA").Hex(), got.Operator)
+	assert.Equal(t, []byte("payload"), got.Payload)
+	assert.True(t, got.Urgent)
+	assert.Equal(t, amount.String(), got.Amount)
+	assert.Equal(t, []uint32{0, 1, 3}, got.GroupNumbers)
+	assert.Equal(t, digest[:], got.Digest)
+	assert.Equal(t, "task", got.Info.Name)
+	assert.Equal(t, int64(-7), got.Info.Weight)
+}
+
+func TestCoderGogoMessage(t *testing.T) {
+	coder := testCoder(t)
+	msg := &txtypes.TxRaw{
+		BodyBytes:     []byte("body"),
+		AuthInfoBytes: []byte("auth"),
+		Signatures:    [][]byte{[]byte("sig1"), []byte("sig2")},
+	}
+
+	data, err := coder.EncodeMsgs(msg)
+	require.NoError(t, err)
+
+	decoded, err := coder.Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, msg, decoded.GetMsgs()[0])
+}
+
+func TestCoderFallback(t *testing.T) {
+	data := []byte{0xde, 0xad, 0xbe, 0xef, 0x01}
+
+	_, err := testCoder(t).Decode(data)
+	assert.ErrorContains(t, err, "no route for calldata selector deadbeef")
+
+	_, err = testCoder(t).EncodeMsgs(&testpb.TestMsg{})
+	assert.ErrorContains(t, err, "no route for message")
+
+	_, err = testCoder(t).EncodeMsgs(testCallMsg(), testCallMsg())
+	assert.ErrorContains(t, err, "exactly one message")
+
+	fallback := new(MockMsgEncoder)
+	fallback.On("Decode", data).Return(nil, assert.AnError)
+	fallback.On("EncodeMsgs", mock.Anything).Return([]byte("fallback"), nil)
+	coder := testCoder(t, WithFallback(fallback))
+
+	_, err = coder.Decode(data)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	bz, err := coder.EncodeMsgs(&testpb.TestMsg{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("fallback"), bz)
+	fallback.AssertExpectations(t)
+}
+
+func TestNewCoderValidation(t *testing.T) {
+	contract := testContract(t)
+
+	testCases := []struct {
+		name   string
+		routes []Route
+		errMsg string
+	}{
+		{
+			name:   "unknown method",
+			routes: []Route{{Method: "missing", Msg: &testpb.TestCallMsg{}}},
+			errMsg: "method missing not found",
+		},
+		{
+			name:   "unknown field",
+			routes: []Route{{Method: "misnamed", Msg: &testpb.TestCallMsg{}}},
+			errMsg: `no field matching "unknownField"`,
+		},
+		{
+			name:   "incompatible field",
+			routes: []Route{{Method: "submitTask", Msg: &testpb.TestCallResult{}}},
+			errMsg: "no field matching",
+		},
+		{
+			name:   "incompatible outputs",
+			routes: []Route{{Method: "submitTask", Msg: &testpb.TestCallMsg{}, Response: &testpb.TestMsg{}}},
+			errMsg: "outputs",
+		},
+		{
+			name: "duplicate message",
+			routes: []Route{
+				{Method: "submitTask", Msg: &testpb.TestCallMsg{}},
+				{Method: "misnamed", Msg: &testpb.TestCallMsg{}},
+			},
+			errMsg: "message /test.service.TestCallMsg is routed more than once",
+		},
+		{
+			name: "duplicate method",
+			routes: []Route{
+				{Method: "submitTx", Msg: &txtypes.TxRaw{}},
+				{Method: "submitTx", Msg: &testpb.TestMsg{}},
+			},
+			errMsg: "method submitTx(bytes,bytes,bytes[]) is routed more than once",
+		},
+		{
+			name: "duplicate response",
+			routes: []Route{
+				{Method: "submitTask", Msg: &testpb.TestCallMsg{}, Response: &testpb.TestCallResult{}},
+				{Method: "submitTx", Msg: &txtypes.TxRaw{}, Response: &testpb.TestCallResult{}},
+			},
+			errMsg: "response /test.service.TestCallResult is routed more than once",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewCoder(contract, tc.routes)
+			assert.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestResultExtractor(t *testing.T) {
+	outputs := testContract(t).Methods["submitTask"].Outputs
+	extractor := NewResultExtractor(outputs)
+	res := &testpb.TestCallResult{
+		TaskId:   42,
+		Digest:   crypto.Keccak256([]byte("digest")),
+		Accepted: true,
+	}
+
+	data, err := extractor.GetData(res)
+	require.NoError(t, err)
+
+	values, err := outputs.Unpack(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), values[0])
+	digest := values[1].([32]byte)
+	assert.Equal(t, res.Digest, digest[:])
+	assert.Equal(t, true, values[2])
+
+	resDigest, err := extractor.GetDigest(res)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256(data), resDigest)
+
+	_, err = extractor.GetData(&testpb.TestCallResult{Digest: []byte("short")})
+	assert.ErrorContains(t, err, "expected 32 bytes")
+}
+
+func TestRegisterResultMsgExtractors(t *testing.T) {
+	coder := testCoder(t)
+	resultManager := result.NewCustomResultManager()
+	configurator := service.NewConfigurator(coder, resultManager).(*service.Configurator)
+	coder.RegisterResultMsgExtractors(configurator)
+
+	res := &testpb.TestCallResult{TaskId: 1, Accepted: true}
+	avsiResult, err := resultManager.WrapServiceResult(sdktypes.NewContext(context.Background(), nil, nil), res, nil)
+	require.NoError(t, err)
+
+	expected, err := NewResultExtractor(testContract(t).Methods["submitTask"].Outputs).GetData(res)
+	require.NoError(t, err)
+	assert.Equal(t, expected, avsiResult.CustomData)
+	assert.Equal(t, crypto.Keccak256(expected), avsiResult.CustomDigest)
+}
+
+func TestMessageArguments(t *testing.T) {
+	args, err := MessageArguments((&testpb.TestCallMsg{}).ProtoReflect().Descriptor())
+	require.NoError(t, err)
+
+	inputs := testContract(t).Methods["submitTask"].Inputs
+	require.Len(t, args, len(inputs))
+	for i, arg := range args {
+		assert.Equal(t, inputs[i].Type.String(), arg.Type.String())
+	}
+
+	// annotations make PackFields match the contract encoding
+	msg := testCallMsg()
+	data, err := PackFields(msg)
+	require.NoError(t, err)
+	calldata, err := testCoder(t).EncodeMsgs(msg)
+	require.NoError(t, err)
+	assert.Equal(t, calldata[4:], data)
+
+	raw, err := ReflectMessage(&txtypes.TxRaw{})
+	require.NoError(t, err)
+	args, err = MessageArguments(raw.Descriptor())
+	require.NoError(t, err)
+	assert.Equal(t, "bytes[]", args[2].Type.String())
+}
@@ -0,0 +1,489 @@
+package abicodec
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var bigIntType = reflect.TypeOf(&big.Int{})
+
+// abiField is a named Solidity value, either a top-level argument or a tuple component.
+type abiField struct {
+	name string
+	typ  abi.Type
+}
+
+func argumentFields(args abi.Arguments) []abiField {
+	fields := make([]abiField, len(args))
+	for i, arg := range args {
+		fields[i] = abiField{name: arg.Name, typ: arg.Type}
+	}
+	return fields
+}
+
+func tupleFields(typ abi.Type) []abiField {
+	fields := make([]abiField, len(typ.TupleElems))
+	for i, elem := range typ.TupleElems {
+		fields[i] = abiField{name: typ.TupleRawNames[i], typ: *elem}
+	}
+	return fields
+}
+
+// ReflectMessage returns a protoreflect view of msg. Messages generated by
+// protoc-gen-go are reflected directly, gogoproto messages are copied into a
+// dynamic message built from their registered descriptor.
+func ReflectMessage(msg proto.Message) (protoreflect.Message, error) {
+	if m, ok := msg.(protov2.Message); ok {
+		return m.ProtoReflect(), nil
+	}
+
+	name := proto.MessageName(msg)
+	desc, err := proto.HybridResolver.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("cannot find descriptor of %s: %w", name, err)
+	}
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message", name)
+	}
+
+	bz, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	dyn := dynamicpb.NewMessage(md)
+	if err := protov2.Unmarshal(bz, dyn); err != nil {
+		return nil, err
+	}
+	return dyn, nil
+}
+
+// writeBack copies m into msg when m is a dynamic copy created by ReflectMessage.
+func writeBack(msg proto.Message, m protoreflect.Message) error {
+	if _, ok := msg.(protov2.Message); ok {
+		return nil
+	}
+
+	bz, err := protov2.Marshal(m.Interface())
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(bz, msg)
+}
+
+// normalizeName makes Solidity (camelCase, leading underscore) and protobuf
+// (snake_case) identifiers comparable.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimLeft(name, "_"), "_", ""))
+}
+
+// resolveField returns the field of md bound to the Solidity value called name.
+// Unnamed values are bound by position.
+func resolveField(md protoreflect.MessageDescriptor, name string, index int) (protoreflect.FieldDescriptor, error) {
+	fields := md.Fields()
+	if name == "" {
+		if index < fields.Len() {
+			return fields.Get(index), nil
+		}
+		return nil, fmt.Errorf("%s has no field at position %d", md.FullName(), index)
+	}
+
+	want := normalizeName(name)
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if normalizeName(string(fd.Name())) == want {
+			return fd, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no field matching %q", md.FullName(), name)
+}
+
+func isList(typ abi.Type) bool {
+	return typ.T == abi.SliceTy || typ.T == abi.ArrayTy
+}
+
+// validateFields checks that every Solidity value can be bound to a field of md.
+func validateFields(md protoreflect.MessageDescriptor, fields []abiField) error {
+	for i, f := range fields {
+		fd, err := resolveField(md, f.name, i)
+		if err != nil {
+			return err
+		}
+
+		typ := f.typ
+		if isList(typ) {
+			if !fd.IsList() {
+				return fmt.Errorf("%s: %s must be a repeated field to hold %s", md.FullName(), fd.Name(), typ.String())
+			}
+			typ = *typ.Elem
+		} else if fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("%s: %s cannot hold %s", md.FullName(), fd.Name(), typ.String())
+		}
+
+		if typ.T == abi.TupleTy {
+			if fd.Kind() != protoreflect.MessageKind {
+				return fmt.Errorf("%s: %s must be a message to hold %s", md.FullName(), fd.Name(), typ.String())
+			}
+			if err := validateFields(fd.Message(), tupleFields(typ)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fillMessage sets the fields of m from values unpacked by the abi package.
+func fillMessage(m protoreflect.Message, fields []abiField, value func(i int) reflect.Value) error {
+	md := m.Descriptor()
+	for i, f := range fields {
+		fd, err := resolveField(md, f.name, i)
+		if err != nil {
+			return err
+		}
+		if err := setField(m, fd, f.typ, value(i)); err != nil {
+			return fmt.Errorf("%s.%s: %w", md.FullName(), fd.Name(), err)
+		}
+	}
+	return nil
+}
+
+func setField(m protoreflect.Message, fd protoreflect.FieldDescriptor, typ abi.Type, v reflect.Value) error {
+	if isList(typ) {
+		if !fd.IsList() {
+			return fmt.Errorf("cannot hold %s in a singular field", typ.String())
+		}
+		list := m.Mutable(fd).List()
+		for i := 0; i < v.Len(); i++ {
+			elem, err := fromABIValue(*typ.Elem, fd, v.Index(i), list.NewElement)
+			if err != nil {
+				return err
+			}
+			list.Append(elem)
+		}
+		return nil
+	}
+
+	pv, err := fromABIValue(typ, fd, v, func() protoreflect.Value { return m.NewField(fd) })
+	if err != nil {
+		return err
+	}
+	m.Set(fd, pv)
+	return nil
+}
+
+// fromABIValue converts a single (non list) value unpacked by the abi package
+// into a protobuf value of the kind of fd.
+func fromABIValue(typ abi.Type, fd protoreflect.FieldDescriptor, v reflect.Value, newMessage func() protoreflect.Value) (protoreflect.Value, error) {
+	kind := fd.Kind()
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		return intToProto(kind, toBigInt(v))
+
+	case abi.BoolTy:
+		if kind == protoreflect.BoolKind {
+			return protoreflect.ValueOfBool(v.Bool()), nil
+		}
+
+	case abi.StringTy:
+		switch kind {
+		case protoreflect.StringKind:
+			return protoreflect.ValueOfString(v.String()), nil
+		case protoreflect.BytesKind:
+			return protoreflect.ValueOfBytes([]byte(v.String())), nil
+		}
+
+	case abi.AddressTy:
+		addr := v.Interface().(common.Address)
+		switch kind {
+		case protoreflect.StringKind:
+			return protoreflect.ValueOfString(addr.Hex()), nil
+		case protoreflect.BytesKind:
+			return protoreflect.ValueOfBytes(addr.Bytes()), nil
+		}
+
+	case abi.FixedBytesTy, abi.BytesTy:
+		bz := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(bz), v)
+		switch kind {
+		case protoreflect.BytesKind:
+			return protoreflect.ValueOfBytes(bz), nil
+		case protoreflect.StringKind:
+			return protoreflect.ValueOfString(hexutil.Encode(bz)), nil
+		}
+
+	case abi.TupleTy:
+		if kind == protoreflect.MessageKind {
+			pv := newMessage()
+			if err := fillMessage(pv.Message(), tupleFields(typ), v.Field); err != nil {
+				return protoreflect.Value{}, err
+			}
+			return pv, nil
+		}
+	}
+
+	return protoreflect.Value{}, fmt.Errorf("cannot convert %s into a %s field", typ.String(), kind)
+}
+
+func toBigInt(v reflect.Value) *big.Int {
+	switch {
+	case v.Type() == bigIntType:
+		return new(big.Int).Set(v.Interface().(*big.Int))
+	case v.CanInt():
+		return big.NewInt(v.Int())
+	default:
+		return new(big.Int).SetUint64(v.Uint())
+	}
+}
+
+func intToProto(kind protoreflect.Kind, n *big.Int) (protoreflect.Value, error) {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if n.IsInt64() && n.Int64() >= math.MinInt32 && n.Int64() <= math.MaxInt32 {
+			return protoreflect.ValueOfInt32(int32(n.Int64())), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if n.IsInt64() {
+			return protoreflect.ValueOfInt64(n.Int64()), nil
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if n.IsUint64() && n.Uint64() <= math.MaxUint32 {
+			return protoreflect.ValueOfUint32(uint32(n.Uint64())), nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if n.IsUint64() {
+			return protoreflect.ValueOfUint64(n.Uint64()), nil
+		}
+	case protoreflect.EnumKind:
+		if n.IsInt64() && n.Int64() >= math.MinInt32 && n.Int64() <= math.MaxInt32 {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n.Int64())), nil
+		}
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(n.String()), nil
+	case protoreflect.BytesKind:
+		if n.Sign() >= 0 {
+			return protoreflect.ValueOfBytes(n.Bytes()), nil
+		}
+	default:
+		return protoreflect.Value{}, fmt.Errorf("cannot convert an integer into a %s field", kind)
+	}
+	return protoreflect.Value{}, fmt.Errorf("integer %s overflows a %s field", n, kind)
+}
+
+// PackMessage ABI encodes the fields of msg bound to args.
+func PackMessage(args abi.Arguments, msg proto.Message) ([]byte, error) {
+	m, err := ReflectMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := readMessage(m, argumentFields(args))
+	if err != nil {
+		return nil, err
+	}
+	return args.Pack(values...)
+}
+
+// readMessage returns the values of the fields of m in the Go types expected
+// by the abi package.
+func readMessage(m protoreflect.Message, fields []abiField) ([]any, error) {
+	md := m.Descriptor()
+	values := make([]any, len(fields))
+	for i, f := range fields {
+		fd, err := resolveField(md, f.name, i)
+		if err != nil {
+			return nil, err
+		}
+		v, err := getField(m, fd, f.typ)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", md.FullName(), fd.Name(), err)
+		}
+		values[i] = v.Interface()
+	}
+	return values, nil
+}
+
+func getField(m protoreflect.Message, fd protoreflect.FieldDescriptor, typ abi.Type) (reflect.Value, error) {
+	if !isList(typ) {
+		if fd.IsList() || fd.IsMap() {
+			return reflect.Value{}, fmt.Errorf("cannot hold %s in a repeated field", typ.String())
+		}
+		return toABIValue(typ, fd, m.Get(fd))
+	}
+
+	if !fd.IsList() {
+		return reflect.Value{}, fmt.Errorf("cannot hold %s in a singular field", typ.String())
+	}
+	list := m.Get(fd).List()
+
+	var out reflect.Value
+	if typ.T == abi.SliceTy {
+		out = reflect.MakeSlice(typ.GetType(), list.Len(), list.Len())
+	} else {
+		if list.Len() != typ.Size {
+			return reflect.Value{}, fmt.Errorf("expected %d elements for %s, got %d", typ.Size, typ.String(), list.Len())
+		}
+		out = reflect.New(typ.GetType()).Elem()
+	}
+
+	for i := 0; i < list.Len(); i++ {
+		elem, err := toABIValue(*typ.Elem, fd, list.Get(i))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.Index(i).Set(elem)
+	}
+	return out, nil
+}
+
+// toABIValue converts a single (non list) protobuf value of the kind of fd
+// into the Go type the abi package packs as typ.
+func toABIValue(typ abi.Type, fd protoreflect.FieldDescriptor, v protoreflect.Value) (reflect.Value, error) {
+	kind := fd.Kind()
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		n, err := protoToBigInt(kind, v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return bigIntToABI(typ, n)
+
+	case abi.BoolTy:
+		if kind == protoreflect.BoolKind {
+			return reflect.ValueOf(v.Bool()), nil
+		}
+
+	case abi.StringTy:
+		switch kind {
+		case protoreflect.StringKind:
+			return reflect.ValueOf(v.String()), nil
+		case protoreflect.BytesKind:
+			return reflect.ValueOf(string(v.Bytes())), nil
+		}
+
+	case abi.AddressTy:
+		switch kind {
+		case protoreflect.StringKind:
+			s := v.String()
+			if s != "" && !common.IsHexAddress(s) {
+				return reflect.Value{}, fmt.Errorf("invalid address %q", s)
+			}
+			return reflect.ValueOf(common.HexToAddress(s)), nil
+		case protoreflect.BytesKind:
+			bz := v.Bytes()
+			if len(bz) != 0 && len(bz) != common.AddressLength {
+				return reflect.Value{}, fmt.Errorf("invalid address length %d", len(bz))
+			}
+			return reflect.ValueOf(common.BytesToAddress(bz)), nil
+		}
+
+	case abi.FixedBytesTy, abi.BytesTy:
+		bz, err := protoBytes(kind, v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if typ.T == abi.BytesTy {
+			return reflect.ValueOf(bz), nil
+		}
+		if len(bz) != 0 && len(bz) != typ.Size {
+			return reflect.Value{}, fmt.Errorf("expected %d bytes for %s, got %d", typ.Size, typ.String(), len(bz))
+		}
+		out := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(out, reflect.ValueOf(bz))
+		return out, nil
+
+	case abi.TupleTy:
+		if kind == protoreflect.MessageKind {
+			sub := v.Message()
+			out := reflect.New(typ.TupleType).Elem()
+			values, err := readMessage(sub, tupleFields(typ))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			for i, value := range values {
+				out.Field(i).Set(reflect.ValueOf(value))
+			}
+			return out, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("cannot convert a %s field into %s", kind, typ.String())
+}
+
+func protoBytes(kind protoreflect.Kind, v protoreflect.Value) ([]byte, error) {
+	switch kind {
+	case protoreflect.BytesKind:
+		return v.Bytes(), nil
+	case protoreflect.StringKind:
+		if v.String() == "" {
+			return nil, nil
+		}
+		return hexutil.Decode(v.String())
+	default:
+		return nil, fmt.Errorf("cannot convert a %s field into bytes", kind)
+	}
+}
+
+func protoToBigInt(kind protoreflect.Kind, v protoreflect.Value) (*big.Int, error) {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return big.NewInt(v.Int()), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	case protoreflect.EnumKind:
+		return big.NewInt(int64(v.Enum())), nil
+	case protoreflect.StringKind:
+		if v.String() == "" {
+			return new(big.Int), nil
+		}
+		n, ok := new(big.Int).SetString(v.String(), 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", v.String())
+		}
+		return n, nil
+	case protoreflect.BytesKind:
+		return new(big.Int).SetBytes(v.Bytes()), nil
+	default:
+		return nil, fmt.Errorf("cannot convert a %s field into an integer", kind)
+	}
+}
+
+// bigIntToABI range checks n against typ and returns it in the Go type the abi
+// package expects for typ.
+func bigIntToABI(typ abi.Type, n *big.Int) (reflect.Value, error) {
+	var min, max *big.Int
+	if typ.T == abi.UintTy {
+		min = new(big.Int)
+		max = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(typ.Size)), big.NewInt(1))
+	} else {
+		max = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(typ.Size-1)), big.NewInt(1))
+		min = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), uint(typ.Size-1)))
+	}
+	if n.Cmp(min) < 0 || n.Cmp(max) > 0 {
+		return reflect.Value{}, fmt.Errorf("integer %s overflows %s", n, typ.String())
+	}
+
+	goType := typ.GetType()
+	if goType == bigIntType {
+		return reflect.ValueOf(n), nil
+	}
+
+	out := reflect.New(goType).Elem()
+	if typ.T == abi.UintTy {
+		out.SetUint(n.Uint64())
+	} else {
+		out.SetInt(n.Int64())
+	}
+	return out, nil
+}
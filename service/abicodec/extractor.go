@@ -0,0 +1,37 @@
+package abicodec
+
+import (
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+var _ sdktypes.ResultMsgExtractor = &ResultExtractor{}
+
+// ResultExtractor ABI encodes result messages so that contracts can decode
+// the DVS response data with abi.decode. Arguments are bound to message
+// fields the same way as the inputs of a Route.
+type ResultExtractor struct {
+	args abi.Arguments
+}
+
+// NewResultExtractor creates a ResultExtractor encoding results as args.
+func NewResultExtractor(args abi.Arguments) *ResultExtractor {
+	return &ResultExtractor{args: args}
+}
+
+// GetData returns the ABI encoding of the fields of msg.
+func (e *ResultExtractor) GetData(msg proto.Message) ([]byte, error) {
+	return PackMessage(e.args, msg)
+}
+
+// GetDigest returns the keccak256 hash of the ABI encoded data.
+func (e *ResultExtractor) GetDigest(msg proto.Message) ([]byte, error) {
+	data, err := e.GetData(msg)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(data), nil
+}
@@ -0,0 +1,175 @@
+package abicodec
+
+import (
+	"fmt"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	abiv1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/abi/v1"
+)
+
+// FieldType returns the Solidity type of fd: the pellapp.abi.v1.abi_type
+// annotation when set, otherwise the type derived from the protobuf kind.
+// Message fields are typed by their StructName and repeated fields get a []
+// suffix.
+func FieldType(fd protoreflect.FieldDescriptor) (string, error) {
+	if fd.IsMap() {
+		return "", fmt.Errorf("%s: map fields have no Solidity type", fd.FullName())
+	}
+
+	typ, err := elemType(fd)
+	if err != nil {
+		return "", err
+	}
+	if fd.IsList() {
+		typ += "[]"
+	}
+	return typ, nil
+}
+
+func elemType(fd protoreflect.FieldDescriptor) (string, error) {
+	if typ := fieldAnnotation(fd); typ != "" {
+		return typ, nil
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return "bool", nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind, protoreflect.EnumKind:
+		return "int32", nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64", nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32", nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64", nil
+	case protoreflect.StringKind:
+		return "string", nil
+	case protoreflect.BytesKind:
+		return "bytes", nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return StructName(fd.Message()), nil
+	default:
+		return "", fmt.Errorf("%s: %s fields have no Solidity type", fd.FullName(), fd.Kind())
+	}
+}
+
+// StructName returns the Solidity struct name of md: the
+// pellapp.abi.v1.struct_name annotation when set, otherwise the message name.
+func StructName(md protoreflect.MessageDescriptor) string {
+	opts, ok := md.Options().(*descriptorpb.MessageOptions)
+	if !ok || opts == nil {
+		return string(md.Name())
+	}
+	if name := stringExtension(opts, abiv1.E_StructName); name != "" {
+		return name
+	}
+	return string(md.Name())
+}
+
+func fieldAnnotation(fd protoreflect.FieldDescriptor) string {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return ""
+	}
+	return stringExtension(opts, abiv1.E_AbiType)
+}
+
+// stringExtension reads a string option. Descriptors rebuilt from gogoproto
+// registrations may keep options as unknown fields, those are parsed again
+// with the global registry.
+func stringExtension(opts protov2.Message, xt protoreflect.ExtensionType) string {
+	if protov2.HasExtension(opts, xt) {
+		return protov2.GetExtension(opts, xt).(string)
+	}
+	if len(opts.ProtoReflect().GetUnknown()) == 0 {
+		return ""
+	}
+
+	bz, err := protov2.Marshal(opts)
+	if err != nil {
+		return ""
+	}
+	parsed := opts.ProtoReflect().New().Interface()
+	if err := (protov2.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}).Unmarshal(bz, parsed); err != nil {
+		return ""
+	}
+	if protov2.HasExtension(parsed, xt) {
+		return protov2.GetExtension(parsed, xt).(string)
+	}
+	return ""
+}
+
+// MessageArguments returns the ABI arguments of the fields of md in
+// declaration order, message fields become tuples. Together with PackMessage
+// it gives the abi.encode of a message without a contract ABI.
+func MessageArguments(md protoreflect.MessageDescriptor) (abi.Arguments, error) {
+	components, err := messageComponents(md, map[protoreflect.FullName]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(abi.Arguments, len(components))
+	for i, c := range components {
+		typ, err := abi.NewType(c.Type, "", c.Components)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", md.FullName(), c.Name, err)
+		}
+		args[i] = abi.Argument{Name: c.Name, Type: typ}
+	}
+	return args, nil
+}
+
+func messageComponents(md protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]bool) ([]abi.ArgumentMarshaling, error) {
+	if visiting[md.FullName()] {
+		return nil, fmt.Errorf("%s is recursive and has no Solidity type", md.FullName())
+	}
+	visiting[md.FullName()] = true
+	defer delete(visiting, md.FullName())
+
+	fields := md.Fields()
+	components := make([]abi.ArgumentMarshaling, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		c := abi.ArgumentMarshaling{Name: string(fd.Name())}
+
+		if fd.Kind() == protoreflect.MessageKind && !fd.IsMap() && fieldAnnotation(fd) == "" {
+			sub, err := messageComponents(fd.Message(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			c.Type = "tuple"
+			c.Components = sub
+			if fd.IsList() {
+				c.Type += "[]"
+			}
+		} else {
+			typ, err := FieldType(fd)
+			if err != nil {
+				return nil, err
+			}
+			c.Type = typ
+		}
+		components[i] = c
+	}
+	return components, nil
+}
+
+// PackFields returns the abi.encode of the fields of msg, typed as described
+// by MessageArguments.
+func PackFields(msg proto.Message) ([]byte, error) {
+	m, err := ReflectMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	args, err := MessageArguments(m.Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	return PackMessage(args, msg)
+}
@@ -42,3 +42,20 @@ func (p *Configurator) InvokeByMsgData(ctx sdktypes.Context, data []byte) (*sdkt
 func (p *Configurator) RegisterResultMsgExtractor(msg proto.Message, handler sdktypes.ResultMsgExtractor) {
 	p.ResultManager.RegisterCustomizedFunc(msg, handler)
 }
+
+// OptOutResultMsgExtractor declares that results of a specific message type carry no custom data
+func (p *Configurator) OptOutResultMsgExtractor(msg proto.Message) {
+	p.ResultManager.OptOut(msg)
+}
+
+// MissingResultExtractors returns the response types of the request handlers of a service
+// that have no result extractor and did not opt out
+func (p *Configurator) MissingResultExtractors(service string) []string {
+	var missing []string
+	for _, typeURL := range p.Router.ResponseTypes(service) {
+		if !p.ResultManager.HasHandler(typeURL) {
+			missing = append(missing, typeURL)
+		}
+	}
+	return missing
+}
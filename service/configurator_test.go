@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
 
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
 	"github.com/0xPellNetwork/pellapp-sdk/service/result"
 	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
 )
@@ -197,3 +198,82 @@ func TestConfigurator_RegisterResultMsgExtractor(t *testing.T) {
 	// This is an indirect test since we can't directly access the result manager's internal state
 	// The test passing without panicking indicates successful registration
 }
+
+// testServiceDesc describes test.service.TestService, whose descriptor is registered by the testpb package
+var testServiceDesc = &grpc.ServiceDesc{
+	ServiceName: "test.service.TestService",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "SubmitTask", Handler: testMethodHandler(func() types.Msg { return &testpb.TestCallMsg{} })},
+		{MethodName: "Echo", Handler: testMethodHandler(func() types.Msg { return &testpb.TestMsg{} })},
+		{MethodName: "SubmitTaskDVSResponsHandler", Handler: testMethodHandler(func() types.Msg { return &testpb.TestCallMsg{} })},
+	},
+}
+
+func testMethodHandler(newMsg func() types.Msg) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := newMsg()
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv}, func(context.Context, any) (any, error) {
+			return in, nil
+		})
+	}
+}
+
+// MockModuleForConfigurator implements sdktypes.BasicModule for testing
+type MockModuleForConfigurator struct {
+	sdktypes.BasicModule
+	name         string
+	configurator sdktypes.Configurator
+}
+
+func (m *MockModuleForConfigurator) Name() string { return m.name }
+
+func (m *MockModuleForConfigurator) RegisterServices(c sdktypes.Configurator) {
+	m.configurator = c
+	c.RegisterService(testServiceDesc, nil)
+}
+
+func TestConfigurator_MissingResultExtractors(t *testing.T) {
+	resultManager := result.NewCustomResultManager()
+	c := NewConfigurator(new(MockMsgEncoderForConfigurator), resultManager).(*Configurator)
+	c.RegisterService(testServiceDesc, nil)
+
+	// response handlers are not signed and not reported
+	assert.Equal(t, []string{"/test.service.TestCallResult", "/test.service.TestMsg"}, c.Router.ResponseTypes(testServiceDesc.ServiceName))
+	assert.Equal(t, []string{"/test.service.TestCallResult", "/test.service.TestMsg"}, c.MissingResultExtractors(testServiceDesc.ServiceName))
+
+	c.RegisterResultMsgExtractor(&testpb.TestCallResult{}, new(MockResultMsgExtractorForConfigurator))
+	c.OptOutResultMsgExtractor(&testpb.TestMsg{})
+	assert.Empty(t, c.MissingResultExtractors(testServiceDesc.ServiceName))
+
+	// a default extractor covers every type that did not opt out
+	resultManager = result.NewCustomResultManager()
+	resultManager.SetDefaultHandler(new(MockResultMsgExtractorForConfigurator))
+	c = NewConfigurator(new(MockMsgEncoderForConfigurator), resultManager).(*Configurator)
+	c.RegisterService(testServiceDesc, nil)
+	assert.Empty(t, c.MissingResultExtractors(testServiceDesc.ServiceName))
+}
+
+func TestModuleManager_ValidateResultExtractors(t *testing.T) {
+	c := NewConfigurator(new(MockMsgEncoderForConfigurator), result.NewCustomResultManager()).(*Configurator)
+	module := &MockModuleForConfigurator{name: "test"}
+	mm := sdktypes.NewManager(module)
+	mm.RegisterServices(c)
+
+	// the module is given a wrapper of the configurator
+	_, ok := module.configurator.(*Configurator)
+	assert.False(t, ok)
+	assert.Same(t, c, sdktypes.UnwrapConfigurator(module.configurator))
+	assert.Same(t, c, sdktypes.UnwrapConfigurator(c))
+
+	err := mm.ValidateResultExtractors(c)
+	assert.ErrorContains(t, err, "module test: no result extractor for /test.service.TestCallResult returned by test.service.TestService")
+	assert.ErrorContains(t, err, "module test: no result extractor for /test.service.TestMsg")
+
+	c.RegisterResultMsgExtractor(&testpb.TestCallResult{}, new(MockResultMsgExtractorForConfigurator))
+	c.OptOutResultMsgExtractor(&testpb.TestMsg{})
+	assert.NoError(t, mm.ValidateResultExtractors(c))
+}
@@ -0,0 +1,209 @@
+package extractor
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/0xPellNetwork/pellapp-sdk/service/abicodec"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+var _ sdktypes.ResultMsgExtractor = &EIP712{}
+
+// EIP712 hashes results as EIP-712 typed data. The struct type of a result is
+// derived from its descriptor: the type name is abicodec.StructName and
+// member types are abicodec.FieldType, both overridable with the
+// pellapp.abi.v1 annotations.
+type EIP712 struct {
+	domain apitypes.TypedDataDomain
+}
+
+// NewEIP712 creates an EIP712 extractor signing under domain.
+func NewEIP712(domain apitypes.TypedDataDomain) *EIP712 {
+	return &EIP712{domain: domain}
+}
+
+// GetData returns the EIP-712 encodeData of msg, the preimage of its hashStruct.
+func (e *EIP712) GetData(msg proto.Message) ([]byte, error) {
+	typedData, err := e.TypedData(msg)
+	if err != nil {
+		return nil, err
+	}
+	return typedData.EncodeData(typedData.PrimaryType, typedData.Message, 1)
+}
+
+// GetDigest returns keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(msg)).
+func (e *EIP712) GetDigest(msg proto.Message) ([]byte, error) {
+	typedData, err := e.TypedData(msg)
+	if err != nil {
+		return nil, err
+	}
+	digest, _, err := apitypes.TypedDataAndHash(*typedData)
+	return digest, err
+}
+
+// TypedData returns the EIP-712 typed data of msg under the extractor domain.
+func (e *EIP712) TypedData(msg proto.Message) (*apitypes.TypedData, error) {
+	m, err := abicodec.ReflectMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	types := apitypes.Types{"EIP712Domain": domainType(e.domain)}
+	primaryType, err := addStructType(types, m.Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	message, err := typedMessage(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitypes.TypedData{
+		Types:       types,
+		PrimaryType: primaryType,
+		Domain:      e.domain,
+		Message:     message,
+	}, nil
+}
+
+// domainType lists the EIP712Domain members present in domain, in the order
+// defined by EIP-712.
+func domainType(domain apitypes.TypedDataDomain) []apitypes.Type {
+	var members []apitypes.Type
+	if domain.Name != "" {
+		members = append(members, apitypes.Type{Name: "name", Type: "string"})
+	}
+	if domain.Version != "" {
+		members = append(members, apitypes.Type{Name: "version", Type: "string"})
+	}
+	if domain.ChainId != nil {
+		members = append(members, apitypes.Type{Name: "chainId", Type: "uint256"})
+	}
+	if domain.VerifyingContract != "" {
+		members = append(members, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	if domain.Salt != "" {
+		members = append(members, apitypes.Type{Name: "salt", Type: "bytes32"})
+	}
+	return members
+}
+
+// addStructType adds the struct type of md and of its message fields to types
+// and returns its name.
+func addStructType(types apitypes.Types, md protoreflect.MessageDescriptor) (string, error) {
+	name := abicodec.StructName(md)
+	if _, ok := types[name]; ok {
+		return name, nil
+	}
+
+	fields := md.Fields()
+	members := make([]apitypes.Type, fields.Len())
+	// registered before visiting fields so that recursive messages terminate
+	types[name] = members
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		typ, err := abicodec.FieldType(fd)
+		if err != nil {
+			return "", err
+		}
+		if fd.Kind() == protoreflect.MessageKind {
+			if _, err := addStructType(types, fd.Message()); err != nil {
+				return "", err
+			}
+		}
+		members[i] = apitypes.Type{Name: string(fd.JSONName()), Type: typ}
+	}
+	return name, nil
+}
+
+// typedMessage returns the fields of m keyed by member name, in the value
+// types accepted by apitypes.
+func typedMessage(m protoreflect.Message) (apitypes.TypedDataMessage, error) {
+	fields := m.Descriptor().Fields()
+	message := make(apitypes.TypedDataMessage, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		v := m.Get(fd)
+		typ, err := abicodec.FieldType(fd)
+		if err != nil {
+			return nil, err
+		}
+		typ = strings.TrimSuffix(typ, "[]")
+
+		if !fd.IsList() {
+			value, err := typedValue(fd, typ, v)
+			if err != nil {
+				return nil, err
+			}
+			message[fd.JSONName()] = value
+			continue
+		}
+
+		list := v.List()
+		values := make([]any, list.Len())
+		for j := 0; j < list.Len(); j++ {
+			value, err := typedValue(fd, typ, list.Get(j))
+			if err != nil {
+				return nil, err
+			}
+			values[j] = value
+		}
+		message[fd.JSONName()] = values
+	}
+	return message, nil
+}
+
+// typedValue converts v to a value of the Solidity type typ. Empty strings and
+// bytes stand for the zero value of annotated types, as in abicodec.
+func typedValue(fd protoreflect.FieldDescriptor, typ string, v protoreflect.Value) (any, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool(), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return big.NewInt(v.Int()), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	case protoreflect.EnumKind:
+		return big.NewInt(int64(v.Enum())), nil
+	case protoreflect.StringKind:
+		if v.String() == "" {
+			return zeroValue(typ, v.String()), nil
+		}
+		return v.String(), nil
+	case protoreflect.BytesKind:
+		if len(v.Bytes()) == 0 {
+			return zeroValue(typ, v.Bytes()), nil
+		}
+		return v.Bytes(), nil
+	case protoreflect.MessageKind:
+		return typedMessage(v.Message())
+	default:
+		return nil, fmt.Errorf("%s: %s fields have no EIP-712 type", fd.FullName(), fd.Kind())
+	}
+}
+
+func zeroValue(typ string, empty any) any {
+	switch {
+	case typ == "address":
+		return make([]byte, common.AddressLength)
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"):
+		return new(big.Int)
+	case strings.HasPrefix(typ, "bytes") && typ != "bytes":
+		size, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil {
+			return empty
+		}
+		return make([]byte, size)
+	default:
+		return empty
+	}
+}
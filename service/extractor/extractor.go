@@ -0,0 +1,75 @@
+// Package extractor provides stock sdktypes.ResultMsgExtractor
+// implementations, so modules do not have to hand-write GetData and GetDigest
+// for the usual ways contracts verify DVS results.
+package extractor
+
+import (
+	"crypto/sha256"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum/crypto"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/0xPellNetwork/pellapp-sdk/service/abicodec"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+var (
+	_ sdktypes.ResultMsgExtractor = Keccak256{}
+	_ sdktypes.ResultMsgExtractor = Sha256{}
+)
+
+// Keccak256 encodes results as abi.encode of their fields in declaration
+// order and digests them with keccak256, matching
+// keccak256(abi.encode(...)) on chain. Field types follow
+// abicodec.MessageArguments and can be overridden with the
+// pellapp.abi.v1.abi_type annotation.
+type Keccak256 struct{}
+
+// NewKeccak256 creates a Keccak256 extractor.
+func NewKeccak256() Keccak256 {
+	return Keccak256{}
+}
+
+// GetData returns the ABI encoding of the fields of msg.
+func (Keccak256) GetData(msg proto.Message) ([]byte, error) {
+	return abicodec.PackFields(msg)
+}
+
+// GetDigest returns the keccak256 hash of the ABI encoded fields of msg.
+func (e Keccak256) GetDigest(msg proto.Message) ([]byte, error) {
+	data, err := e.GetData(msg)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(data), nil
+}
+
+// Sha256 encodes results as their deterministic protobuf bytes and digests
+// them with sha256.
+type Sha256 struct{}
+
+// NewSha256 creates a Sha256 extractor.
+func NewSha256() Sha256 {
+	return Sha256{}
+}
+
+// GetData returns the deterministic protobuf encoding of msg, fields are
+// written in field number order and map entries sorted by key.
+func (Sha256) GetData(msg proto.Message) ([]byte, error) {
+	m, err := abicodec.ReflectMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	return protov2.MarshalOptions{Deterministic: true}.Marshal(m.Interface())
+}
+
+// GetDigest returns the sha256 hash of the deterministic protobuf encoding of msg.
+func (e Sha256) GetDigest(msg proto.Message) ([]byte, error) {
+	data, err := e.GetData(msg)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(data)
+	return digest[:], nil
+}
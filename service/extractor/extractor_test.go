@@ -0,0 +1,200 @@
+package extractor
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protov2 "google.golang.org/protobuf/proto"
+
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
+)
+
+func testCallMsg() *testpb.TestCallMsg {
+	return &testpb.TestCallMsg{
+		TaskId:       42,
+		Operator:     "0x00000000000000000000000000000000000000AA",
+		Payload:      []byte("payload"),
+		Urgent:       true,
+		Amount:       "1000000000000000000000",
+		GroupNumbers: []uint32{0, 1, 3},
+		Digest:       crypto.Keccak256([]byte("digest")),
+		Info:         &testpb.TestCallInfo{Name: "task", Weight: -7},
+	}
+}
+
+func mustNewType(t *testing.T, typ string) abi.Type {
+	abiType, err := abi.NewType(typ, "", nil)
+	require.NoError(t, err)
+	return abiType
+}
+
+func TestKeccak256(t *testing.T) {
+	res := &testpb.TestCallResult{
+		TaskId:   42,
+		Digest:   crypto.Keccak256([]byte("digest")),
+		Accepted: true,
+	}
+
+	args := abi.Arguments{
+		{Type: mustNewType(t, "uint64")},
+		{Type: mustNewType(t, "bytes32")},
+		{Type: mustNewType(t, "bool")},
+	}
+	expected, err := args.Pack(uint64(42), [32]byte(res.Digest), true)
+	require.NoError(t, err)
+
+	extractor := NewKeccak256()
+	data, err := extractor.GetData(res)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+
+	digest, err := extractor.GetDigest(res)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256(expected), digest)
+
+	_, err = extractor.GetDigest(&testpb.TestCallResult{Digest: []byte("short")})
+	assert.ErrorContains(t, err, "expected 32 bytes")
+}
+
+func TestKeccak256Nested(t *testing.T) {
+	msg := testCallMsg()
+	data, err := NewKeccak256().GetData(msg)
+	require.NoError(t, err)
+
+	info, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "name", Type: "string"},
+		{Name: "weight", Type: "int64"},
+	})
+	require.NoError(t, err)
+	args := abi.Arguments{
+		{Type: mustNewType(t, "uint64")},
+		{Type: mustNewType(t, "address")},
+		{Type: mustNewType(t, "bytes")},
+		{Type: mustNewType(t, "bool")},
+		{Type: mustNewType(t, "uint256")},
+		{Type: mustNewType(t, "uint32[]")},
+		{Type: mustNewType(t, "bytes32")},
+		{Type: info},
+	}
+	amount, _ := new(big.Int).SetString(msg.Amount, 10)
+	expected, err := args.Pack(
+		msg.TaskId,
+		common.HexToAddress(msg.Operator),
+		msg.Payload,
+		msg.Urgent,
+		amount,
+		msg.GroupNumbers,
+		[32]byte(msg.Digest),
+		struct {
+			Name   string
+			Weight int64
+		}{Name: msg.Info.Name, Weight: msg.Info.Weight},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+}
+
+func TestSha256(t *testing.T) {
+	extractor := NewSha256()
+
+	res := &testpb.TestCallResult{TaskId: 42, Accepted: true}
+	expected, err := protov2.MarshalOptions{Deterministic: true}.Marshal(res)
+	require.NoError(t, err)
+
+	data, err := extractor.GetData(res)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+
+	digest, err := extractor.GetDigest(res)
+	require.NoError(t, err)
+	sum := sha256.Sum256(expected)
+	assert.Equal(t, sum[:], digest)
+
+	// gogoproto messages are encoded the same way
+	raw := &txtypes.TxRaw{BodyBytes: []byte("body"), Signatures: [][]byte{[]byte("sig")}}
+	expected, err = proto.Marshal(raw)
+	require.NoError(t, err)
+	data, err = extractor.GetData(raw)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+}
+
+func TestEIP712(t *testing.T) {
+	domain := apitypes.TypedDataDomain{
+		Name:              "PellDVS",
+		Version:           "1",
+		ChainId:           math.NewHexOrDecimal256(1337),
+		VerifyingContract: "0x00000000000000000000000000000000000000BB",
+	}
+	msg := testCallMsg()
+
+	expected := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"TestCallMsg": {
+				{Name: "taskId", Type: "uint64"},
+				{Name: "operator", Type: "address"},
+				{Name: "payload", Type: "bytes"},
+				{Name: "urgent", Type: "bool"},
+				{Name: "amount", Type: "uint256"},
+				{Name: "groupNumbers", Type: "uint32[]"},
+				{Name: "digest", Type: "bytes32"},
+				{Name: "info", Type: "TaskInfo"},
+			},
+			"TaskInfo": {
+				{Name: "name", Type: "string"},
+				{Name: "weight", Type: "int64"},
+			},
+		},
+		PrimaryType: "TestCallMsg",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"taskId":       "42",
+			"operator":     msg.Operator,
+			"payload":      "0x7061796c6f6164",
+			"urgent":       true,
+			"amount":       msg.Amount,
+			"groupNumbers": []any{"0", "1", "3"},
+			"digest":       msg.Digest,
+			"info": map[string]any{
+				"name":   "task",
+				"weight": "-7",
+			},
+		},
+	}
+	expectedDigest, _, err := apitypes.TypedDataAndHash(expected)
+	require.NoError(t, err)
+	expectedData, err := expected.EncodeData("TestCallMsg", expected.Message, 1)
+	require.NoError(t, err)
+
+	extractor := NewEIP712(domain)
+	data, err := extractor.GetData(msg)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(expectedData), data)
+
+	digest, err := extractor.GetDigest(msg)
+	require.NoError(t, err)
+	assert.Equal(t, expectedDigest, digest)
+
+	// zero values of annotated fields are encoded as Solidity zero values
+	_, err = extractor.GetDigest(&testpb.TestCallMsg{})
+	require.NoError(t, err)
+
+	_, err = extractor.GetDigest(&testpb.TestCallMsg{Operator: "not an address"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.opentelemetry.io/otel/attribute"
+
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+	"github.com/0xPellNetwork/pellapp-sdk/telemetry"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+var _ sdktypes.MsgDispatcher = (*MsgDispatcher)(nil)
+
+// MsgDispatcher routes the messages dispatched from within message handlers
+// to the request handlers registered on a MsgRouterMgr, on behalf of the
+// authority it is bound to, if any. Dispatched messages do not run through
+// the ante handler.
+type MsgDispatcher struct {
+	router    *MsgRouterMgr
+	authority string
+}
+
+// NewMsgDispatcher creates a dispatcher routing messages to the handlers of
+// router, bound to no authority, see ForAuthority.
+func NewMsgDispatcher(router *MsgRouterMgr) *MsgDispatcher {
+	return &MsgDispatcher{router: router}
+}
+
+// ForAuthority returns a dispatcher routing messages to the same handlers on
+// behalf of authority, e.g. the name of the module it is injected in.
+func (d *MsgDispatcher) ForAuthority(authority string) *MsgDispatcher {
+	return &MsgDispatcher{router: d.router, authority: authority}
+}
+
+// Authority implements types.MsgDispatcher.
+func (d *MsgDispatcher) Authority() string {
+	return d.authority
+}
+
+// Dispatch implements types.MsgDispatcher. Messages implementing
+// types.HasAuthority are only dispatched on behalf of their authority, and
+// messages nested deeper than types.MaxDispatchDepth are refused.
+func (d *MsgDispatcher) Dispatch(ctx sdktypes.Context, msg sdk.Msg) (_ *sdktypes.AvsiResult, err error) {
+	key := d.router.calcMsgKey(msg)
+
+	spanCtx, span := telemetry.StartSpan(ctx.Context(), "msg.dispatch", attribute.String(telemetry.MetricLabelMsgType, key))
+	defer func() { telemetry.EndSpan(span, err) }()
+	ctx = ctx.WithContext(spanCtx)
+
+	if ctx.DispatchDepth() >= sdktypes.MaxDispatchDepth {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrMaxDispatchDepth, "%s dispatched at depth %d", key, ctx.DispatchDepth())
+	}
+	if m, ok := msg.(sdktypes.HasAuthority); ok && (d.authority == "" || m.GetAuthority() != d.authority) {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "%s expects authority %s, dispatched on behalf of %q", key, m.GetAuthority(), d.authority)
+	}
+
+	// dispatched messages are requests, even from within response handlers
+	handler, found := d.router.Router[key]
+	if !found {
+		return nil, fmt.Errorf("no handler found for %s", key)
+	}
+
+	cacheCtx, writeCache := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithDispatchDepth(ctx.DispatchDepth() + 1).WithDispatchAuthority(d.authority)
+	res, err := handler(cacheCtx, msg)
+	if err != nil {
+		return nil, err
+	}
+	writeCache()
+
+	return res, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+	"github.com/0xPellNetwork/pellapp-sdk/proto/test"
+	"github.com/0xPellNetwork/pellapp-sdk/service/result"
+	"github.com/0xPellNetwork/pellapp-sdk/testutil"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+// rewardMsg may only be dispatched on behalf of the rewards authority
+type rewardMsg struct {
+	Name string
+}
+
+func (m *rewardMsg) Reset() { *m = rewardMsg{} }
+
+func (m *rewardMsg) String() string { return m.Name }
+
+func (*rewardMsg) ProtoMessage() {}
+
+func (*rewardMsg) XXX_MessageName() string { return "test.service.RewardMsg" }
+
+func (*rewardMsg) GetAuthority() string { return "rewards" }
+
+// dispatchService writes the type URL of the messages it handles to its
+// store, records the authority they were dispatched on behalf of and emits an
+// event, then behaves as the type URL says: "fail" fails after writing,
+// "loop" dispatches itself, "reward" dispatches a rewardMsg with the
+// dispatcher of its module
+type dispatchService struct {
+	key         storetypes.StoreKey
+	dispatcher  sdktypes.MsgDispatcher
+	authorities []string
+}
+
+func (s *dispatchService) Handle(ctx context.Context, msg *test.TestMsg) (*test.TestMsg, error) {
+	sdkCtx := sdktypes.UnwrapContext(ctx)
+	sdkCtx.KVStore(s.key).Set([]byte(msg.TypeUrl), []byte{1})
+	sdkCtx.EventManager().EmitEvent(sdktypes.NewEvent("handled", sdktypes.NewAttribute("msg", msg.TypeUrl)))
+	s.authorities = append(s.authorities, sdkCtx.DispatchAuthority())
+
+	var err error
+	switch msg.TypeUrl {
+	case "fail":
+		err = errors.New("handler failed")
+	case "loop":
+		_, err = sdkCtx.MsgDispatcher().Dispatch(sdkCtx, &test.TestMsg{TypeUrl: "loop"})
+	case "reward":
+		_, err = s.dispatcher.Dispatch(sdkCtx, &rewardMsg{Name: "rewarded"})
+	}
+	return msg, err
+}
+
+var dispatchServiceDesc = &grpc.ServiceDesc{
+	ServiceName: "test.service.DispatchService",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Handle",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				in := new(test.TestMsg)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv}, func(ctx context.Context, req any) (any, error) {
+					return srv.(*dispatchService).Handle(ctx, req.(*test.TestMsg))
+				})
+			},
+		},
+		{
+			MethodName: "Reward",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				in := new(rewardMsg)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv}, func(ctx context.Context, req any) (any, error) {
+					return srv.(*dispatchService).Handle(ctx, &test.TestMsg{TypeUrl: req.(*rewardMsg).Name})
+				})
+			},
+		},
+	},
+}
+
+// setupDispatcher returns the dispatcher bound to no authority, set on the
+// returned context, with the dispatch service of module
+func setupDispatcher(t *testing.T, module string) (*MsgDispatcher, sdktypes.Context, storetypes.StoreKey, *dispatchService) {
+	t.Helper()
+	key := storetypes.NewKVStoreKey("dispatch")
+	router := NewMsgRouterMgr(&MockMsgEncoderForMsgMgr{}, result.NewCustomResultManager())
+	dispatcher := NewMsgDispatcher(router)
+	svc := &dispatchService{key: key, dispatcher: dispatcher.ForAuthority(module)}
+	RegisterServiceRouter(router, dispatchServiceDesc, svc)
+
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_dispatch")).WithMsgDispatcher(dispatcher)
+	return dispatcher, ctx, key, svc
+}
+
+func TestMsgDispatcherDispatch(t *testing.T) {
+	dispatcher, ctx, key, svc := setupDispatcher(t, "rewards")
+
+	res, err := dispatcher.Dispatch(ctx, &test.TestMsg{TypeUrl: "reward"})
+	require.NoError(t, err)
+	require.Len(t, res.MsgResponses, 1)
+	assert.Equal(t, []string{"", "rewards"}, svc.authorities)
+
+	// the writes and events of the nested dispatch are merged into ctx
+	assert.True(t, ctx.KVStore(key).Has([]byte("reward")))
+	assert.True(t, ctx.KVStore(key).Has([]byte("rewarded")))
+	attrs, ok := ctx.EventManager().Events().GetAttributes("msg")
+	require.True(t, ok)
+	require.Len(t, attrs, 2)
+	assert.Equal(t, "reward", attrs[0].Value)
+	assert.Equal(t, "rewarded", attrs[1].Value)
+}
+
+func TestMsgDispatcherFailure(t *testing.T) {
+	dispatcher, ctx, key, _ := setupDispatcher(t, "rewards")
+
+	_, err := dispatcher.Dispatch(ctx, &test.TestMsg{TypeUrl: "fail"})
+	require.ErrorContains(t, err, "handler failed")
+
+	// the writes and events of the failed handler are discarded
+	assert.False(t, ctx.KVStore(key).Has([]byte("fail")))
+	assert.Empty(t, ctx.EventManager().Events())
+}
+
+func TestMsgDispatcherAuthority(t *testing.T) {
+	dispatcher, ctx, key, _ := setupDispatcher(t, "rewards")
+
+	// the dispatcher bound to no authority refuses guarded messages
+	_, err := dispatcher.Dispatch(ctx, &rewardMsg{Name: "rewarded"})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	_, err = dispatcher.ForAuthority("tasks").Dispatch(ctx, &rewardMsg{Name: "rewarded"})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	assert.False(t, ctx.KVStore(key).Has([]byte("rewarded")))
+
+	_, err = dispatcher.ForAuthority("rewards").Dispatch(ctx, &rewardMsg{Name: "rewarded"})
+	require.NoError(t, err)
+	assert.True(t, ctx.KVStore(key).Has([]byte("rewarded")))
+}
+
+func TestMsgDispatcherOtherModuleAuthority(t *testing.T) {
+	dispatcher, ctx, key, _ := setupDispatcher(t, "tasks")
+
+	// the tasks module cannot dispatch the message guarded by the rewards module
+	_, err := dispatcher.Dispatch(ctx, &test.TestMsg{TypeUrl: "reward"})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	assert.False(t, ctx.KVStore(key).Has([]byte("reward")))
+	assert.False(t, ctx.KVStore(key).Has([]byte("rewarded")))
+}
+
+func TestMsgDispatcherMaxDepth(t *testing.T) {
+	dispatcher, ctx, key, _ := setupDispatcher(t, "rewards")
+
+	_, err := dispatcher.Dispatch(ctx, &test.TestMsg{TypeUrl: "loop"})
+	require.ErrorIs(t, err, sdkerrors.ErrMaxDispatchDepth)
+	assert.False(t, ctx.KVStore(key).Has([]byte("loop")))
+	assert.Equal(t, 0, ctx.DispatchDepth())
+}
+
+func TestMsgDispatcherUnknownMsg(t *testing.T) {
+	dispatcher, ctx, _, _ := setupDispatcher(t, "rewards")
+
+	_, err := dispatcher.Dispatch(ctx, &test.TestCallMsg{})
+	require.ErrorContains(t, err, "no handler found for /test.service.TestCallMsg")
+}
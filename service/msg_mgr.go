@@ -3,15 +3,20 @@ package service
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/gogoproto/proto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/go-metrics"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/0xPellNetwork/pellapp-sdk/service/result"
 	"github.com/0xPellNetwork/pellapp-sdk/service/tx"
+	"github.com/0xPellNetwork/pellapp-sdk/telemetry"
 	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
 )
 
@@ -33,6 +38,8 @@ type MsgRouterMgr struct {
 	encoder       tx.MsgEncoder
 	calcMsgKey    func(msg sdk.Msg) string // ONLY FOR router dispatcher; register use sdk.MsgTypeURL
 	resultHandler *result.CustomResultManager
+	responseTypes map[string][]string // response type URLs of request handlers, by service name
+	methods       map[string]string   // full service method names of handlers, by router key
 }
 
 // NewMsgRouterMgr creates a new message router manager with the provided encoder and result handler
@@ -45,9 +52,16 @@ func NewMsgRouterMgr(
 		encoder:       encoder,
 		calcMsgKey:    defaultMsgKeyFunc,
 		resultHandler: resultHandler,
+		responseTypes: map[string][]string{},
+		methods:       map[string]string{},
 	}
 }
 
+// SetEncoder replaces the encoder used to decode message data
+func (m *MsgRouterMgr) SetEncoder(encoder tx.MsgEncoder) {
+	m.encoder = encoder
+}
+
 // RegisterMsgHandler registers a gRPC service method as a message handler
 // Inspired by github.com/cosmos/cosmos-sdk@v0.50.9/baseapp/msg_service_router.go:120 MsgServiceRouter.registerMsgServiceHandler
 func (m *MsgRouterMgr) RegisterMsgHandler(sd *grpc.ServiceDesc, method grpc.MethodDesc, handler any) error {
@@ -78,7 +92,17 @@ func (m *MsgRouterMgr) RegisterMsgHandler(sd *grpc.ServiceDesc, method grpc.Meth
 
 	// requestTypeName register check
 	if _, ok := m.Router[requestTypeName]; !ok {
-		m.Router[requestTypeName] = func(ctx sdktypes.Context, msg sdk.Msg) (*sdktypes.AvsiResult, error) {
+		msgTypeLabels := []metrics.Label{telemetry.NewLabel(telemetry.MetricLabelMsgType, requestTypeName)}
+		m.Router[requestTypeName] = func(ctx sdktypes.Context, msg sdk.Msg) (_ *sdktypes.AvsiResult, err error) {
+			defer telemetry.MeasureSinceWithLabels([]string{telemetry.MetricKeyMsg, telemetry.MetricKeyHandler}, telemetry.Now(), msgTypeLabels)
+
+			spanCtx, span := telemetry.StartSpan(ctx.Context(), "msg.handler", attribute.String(telemetry.MetricLabelMsgType, requestTypeName))
+			defer func() { telemetry.EndSpan(span, err) }()
+			ctx = ctx.WithContext(spanCtx)
+			if logger := ctx.Logger(); logger != nil {
+				ctx = ctx.WithLogger(logger.With(sdktypes.LogKeyMsgType, requestTypeName))
+			}
+
 			// ctx = ctx.WithEventManager(sdk.NewEventManager())
 			interceptor := func(goCtx context.Context, _ any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 				goCtx = context.WithValue(goCtx, sdktypes.ContextKey, ctx)
@@ -97,6 +121,16 @@ func (m *MsgRouterMgr) RegisterMsgHandler(sd *grpc.ServiceDesc, method grpc.Meth
 
 			return m.resultHandler.WrapServiceResult(ctx, resMsg, err)
 		}
+
+		m.methods[requestTypeName] = fqMethod
+
+		// responses of request handlers are signed by operators, keep track of
+		// their types to check that their results can be extracted
+		if !strings.Contains(method.MethodName, DVSResponsHandler) {
+			if responseType, ok := responseTypeURL(sd.ServiceName, method.MethodName); ok {
+				m.responseTypes[sd.ServiceName] = append(m.responseTypes[sd.ServiceName], responseType)
+			}
+		}
 	} else {
 		log.Warn("duplicate existing handler for %s", requestTypeName)
 	}
@@ -104,6 +138,38 @@ func (m *MsgRouterMgr) RegisterMsgHandler(sd *grpc.ServiceDesc, method grpc.Meth
 	return nil
 }
 
+// HandlerMethods returns the full service method names of the registered
+// handlers, by router key: the message type URL, suffixed as
+// DVSResponseHandlerKeyPattern for handlers of validated responses.
+func (m *MsgRouterMgr) HandlerMethods() map[string]string {
+	return maps.Clone(m.methods)
+}
+
+// ResponseTypes returns the response type URLs of the request handlers
+// registered for service. Methods whose descriptor is not registered in the
+// protobuf registry are not reported.
+func (m *MsgRouterMgr) ResponseTypes(service string) []string {
+	return m.responseTypes[service]
+}
+
+// responseTypeURL resolves the response type URL of a service method from the
+// protobuf registry
+func responseTypeURL(service, method string) (string, bool) {
+	desc, err := proto.HybridResolver.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return "", false
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return "", false
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return "", false
+	}
+	return "/" + string(md.Output().FullName()), true
+}
+
 // GetHandler returns the handler for a specific message type
 func (m *MsgRouterMgr) GetHandler(ctx sdktypes.Context, msg sdk.Msg) (MsgHandler, bool) {
 	key := m.calcMsgKey(msg)
@@ -139,14 +205,34 @@ func (m *MsgRouterMgr) HandleByData(ctx sdktypes.Context, data []byte) (*sdktype
 		return nil, err
 	}
 
-	for _, msg := range msgTx.GetMsgs() {
+	return m.HandleMsgs(ctx, msgTx.GetMsgs())
+}
+
+// RoutedMsg returns the first of the decoded messages having a handler, the
+// one HandleMsgs processes
+func (m *MsgRouterMgr) RoutedMsg(ctx sdktypes.Context, msgs []sdk.Msg) (sdk.Msg, bool) {
+	for _, msg := range msgs {
+		if _, found := m.GetHandler(ctx, msg); found {
+			return msg, true
+		}
+	}
+	return nil, false
+}
+
+// HandleMsgs processes the first of the decoded messages having a handler
+func (m *MsgRouterMgr) HandleMsgs(ctx sdktypes.Context, msgs []sdk.Msg) (_ *sdktypes.AvsiResult, err error) {
+	spanCtx, span := telemetry.StartSpan(ctx.Context(), "msg.route")
+	defer func() { telemetry.EndSpan(span, err) }()
+	ctx = ctx.WithContext(spanCtx)
+
+	for _, msg := range msgs {
 		handler, found := m.GetHandler(ctx, msg)
 		if found {
 			return handler(ctx, msg)
 		}
 	}
 
-	return nil, fmt.Errorf("no handler found for %s", msgTx.GetMsgs())
+	return nil, fmt.Errorf("no handler found for %s", msgs)
 }
 
 // noopDecoder is a no-operation decoder used during handler registration
@@ -17,29 +17,56 @@ type MsgRouter struct {
 	cdc          codec.Codec
 	encoder      tx.MsgEncoder
 	configurator cosmosrpc.Server
+	dispatcher   *MsgDispatcher
 }
 
 // Init initializes the DvsMsgHandlers with codec and creates default handlers if not set
 func NewMsgRouter(cdc codec.Codec) *MsgRouter {
 	encoder := tx.NewDefaultDecoder(cdc)
 
+	configurator := NewConfigurator(encoder, result.NewCustomResultManager())
+
 	return &MsgRouter{
 		cdc:          cdc,
 		encoder:      encoder,
-		configurator: NewConfigurator(encoder, result.NewCustomResultManager()),
+		configurator: configurator,
+		dispatcher:   NewMsgDispatcher(configurator.(*Configurator).Router),
 	}
 }
 
+// SetEncoder replaces the encoder used to decode DVS request data and encode messages
+func (h *MsgRouter) SetEncoder(encoder tx.MsgEncoder) {
+	h.encoder = encoder
+	h.GetConfigurator().Router.SetEncoder(encoder)
+}
+
 // InvokeByMsgData routes raw byte data to the configurator
 func (h *MsgRouter) InvokeByMsgData(sdkCtx sdktypes.Context, data []byte) (*sdktypes.AvsiResult, error) {
 	return h.configurator.(*Configurator).InvokeByMsgData(sdkCtx, data)
 }
 
+// InvokeByMsgs routes messages already decoded from DVS request data to the
+// configurator
+func (h *MsgRouter) InvokeByMsgs(sdkCtx sdktypes.Context, msgs []sdk.Msg) (*sdktypes.AvsiResult, error) {
+	return h.GetConfigurator().Router.HandleMsgs(sdkCtx, msgs)
+}
+
+// RoutedMsg returns the message, among msgs, InvokeByMsgs routes to a handler
+func (h *MsgRouter) RoutedMsg(sdkCtx sdktypes.Context, msgs []sdk.Msg) (sdk.Msg, bool) {
+	return h.GetConfigurator().Router.RoutedMsg(sdkCtx, msgs)
+}
+
 // GetConfigurator returns the configurator
 func (h *MsgRouter) GetConfigurator() *Configurator {
 	return h.configurator.(*Configurator)
 }
 
+// GetMsgDispatcher returns the dispatcher routing messages dispatched from
+// within handlers to the handlers of the configurator
+func (h *MsgRouter) GetMsgDispatcher() *MsgDispatcher {
+	return h.dispatcher
+}
+
 // EncodeMsgs encodes SDK messages into bytes using the configured encoder
 func (h *MsgRouter) EncodeMsgs(msgs ...sdk.Msg) ([]byte, error) {
 	return h.encoder.EncodeMsgs(msgs...)
@@ -47,6 +74,17 @@ func (h *MsgRouter) EncodeMsgs(msgs ...sdk.Msg) ([]byte, error) {
 
 // DecodeMsg decodes bytes into an SDK message using the configured encoder
 func (h *MsgRouter) DecodeMsg(data []byte) (sdk.Msg, error) {
+	msgs, err := h.DecodeMsgs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return msgs[0], nil
+}
+
+// DecodeMsgs decodes bytes into the SDK messages they carry using the
+// configured encoder, failing if there is none
+func (h *MsgRouter) DecodeMsgs(data []byte) ([]sdk.Msg, error) {
 	tx, err := h.encoder.Decode(data)
 	if err != nil {
 		return nil, err
@@ -55,5 +93,5 @@ func (h *MsgRouter) DecodeMsg(data []byte) (sdk.Msg, error) {
 		return nil, fmt.Errorf("DecodeMsg invalid tx")
 	}
 
-	return tx.GetMsgs()[0], nil
+	return tx.GetMsgs(), nil
 }
@@ -1,18 +1,24 @@
 package result
 
 import (
+	errorsmod "cosmossdk.io/errors"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/gogoproto/proto"
 
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
 	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
 )
 
 // CustomResultManager manages custom result handlers for different message types.
 // It allows registering specialized handlers for processing specific message types
 // and extracting custom data and digests from them.
+// Message types without a registered handler fall back to the default handler,
+// unless they explicitly opted out of custom result data.
 type CustomResultManager struct {
 	customHandlers map[string]sdktypes.ResultMsgExtractor
+	optOuts        map[string]struct{}
+	defaultHandler sdktypes.ResultMsgExtractor
 }
 
 // NewCustomResultManager creates a new instance of CustomResultManager with
@@ -20,9 +26,43 @@ type CustomResultManager struct {
 func NewCustomResultManager() *CustomResultManager {
 	return &CustomResultManager{
 		customHandlers: make(map[string]sdktypes.ResultMsgExtractor),
+		optOuts:        make(map[string]struct{}),
 	}
 }
 
+// SetDefaultHandler sets the handler used for message types that have no
+// registered handler and did not opt out, e.g. extractor.NewSha256().
+func (r *CustomResultManager) SetDefaultHandler(f sdktypes.ResultMsgExtractor) {
+	r.defaultHandler = f
+}
+
+// OptOut declares that results of the message type carry no custom data and
+// digest, the default handler is not applied to them.
+func (r *CustomResultManager) OptOut(t proto.Message) {
+	r.optOuts[sdk.MsgTypeURL(t)] = struct{}{}
+}
+
+// HasHandler reports whether results of the message type are handled: a
+// handler is registered, it opted out, or a default handler is set.
+func (r *CustomResultManager) HasHandler(typeURL string) bool {
+	if _, ok := r.optOuts[typeURL]; ok {
+		return true
+	}
+	_, ok := r.handler(typeURL)
+	return ok
+}
+
+// handler returns the handler of the message type, if any.
+func (r *CustomResultManager) handler(typeURL string) (sdktypes.ResultMsgExtractor, bool) {
+	if f, ok := r.customHandlers[typeURL]; ok {
+		return f, true
+	}
+	if _, ok := r.optOuts[typeURL]; ok {
+		return nil, false
+	}
+	return r.defaultHandler, r.defaultHandler != nil
+}
+
 // RegisterCustomizedFunc registers a custom result handler for a specific message type.
 // The message type is determined by its protobuf URL, and the handler will be called
 // when processing results of this message type.
@@ -33,8 +73,9 @@ func (r *CustomResultManager) RegisterCustomizedFunc(t proto.Message, f sdktypes
 // WrapServiceResult wraps a result from a protobuf RPC service method call (res proto.Message, err error)
 // in a Result object or error. This method takes care of marshaling the res param to
 // protobuf and attaching any events on the ctx.EventManager() to the Result.
-// If a custom handler is registered for the message type, it will also extract
-// custom data and digest from the result.
+// If a custom or default handler applies to the message type, it will also extract
+// custom data and digest from the result, failing with errors.ErrResultExtraction if
+// the handler does.
 func (r *CustomResultManager) WrapServiceResult(ctx sdktypes.Context, res proto.Message, err error) (*sdktypes.AvsiResult, error) {
 	if err != nil {
 		return nil, err
@@ -61,9 +102,14 @@ func (r *CustomResultManager) WrapServiceResult(ctx sdktypes.Context, res proto.
 		},
 	}
 
-	if resHandler, ok := r.customHandlers[sdk.MsgTypeURL(res)]; ok {
-		outResult.CustomData, _ = resHandler.GetData(res)
-		outResult.CustomDigest, _ = resHandler.GetDigest(res)
+	if resHandler, ok := r.handler(sdk.MsgTypeURL(res)); ok {
+		// a result without its custom data or digest must not be signed by operators
+		if outResult.CustomData, err = resHandler.GetData(res); err != nil {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrResultExtraction, "custom data of %s: %s", sdk.MsgTypeURL(res), err)
+		}
+		if outResult.CustomDigest, err = resHandler.GetDigest(res); err != nil {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrResultExtraction, "custom digest of %s: %s", sdk.MsgTypeURL(res), err)
+		}
 	}
 
 	return outResult, nil
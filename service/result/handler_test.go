@@ -0,0 +1,125 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+// MockResultMsgExtractor implements sdktypes.ResultMsgExtractor for testing
+type MockResultMsgExtractor struct {
+	mock.Mock
+}
+
+func (m *MockResultMsgExtractor) GetData(msg proto.Message) ([]byte, error) {
+	args := m.Called(msg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockResultMsgExtractor) GetDigest(msg proto.Message) ([]byte, error) {
+	args := m.Called(msg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestWrapServiceResult(t *testing.T) {
+	ctx := sdktypes.NewContext(context.Background(), nil, nil)
+	res := &testpb.TestMsg{TypeUrl: "result"}
+
+	t.Run("without extractor", func(t *testing.T) {
+		out, err := NewCustomResultManager().WrapServiceResult(ctx, res, nil)
+		require.NoError(t, err)
+
+		data, err := proto.Marshal(res)
+		require.NoError(t, err)
+		assert.Equal(t, data, out.Data)
+		assert.Nil(t, out.CustomData)
+		assert.Nil(t, out.CustomDigest)
+		assert.False(t, NewCustomResultManager().HasHandler("/test.service.TestMsg"))
+	})
+
+	t.Run("with extractor", func(t *testing.T) {
+		extractor := new(MockResultMsgExtractor)
+		extractor.On("GetData", res).Return([]byte("data"), nil)
+		extractor.On("GetDigest", res).Return([]byte("digest"), nil)
+
+		manager := NewCustomResultManager()
+		manager.RegisterCustomizedFunc(res, extractor)
+
+		out, err := manager.WrapServiceResult(ctx, res, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data"), out.CustomData)
+		assert.Equal(t, []byte("digest"), out.CustomDigest)
+	})
+
+	t.Run("default extractor", func(t *testing.T) {
+		extractor := new(MockResultMsgExtractor)
+		extractor.On("GetData", res).Return([]byte("default data"), nil)
+		extractor.On("GetDigest", res).Return([]byte("default digest"), nil)
+
+		manager := NewCustomResultManager()
+		manager.SetDefaultHandler(extractor)
+		assert.True(t, manager.HasHandler("/test.service.TestMsg"))
+
+		out, err := manager.WrapServiceResult(ctx, res, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("default data"), out.CustomData)
+		assert.Equal(t, []byte("default digest"), out.CustomDigest)
+
+		// opted out types skip the default extractor
+		manager.OptOut(res)
+		assert.True(t, manager.HasHandler("/test.service.TestMsg"))
+		out, err = manager.WrapServiceResult(ctx, res, nil)
+		require.NoError(t, err)
+		assert.Nil(t, out.CustomData)
+		assert.Nil(t, out.CustomDigest)
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		_, err := NewCustomResultManager().WrapServiceResult(ctx, nil, assert.AnError)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	testCases := []struct {
+		name      string
+		dataErr   error
+		digestErr error
+	}{
+		{name: "data error", dataErr: assert.AnError},
+		{name: "digest error", digestErr: assert.AnError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extractor := new(MockResultMsgExtractor)
+			extractor.On("GetData", res).Return([]byte("data"), tc.dataErr)
+			extractor.On("GetDigest", res).Return([]byte("digest"), tc.digestErr).Maybe()
+
+			manager := NewCustomResultManager()
+			manager.RegisterCustomizedFunc(res, extractor)
+
+			out, err := manager.WrapServiceResult(ctx, res, nil)
+			assert.Nil(t, out)
+			assert.True(t, errors.Is(err, sdkerrors.ErrResultExtraction))
+			assert.ErrorContains(t, err, assert.AnError.Error())
+
+			codespace, code, _ := sdkerrors.AVSIInfo(err, false)
+			assert.Equal(t, sdkerrors.UndefinedCodespace, codespace)
+			assert.Equal(t, sdkerrors.ErrResultExtraction.AVSICode(), code)
+		})
+	}
+}
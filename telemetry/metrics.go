@@ -0,0 +1,129 @@
+// Package telemetry defines the metrics emitted by the AVSI pipeline, module
+// handlers and stores. Metrics are emitted through the cosmos-sdk telemetry
+// sinks and are no-ops until telemetry is enabled with New.
+package telemetry
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	storemetrics "cosmossdk.io/store/metrics"
+	cosmostelemetry "github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/hashicorp/go-metrics"
+)
+
+// Metric keys
+const (
+	MetricKeyAVSI               = "avsi"
+	MetricKeyProcessDVSRequest  = "process_dvs_request"
+	MetricKeyProcessDVSResponse = "process_dvs_response"
+	MetricKeyResult             = "result"
+	MetricKeyAnteFailure        = "ante_failure"
+	MetricKeyMsg                = "msg"
+	MetricKeyHandler            = "handler"
+	MetricKeyStore              = "store"
+	MetricKeyReadBytes          = "read_bytes"
+	MetricKeyWriteBytes         = "write_bytes"
+	MetricKeyRateLimit          = "rate_limit"
+	MetricKeyRejected           = "rejected"
+)
+
+// Metric label names
+const (
+	MetricLabelCodespace = "codespace"
+	MetricLabelCode      = "code"
+	MetricLabelMsgType   = "msg_type"
+	MetricLabelStore     = "store"
+	MetricLabelServer    = "server"
+	MetricLabelLimit     = "limit"
+	MetricLabelMethod    = "method"
+)
+
+var (
+	mtx          sync.RWMutex
+	globalLabels [][]string
+)
+
+// New enables telemetry as configured by cfg, see cosmostelemetry.New. It
+// returns nil metrics when telemetry is disabled.
+func New(cfg cosmostelemetry.Config) (*cosmostelemetry.Metrics, error) {
+	m, err := cosmostelemetry.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	mtx.Lock()
+	globalLabels = cfg.GlobalLabels
+	mtx.Unlock()
+	return m, nil
+}
+
+// IsTelemetryEnabled reports whether telemetry was enabled with New.
+func IsTelemetryEnabled() bool {
+	return cosmostelemetry.IsTelemetryEnabled()
+}
+
+// NewLabel creates a label with name and value.
+func NewLabel(name, value string) metrics.Label {
+	return cosmostelemetry.NewLabel(name, value)
+}
+
+// ResultLabels returns the labels of the outcome of a request, the codespace
+// and code of its error. Successful requests have an empty codespace and a 0 code.
+func ResultLabels(codespace string, code uint32) []metrics.Label {
+	return []metrics.Label{
+		NewLabel(MetricLabelCodespace, codespace),
+		NewLabel(MetricLabelCode, strconv.FormatUint(uint64(code), 10)),
+	}
+}
+
+// Now returns the current time when telemetry is enabled, the zero time otherwise.
+func Now() time.Time {
+	return cosmostelemetry.Now()
+}
+
+// MeasureSince emits the time elapsed since start under keys.
+func MeasureSince(start time.Time, keys ...string) {
+	cosmostelemetry.MeasureSince(start, keys...)
+}
+
+// MeasureSinceWithLabels emits the time elapsed since start under keys, with
+// labels and the global labels.
+func MeasureSinceWithLabels(keys []string, start time.Time, labels []metrics.Label) {
+	if !IsTelemetryEnabled() {
+		return
+	}
+
+	labels = append(append([]metrics.Label{}, labels...), parsedGlobalLabels()...)
+	metrics.MeasureSinceWithLabels(keys, start.UTC(), labels)
+}
+
+// IncrCounterWithLabels increments the counter under keys by val, with labels
+// and the global labels.
+func IncrCounterWithLabels(keys []string, val float32, labels []metrics.Label) {
+	cosmostelemetry.IncrCounterWithLabels(keys, val, labels)
+}
+
+// NewStoreMetrics returns the metrics gatherer of the commit multistore, a
+// no-op when telemetry is disabled.
+func NewStoreMetrics() storemetrics.StoreMetrics {
+	if !IsTelemetryEnabled() {
+		return storemetrics.NewNoOpMetrics()
+	}
+
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return storemetrics.NewMetrics(globalLabels)
+}
+
+func parsedGlobalLabels() []metrics.Label {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	labels := make([]metrics.Label, len(globalLabels))
+	for i, gl := range globalLabels {
+		labels[i] = NewLabel(gl[0], gl[1])
+	}
+	return labels
+}
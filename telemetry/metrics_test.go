@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"cosmossdk.io/store/dbadapter"
+	storemetrics "cosmossdk.io/store/metrics"
+	dbm "github.com/cosmos/cosmos-db"
+	cosmostelemetry "github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	assert.IsType(t, storemetrics.NoOpMetrics{}, NewStoreMetrics())
+
+	m, err := New(cosmostelemetry.Config{
+		Enabled:                 true,
+		PrometheusRetentionTime: 60,
+		GlobalLabels:            [][]string{{"chain_id", "1337"}},
+	})
+	require.NoError(t, err)
+	require.True(t, IsTelemetryEnabled())
+	assert.IsType(t, storemetrics.Metrics{}, NewStoreMetrics())
+
+	store := NewKVStore(&dbadapter.Store{DB: dbm.NewMemDB()}, "task")
+	store.Set([]byte("key"), []byte("value"))
+	assert.Equal(t, []byte("value"), store.Get([]byte("key")))
+	store.Delete([]byte("key"))
+
+	it := store.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		_ = it.Value()
+	}
+	require.NoError(t, it.Close())
+
+	MeasureSinceWithLabels([]string{MetricKeyMsg, MetricKeyHandler}, time.Now(), ResultLabels("sdk", 3))
+
+	res, err := m.Gather(cosmostelemetry.FormatPrometheus)
+	require.NoError(t, err)
+	out := string(res.Metrics)
+	assert.Contains(t, out, `store_write_bytes{chain_id="1337",store="task"} 11`)
+	assert.Contains(t, out, `store_read_bytes{chain_id="1337",store="task"} 8`)
+	assert.Contains(t, out, `msg_handler_count{chain_id="1337",code="3",codespace="sdk"} 1`)
+}
@@ -0,0 +1,134 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/hex"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/hashicorp/go-metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// KVStore counts the bytes of the keys and values read from and written to
+// a KVStore. Deletes count as writes of their key.
+type KVStore struct {
+	storetypes.KVStore
+
+	labels []metrics.Label
+}
+
+var _ storetypes.KVStore = &KVStore{}
+
+// NewKVStore wraps parent to count the bytes read and written under the
+// store name.
+func NewKVStore(parent storetypes.KVStore, name string) *KVStore {
+	return &KVStore{
+		KVStore: parent,
+		labels:  []metrics.Label{NewLabel(MetricLabelStore, name)},
+	}
+}
+
+func (s *KVStore) Get(key []byte) []byte {
+	value := s.KVStore.Get(key)
+	s.read(len(key) + len(value))
+	return value
+}
+
+func (s *KVStore) Has(key []byte) bool {
+	s.read(len(key))
+	return s.KVStore.Has(key)
+}
+
+func (s *KVStore) Set(key, value []byte) {
+	s.KVStore.Set(key, value)
+	s.write(len(key) + len(value))
+}
+
+func (s *KVStore) Delete(key []byte) {
+	s.KVStore.Delete(key)
+	s.write(len(key))
+}
+
+func (s *KVStore) Iterator(start, end []byte) storetypes.Iterator {
+	return &iterator{Iterator: s.KVStore.Iterator(start, end), store: s}
+}
+
+func (s *KVStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	return &iterator{Iterator: s.KVStore.ReverseIterator(start, end), store: s}
+}
+
+func (s *KVStore) read(n int) {
+	IncrCounterWithLabels([]string{MetricKeyStore, MetricKeyReadBytes}, float32(n), s.labels)
+}
+
+func (s *KVStore) write(n int) {
+	IncrCounterWithLabels([]string{MetricKeyStore, MetricKeyWriteBytes}, float32(n), s.labels)
+}
+
+// iterator counts the bytes of the values read through it along with their keys
+type iterator struct {
+	storetypes.Iterator
+
+	store *KVStore
+}
+
+func (it *iterator) Value() []byte {
+	value := it.Iterator.Value()
+	it.store.read(len(it.Iterator.Key()) + len(value))
+	return value
+}
+
+// TracedKVStore runs the operations on a KVStore in spans, children of the
+// span of the context it was created with.
+type TracedKVStore struct {
+	storetypes.KVStore
+
+	ctx  context.Context
+	name string
+}
+
+var _ storetypes.KVStore = &TracedKVStore{}
+
+// NewTracedKVStore wraps parent to trace its operations under ctx.
+func NewTracedKVStore(ctx context.Context, parent storetypes.KVStore, name string) *TracedKVStore {
+	return &TracedKVStore{KVStore: parent, ctx: ctx, name: name}
+}
+
+func (s *TracedKVStore) Get(key []byte) []byte {
+	defer s.span("store.Get", key).End()
+	return s.KVStore.Get(key)
+}
+
+func (s *TracedKVStore) Has(key []byte) bool {
+	defer s.span("store.Has", key).End()
+	return s.KVStore.Has(key)
+}
+
+func (s *TracedKVStore) Set(key, value []byte) {
+	defer s.span("store.Set", key).End()
+	s.KVStore.Set(key, value)
+}
+
+func (s *TracedKVStore) Delete(key []byte) {
+	defer s.span("store.Delete", key).End()
+	s.KVStore.Delete(key)
+}
+
+func (s *TracedKVStore) Iterator(start, end []byte) storetypes.Iterator {
+	defer s.span("store.Iterator", start).End()
+	return s.KVStore.Iterator(start, end)
+}
+
+func (s *TracedKVStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	defer s.span("store.ReverseIterator", start).End()
+	return s.KVStore.ReverseIterator(start, end)
+}
+
+func (s *TracedKVStore) span(name string, key []byte) trace.Span {
+	_, span := StartSpan(s.ctx, name,
+		attribute.String(MetricLabelStore, s.name),
+		attribute.String("key", hex.EncodeToString(key)),
+	)
+	return span
+}
@@ -0,0 +1,201 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TracerName is the name of the tracer of the spans emitted by the SDK.
+const TracerName = "github.com/0xPellNetwork/pellapp-sdk"
+
+// Trace exporters
+const (
+	TraceExporterStdout = "stdout"
+	TraceExporterFile   = "file"
+)
+
+// tracingEnabled reports whether a tracer provider was installed with
+// SetTracerProvider, spans are then also emitted around store operations
+var tracingEnabled atomic.Bool
+
+// TracingConfig defines the configuration of OpenTelemetry tracing.
+type TracingConfig struct {
+	// Enabled enables tracing of AVSI calls, handlers, stores and gRPC queries.
+	Enabled bool `mapstructure:"enabled"`
+
+	// ServiceName is the service.name resource attribute of the spans.
+	ServiceName string `mapstructure:"service-name"`
+
+	// Exporter defines where spans are exported, "stdout" or "file".
+	Exporter string `mapstructure:"exporter"`
+
+	// File is the path spans are written to by the file exporter, relative
+	// to the home directory unless absolute.
+	File string `mapstructure:"file"`
+
+	// SampleRatio is the ratio of traces sampled, between 0 and 1. Spans with
+	// a sampled parent, e.g. propagated from a gRPC client, are always sampled.
+	SampleRatio float64 `mapstructure:"sample-ratio"`
+}
+
+// DefaultTracingConfig returns the default tracing configuration, disabled.
+func DefaultTracingConfig() TracingConfig {
+	return TracingConfig{
+		Enabled:     false,
+		ServiceName: "pellapp",
+		Exporter:    TraceExporterStdout,
+		File:        filepath.Join("data", "traces.json"),
+		SampleRatio: 1,
+	}
+}
+
+// ValidateBasic returns an error if the configuration is invalid.
+func (c TracingConfig) ValidateBasic() error {
+	switch c.Exporter {
+	case TraceExporterStdout, TraceExporterFile:
+	default:
+		return fmt.Errorf("unknown trace exporter %q, expected %q or %q", c.Exporter, TraceExporterStdout, TraceExporterFile)
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("trace sample ratio %v is not between 0 and 1", c.SampleRatio)
+	}
+	return nil
+}
+
+// StartTracing installs a tracer provider exporting spans as configured by
+// cfg. The returned function flushes pending spans and releases the exporter,
+// it is a no-op when tracing is disabled.
+func StartTracing(cfg TracingConfig, home string) (shutdown func(context.Context) error, err error) {
+	shutdown = func(context.Context) error { return nil }
+	if !cfg.Enabled {
+		return shutdown, nil
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return shutdown, err
+	}
+
+	var w io.Writer = os.Stdout
+	var file *os.File
+	if cfg.Exporter == TraceExporterFile {
+		path := cfg.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(home, path)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return shutdown, err
+		}
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return shutdown, fmt.Errorf("failed to open trace file: %w", err)
+		}
+		w = file
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return shutdown, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
+
+// SetTracerProvider installs tp as the global tracer provider, along with the
+// W3C trace context and baggage propagators.
+func SetTracerProvider(tp trace.TracerProvider) {
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	tracingEnabled.Store(true)
+}
+
+// IsTracingEnabled reports whether a tracer provider was installed.
+func IsTracingEnabled() bool {
+	return tracingEnabled.Load()
+}
+
+// StartSpan starts a span named name, child of the span of ctx if any. A nil
+// ctx is treated as context.Background().
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err, if any, on span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor running each call in a
+// server span, child of the span propagated in the request metadata if any.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+		}
+
+		ctx, span := otel.Tracer(TracerName).Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("rpc.system", "grpc")),
+		)
+		defer func() { EndSpan(span, err) }()
+
+		return handler(ctx, req)
+	}
+}
+
+// metadataCarrier adapts gRPC metadata to a propagation.TextMapCarrier
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
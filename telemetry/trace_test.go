@@ -0,0 +1,93 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cosmossdk.io/store/dbadapter"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTracingConfigValidateBasic(t *testing.T) {
+	require.NoError(t, DefaultTracingConfig().ValidateBasic())
+
+	cfg := DefaultTracingConfig()
+	cfg.Exporter = "jaeger"
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultTracingConfig()
+	cfg.SampleRatio = 1.5
+	require.Error(t, cfg.ValidateBasic())
+}
+
+// restoreTracing restores the global tracer provider and propagator, and
+// whether tracing is enabled, once t ends
+func restoreTracing(t *testing.T) {
+	enabled := tracingEnabled.Load()
+	tp, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(propagator)
+		tracingEnabled.Store(enabled)
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	restoreTracing(t)
+	recorder := tracetest.NewSpanRecorder()
+	SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	require.True(t, IsTracingEnabled())
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"traceparent", "00-"+traceID+"-00f067aa0ba902b7-01",
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Query/Task"}
+	_, err := UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+		store := NewTracedKVStore(ctx, &dbadapter.Store{DB: dbm.NewMemDB()}, "task")
+		store.Set([]byte("key"), []byte("value"))
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	storeSpan, serverSpan := spans[0], spans[1]
+	assert.Equal(t, "store.Set", storeSpan.Name())
+	assert.Equal(t, "/test.Query/Task", serverSpan.Name())
+	assert.Equal(t, traceID, serverSpan.SpanContext().TraceID().String())
+	assert.True(t, serverSpan.Parent().IsRemote())
+	assert.Equal(t, serverSpan.SpanContext().SpanID(), storeSpan.Parent().SpanID())
+}
+
+func TestStartTracing(t *testing.T) {
+	restoreTracing(t)
+	shutdown, err := StartTracing(DefaultTracingConfig(), t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+
+	home := t.TempDir()
+	cfg := DefaultTracingConfig()
+	cfg.Enabled = true
+	cfg.Exporter = TraceExporterFile
+	shutdown, err = StartTracing(cfg, home)
+	require.NoError(t, err)
+
+	_, span := StartSpan(context.Background(), "avsi.ProcessDVSRequest")
+	EndSpan(span, nil)
+	require.NoError(t, shutdown(context.Background()))
+
+	out, err := os.ReadFile(filepath.Join(home, "data", "traces.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"Name":"avsi.ProcessDVSRequest"`)
+	assert.Contains(t, string(out), `"Value":"pellapp"`)
+}
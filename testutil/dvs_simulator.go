@@ -0,0 +1,365 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/0xPellNetwork/pelldvs-libs/crypto/bls"
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	avsitypes "github.com/0xPellNetwork/pelldvs/avsi/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	servertypes "github.com/0xPellNetwork/pellapp-sdk/server/types"
+)
+
+const (
+	// SimOperatorIndexKey is the AppOptions key holding the index of the
+	// operator an application is created for by the DVSSimulator.
+	SimOperatorIndexKey = "simulator.operator_index"
+
+	// DefaultSimThresholdPercentage is the group threshold used by
+	// DVSSimulator.NewRequest.
+	DefaultSimThresholdPercentage = 67
+
+	// responseDigestLen is the digest length PellDVS operators sign
+	responseDigestLen = 32
+)
+
+// SimOperator is an operator of a DVSSimulator, running its own application
+// over its own in-memory database.
+type SimOperator struct {
+	App      servertypes.Application
+	DB       dbm.DB
+	KeyPair  *bls.KeyPair
+	Operator *avsitypes.Operator
+	// Groups lists the group numbers the operator is registered in
+	Groups []uint32
+}
+
+// SimResult is the outcome of a DVS request processed by the operators of a
+// DVSSimulator.
+type SimResult struct {
+	// Responses holds the ProcessDVSRequest response of each participating operator, by operator index
+	Responses map[int]*avsitypes.ResponseProcessDVSRequest
+	// RequestErrors holds the ProcessDVSRequest error of each failing operator, by operator index
+	RequestErrors map[int]error
+	// Signers lists the indexes of the operators whose signature was aggregated
+	Signers []int
+	// DVSResponse is the aggregated response, nil when no digest reached the group thresholds
+	DVSResponse *avsitypes.DVSResponse
+	// PostResponses holds the ProcessDVSResponse response of each participating operator, by operator index
+	PostResponses map[int]*avsitypes.ResponseProcessDVSResponse
+	// PostErrors holds the ProcessDVSResponse error of each failing operator, by operator index
+	PostErrors map[int]error
+}
+
+// SimulatorOption configures a DVSSimulator.
+type SimulatorOption func(*DVSSimulator)
+
+// WithSimLogger sets the logger passed to the AppCreator, logs are discarded by default.
+func WithSimLogger(logger log.Logger) SimulatorOption {
+	return func(s *DVSSimulator) {
+		s.logger = logger
+	}
+}
+
+// WithSimAppOptions sets the AppOptions passed to the AppCreator. The
+// simulator answers SimOperatorIndexKey itself and delegates other keys.
+func WithSimAppOptions(opts servertypes.AppOptions) SimulatorOption {
+	return func(s *DVSSimulator) {
+		s.appOpts = opts
+	}
+}
+
+// WithSimStakes sets the stake of each operator, by operator index. Operators
+// default to a stake of 1.
+func WithSimStakes(stakes ...int64) SimulatorOption {
+	return func(s *DVSSimulator) {
+		s.stakes = stakes
+	}
+}
+
+// WithSimGroups sets the group numbers of each operator, by operator index.
+// Operators default to group 0.
+func WithSimGroups(groups ...[]uint32) SimulatorOption {
+	return func(s *DVSSimulator) {
+		s.groups = groups
+	}
+}
+
+// DVSSimulator runs a DVS round in-process: every operator processes the
+// request, a fake aggregator collects the BLS signatures of the response
+// digests and checks the group thresholds, then every operator processes the
+// aggregated response. It lets module authors write end-to-end task tests
+// with no network.
+type DVSSimulator struct {
+	Operators []*SimOperator
+
+	logger  log.Logger
+	appOpts servertypes.AppOptions
+	stakes  []int64
+	groups  [][]uint32
+	height  int64
+}
+
+// NewDVSSimulator creates n operators, each running an application created
+// by appCreator over a fresh MemDB with a random BLS key.
+func NewDVSSimulator(t testing.TB, n int, appCreator servertypes.AppCreator, opts ...SimulatorOption) *DVSSimulator {
+	s := &DVSSimulator{
+		logger: log.NewLogger(io.Discard),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	for i := 0; i < n; i++ {
+		keyPair, err := bls.GenRandomBlsKeys()
+		require.NoError(t, err)
+
+		g1 := keyPair.GetPubKeyG1().Serialize()
+		id := crypto.Keccak256(g1)
+
+		stake := int64(1)
+		if i < len(s.stakes) {
+			stake = s.stakes[i]
+		}
+		groups := []uint32{0}
+		if i < len(s.groups) {
+			groups = s.groups[i]
+		}
+
+		db := dbm.NewMemDB()
+		app := appCreator(s.logger, db, nil, simAppOptions{index: i, parent: s.appOpts})
+		t.Cleanup(func() {
+			_ = app.Close()
+		})
+
+		s.Operators = append(s.Operators, &SimOperator{
+			App:     app,
+			DB:      db,
+			KeyPair: keyPair,
+			Operator: &avsitypes.Operator{
+				Id:      id,
+				Address: id[12:],
+				Socket:  fmt.Sprintf("sim-operator-%d", i),
+				Stake:   stake,
+				Pubkeys: &avsitypes.OperatorPubkeys{
+					G1Pubkey: g1,
+					G2Pubkey: keyPair.GetPubKeyG2().Serialize(),
+				},
+			},
+			Groups: groups,
+		})
+	}
+
+	return s
+}
+
+// NewRequest returns a request carrying data for every group of the
+// operators, with the default threshold, at the next height.
+func (s *DVSSimulator) NewRequest(data []byte) *avsitypes.DVSRequest {
+	s.height++
+
+	var groupNumbers, thresholds []uint32
+	seen := map[uint32]bool{}
+	for _, op := range s.Operators {
+		for _, g := range op.Groups {
+			if !seen[g] {
+				seen[g] = true
+				groupNumbers = append(groupNumbers, g)
+				thresholds = append(thresholds, DefaultSimThresholdPercentage)
+			}
+		}
+	}
+
+	return &avsitypes.DVSRequest{
+		Data:                      data,
+		Height:                    s.height,
+		ChainId:                   1337,
+		GroupNumbers:              groupNumbers,
+		GroupThresholdPercentages: thresholds,
+	}
+}
+
+// Process runs a DVS round for req. Operators that fail to process the
+// request or return a digest that is not 32 bytes long do not sign. When no
+// digest reaches the threshold of every group of the request, the returned
+// result has no DVSResponse and an error is returned.
+func (s *DVSSimulator) Process(ctx context.Context, req *avsitypes.DVSRequest) (*SimResult, error) {
+	if len(req.GroupNumbers) != len(req.GroupThresholdPercentages) {
+		return nil, fmt.Errorf("got %d group numbers and %d threshold percentages", len(req.GroupNumbers), len(req.GroupThresholdPercentages))
+	}
+
+	participants := s.participants(req)
+	operators := make([]*avsitypes.Operator, len(participants))
+	for i, idx := range participants {
+		operators[i] = s.Operators[idx].Operator
+	}
+
+	res := &SimResult{
+		Responses:     map[int]*avsitypes.ResponseProcessDVSRequest{},
+		RequestErrors: map[int]error{},
+		PostResponses: map[int]*avsitypes.ResponseProcessDVSResponse{},
+		PostErrors:    map[int]error{},
+	}
+
+	for _, idx := range participants {
+		resp, err := s.Operators[idx].App.ProcessDVSRequest(ctx, &avsitypes.RequestProcessDVSRequest{
+			Request:  req,
+			Operator: operators,
+		})
+		if err == nil && len(resp.ResponseDigest) != responseDigestLen {
+			err = fmt.Errorf("response digest length %d is not equal to %d", len(resp.ResponseDigest), responseDigestLen)
+		}
+		res.Responses[idx] = resp
+		if err != nil {
+			res.RequestErrors[idx] = err
+		}
+	}
+
+	digest, signers := s.quorum(req, participants, res)
+	if signers == nil {
+		return res, fmt.Errorf("no response digest reached the group thresholds, %d of %d operators failed", len(res.RequestErrors), len(participants))
+	}
+	res.Signers = signers
+	res.DVSResponse = s.aggregate(req, participants, signers, res.Responses[signers[0]].Response, digest)
+
+	for _, idx := range participants {
+		resp, err := s.Operators[idx].App.ProcessDVSResponse(ctx, &avsitypes.RequestProcessDVSResponse{
+			DvsRequest:  req,
+			DvsResponse: res.DVSResponse,
+		})
+		res.PostResponses[idx] = resp
+		if err != nil {
+			res.PostErrors[idx] = err
+		}
+	}
+
+	return res, nil
+}
+
+// participants returns the indexes of the operators registered in a group of req
+func (s *DVSSimulator) participants(req *avsitypes.DVSRequest) []int {
+	var participants []int
+	for i, op := range s.Operators {
+		if s.inGroups(op, req.GroupNumbers) {
+			participants = append(participants, i)
+		}
+	}
+	return participants
+}
+
+func (s *DVSSimulator) inGroups(op *SimOperator, groups []uint32) bool {
+	for _, g := range op.Groups {
+		for _, rg := range groups {
+			if g == rg {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// quorum returns the first digest, in operator order, whose signers reach the
+// threshold of every group of req, along with its signers
+func (s *DVSSimulator) quorum(req *avsitypes.DVSRequest, participants []int, res *SimResult) ([]byte, []int) {
+	for _, candidate := range participants {
+		if _, failed := res.RequestErrors[candidate]; failed {
+			continue
+		}
+		digest := res.Responses[candidate].ResponseDigest
+
+		var signers []int
+		for _, idx := range participants {
+			if _, failed := res.RequestErrors[idx]; !failed && bytes.Equal(res.Responses[idx].ResponseDigest, digest) {
+				signers = append(signers, idx)
+			}
+		}
+
+		if s.reachesThresholds(req, participants, signers) {
+			return digest, signers
+		}
+	}
+	return nil, nil
+}
+
+func (s *DVSSimulator) reachesThresholds(req *avsitypes.DVSRequest, participants, signers []int) bool {
+	for k, group := range req.GroupNumbers {
+		var total, signed int64
+		for _, idx := range participants {
+			if s.inGroups(s.Operators[idx], []uint32{group}) {
+				total += s.Operators[idx].Operator.Stake
+			}
+		}
+		for _, idx := range signers {
+			if s.inGroups(s.Operators[idx], []uint32{group}) {
+				signed += s.Operators[idx].Operator.Stake
+			}
+		}
+		if signed*100 < total*int64(req.GroupThresholdPercentages[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// aggregate builds the validated response the aggregator hands back to
+// operators. Registry indices are left empty as there is no chain to index.
+func (s *DVSSimulator) aggregate(req *avsitypes.DVSRequest, participants, signers []int, data, digest []byte) *avsitypes.DVSResponse {
+	signed := map[int]bool{}
+	sig := bls.NewZeroSignature()
+	apkG2 := bls.NewZeroG2Point()
+	for _, idx := range signers {
+		signed[idx] = true
+		sig.Add(s.Operators[idx].KeyPair.SignMessage([responseDigestLen]byte(digest)))
+		apkG2.Add(s.Operators[idx].KeyPair.GetPubKeyG2())
+	}
+
+	var nonSigners [][]byte
+	for _, idx := range participants {
+		if !signed[idx] {
+			nonSigners = append(nonSigners, s.Operators[idx].Operator.Pubkeys.G1Pubkey)
+		}
+	}
+
+	groupApks := make([][]byte, len(req.GroupNumbers))
+	for k, group := range req.GroupNumbers {
+		apk := bls.NewZeroG1Point()
+		for _, idx := range participants {
+			if s.inGroups(s.Operators[idx], []uint32{group}) {
+				apk.Add(s.Operators[idx].KeyPair.GetPubKeyG1())
+			}
+		}
+		groupApks[k] = apk.Serialize()
+	}
+
+	return &avsitypes.DVSResponse{
+		Data:                data,
+		Hash:                digest,
+		NonSignersPubkeysG1: nonSigners,
+		GroupApksG1:         groupApks,
+		SignersApkG2:        apkG2.Serialize(),
+		SignersAggSigG1:     sig.Serialize(),
+	}
+}
+
+// simAppOptions gives each application the index of its operator
+type simAppOptions struct {
+	index  int
+	parent servertypes.AppOptions
+}
+
+func (o simAppOptions) Get(key string) interface{} {
+	if key == SimOperatorIndexKey {
+		return o.index
+	}
+	if o.parent != nil {
+		return o.parent.Get(key)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package testutil
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/0xPellNetwork/pelldvs-libs/crypto/bls"
+	"github.com/0xPellNetwork/pelldvs-libs/log"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/baseapp"
+	"github.com/0xPellNetwork/pellapp-sdk/client"
+	"github.com/0xPellNetwork/pellapp-sdk/client/grpc/node"
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
+	"github.com/0xPellNetwork/pellapp-sdk/server/api"
+	"github.com/0xPellNetwork/pellapp-sdk/server/config"
+	servertypes "github.com/0xPellNetwork/pellapp-sdk/server/types"
+	"github.com/0xPellNetwork/pellapp-sdk/service/extractor"
+	"github.com/0xPellNetwork/pellapp-sdk/testutil/tasktest"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+// simTestService implements tasktest.ResponseServer, accepting tasks unless rejectAll is set
+type simTestService struct {
+	rejectAll bool
+
+	mu        sync.Mutex
+	validated []*sdktypes.Context
+}
+
+func (s *simTestService) SubmitTask(_ context.Context, msg *testpb.TestCallMsg) (*testpb.TestCallResult, error) {
+	return &testpb.TestCallResult{
+		TaskId:   msg.TaskId,
+		Digest:   crypto.Keccak256(msg.Payload),
+		Accepted: !s.rejectAll,
+	}, nil
+}
+
+func (s *simTestService) SubmitTaskDVSResponsHandler(ctx context.Context, _ *testpb.TestCallMsg) (*testpb.TestMsg, error) {
+	sdkCtx := sdktypes.UnwrapContext(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.validated = append(s.validated, &sdkCtx)
+	return &testpb.TestMsg{}, nil
+}
+
+type simTestApp struct {
+	*baseapp.BaseApp
+}
+
+func (simTestApp) RegisterAPIRoutes(*api.Server, config.APIConfig) {}
+
+func (app simTestApp) RegisterNodeService(_ client.Context, cfg config.Config) {
+	node.RegisterNodeService(app.GRPCQueryRouter(), app, cfg)
+}
+
+// newSimTestAppCreator returns an AppCreator whose operators listed in rejecting reject every task
+func newSimTestAppCreator(t *testing.T, services map[int]*simTestService, rejecting ...int) servertypes.AppCreator {
+	return func(logger log.Logger, db dbm.DB, _ io.Writer, opts servertypes.AppOptions) servertypes.Application {
+		index := opts.Get(SimOperatorIndexKey).(int)
+		svc := &simTestService{}
+		for _, r := range rejecting {
+			svc.rejectAll = svc.rejectAll || r == index
+		}
+		services[index] = svc
+
+		app := baseapp.NewBaseApp("sim", logger, db, nil)
+		tasktest.Setup(t, app, svc)
+		return simTestApp{app}
+	}
+}
+
+func simTestRequestData(t *testing.T) []byte {
+	return tasktest.RequestData(t, 7, []byte("task"))
+}
+
+func TestDVSSimulator(t *testing.T) {
+	services := map[int]*simTestService{}
+	sim := NewDVSSimulator(t, 4, newSimTestAppCreator(t, services))
+	req := sim.NewRequest(simTestRequestData(t))
+
+	res, err := sim.Process(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, res.RequestErrors)
+	assert.Empty(t, res.PostErrors)
+	assert.Equal(t, []int{0, 1, 2, 3}, res.Signers)
+	assert.Empty(t, res.DVSResponse.NonSignersPubkeysG1)
+
+	expected, err := extractor.NewKeccak256().GetDigest(&testpb.TestCallResult{
+		TaskId:   7,
+		Digest:   crypto.Keccak256([]byte("task")),
+		Accepted: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, res.DVSResponse.Hash)
+
+	// the aggregated signature verifies against the aggregated public key of the signers
+	sig := &bls.Signature{G1Point: new(bls.G1Point).Deserialize(res.DVSResponse.SignersAggSigG1)}
+	apk := new(bls.G2Point).Deserialize(res.DVSResponse.SignersApkG2)
+	ok, err := sig.Verify(apk, [32]byte(res.DVSResponse.Hash))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// every operator processed the validated response
+	for i, svc := range services {
+		require.Len(t, svc.validated, 1, "operator %d", i)
+		assert.Equal(t, res.DVSResponse, svc.validated[0].ValidatedResponse())
+		assert.Equal(t, req.Height, svc.validated[0].Height())
+	}
+}
+
+func TestDVSSimulatorThresholds(t *testing.T) {
+	services := map[int]*simTestService{}
+	sim := NewDVSSimulator(t, 4, newSimTestAppCreator(t, services, 3))
+
+	// 3 of 4 operators agree, above the default threshold
+	req := sim.NewRequest(simTestRequestData(t))
+	res, err := sim.Process(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, res.Signers)
+	assert.Equal(t, [][]byte{sim.Operators[3].Operator.Pubkeys.G1Pubkey}, res.DVSResponse.NonSignersPubkeysG1)
+
+	// 75% does not reach a threshold of 80%
+	req = sim.NewRequest(simTestRequestData(t))
+	req.GroupThresholdPercentages = []uint32{80}
+	res, err = sim.Process(context.Background(), req)
+	assert.ErrorContains(t, err, "no response digest reached the group thresholds")
+	assert.Nil(t, res.DVSResponse)
+	assert.Empty(t, res.PostResponses)
+}
+
+func TestDVSSimulatorGroups(t *testing.T) {
+	services := map[int]*simTestService{}
+	sim := NewDVSSimulator(t, 3, newSimTestAppCreator(t, services, 2),
+		WithSimStakes(1, 1, 10),
+		WithSimGroups([]uint32{0}, []uint32{0, 1}, []uint32{1}),
+	)
+
+	// group 1 is dominated by the rejecting operator
+	req := sim.NewRequest(simTestRequestData(t))
+	assert.Equal(t, []uint32{0, 1}, req.GroupNumbers)
+	_, err := sim.Process(context.Background(), req)
+	assert.Error(t, err)
+
+	// only operators of the requested groups participate
+	req = sim.NewRequest(simTestRequestData(t))
+	req.GroupNumbers = []uint32{0}
+	req.GroupThresholdPercentages = []uint32{100}
+	res, err := sim.Process(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, res.Signers)
+	assert.NotContains(t, res.Responses, 2)
+	assert.Len(t, res.DVSResponse.GroupApksG1, 1)
+}
@@ -0,0 +1,136 @@
+// Package tasktest provides the task contract fixture of DVS request tests:
+// the ABI of the contract, the descriptor of test.service.TestService
+// handling its calls, and the setup of an application serving them.
+package tasktest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	avsitypes "github.com/0xPellNetwork/pelldvs/avsi/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	testpb "github.com/0xPellNetwork/pellapp-sdk/proto/test"
+	"github.com/0xPellNetwork/pellapp-sdk/service"
+	"github.com/0xPellNetwork/pellapp-sdk/service/abicodec"
+	"github.com/0xPellNetwork/pellapp-sdk/service/extractor"
+	"github.com/0xPellNetwork/pellapp-sdk/service/tx"
+)
+
+// ABI is the ABI of the task contract, whose submitTask calls are decoded
+// into testpb.TestCallMsg.
+const ABI = `[{
+	"type": "function",
+	"name": "submitTask",
+	"inputs": [{"name": "taskId", "type": "uint64"}, {"name": "payload", "type": "bytes"}],
+	"outputs": []
+}]`
+
+// Server handles the tasks submitted to test.service.TestService.
+type Server interface {
+	SubmitTask(context.Context, *testpb.TestCallMsg) (*testpb.TestCallResult, error)
+}
+
+// ResponseServer handles the tasks and validates their responses.
+type ResponseServer interface {
+	Server
+	SubmitTaskDVSResponsHandler(context.Context, *testpb.TestCallMsg) (*testpb.TestMsg, error)
+}
+
+func submitTaskHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(testpb.TestCallMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv}, func(ctx context.Context, req any) (any, error) {
+		return srv.(Server).SubmitTask(ctx, req.(*testpb.TestCallMsg))
+	})
+}
+
+func submitTaskResponseHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(testpb.TestCallMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv}, func(ctx context.Context, req any) (any, error) {
+		return srv.(ResponseServer).SubmitTaskDVSResponsHandler(ctx, req.(*testpb.TestCallMsg))
+	})
+}
+
+// ServiceDesc describes test.service.TestService served by a Server.
+var ServiceDesc = &grpc.ServiceDesc{
+	ServiceName: "test.service.TestService",
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "SubmitTask", Handler: submitTaskHandler},
+	},
+}
+
+// ResponseServiceDesc describes test.service.TestService served by a
+// ResponseServer.
+var ResponseServiceDesc = &grpc.ServiceDesc{
+	ServiceName: "test.service.TestService",
+	HandlerType: (*ResponseServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "SubmitTask", Handler: submitTaskHandler},
+		{MethodName: "SubmitTaskDVSResponsHandler", Handler: submitTaskResponseHandler},
+	},
+}
+
+// Contract returns the ABI of the task contract.
+func Contract(t testing.TB) abi.ABI {
+	t.Helper()
+	contract, err := abi.JSON(strings.NewReader(ABI))
+	require.NoError(t, err)
+	return contract
+}
+
+// Coder returns the coder of the calls of the task contract.
+func Coder(t testing.TB) *abicodec.Coder {
+	t.Helper()
+	coder, err := abicodec.NewCoder(Contract(t), []abicodec.Route{{Method: "submitTask", Msg: &testpb.TestCallMsg{}}})
+	require.NoError(t, err)
+	return coder
+}
+
+// RequestData returns the data of a DVS request submitting the task taskID.
+func RequestData(t testing.TB, taskID uint64, payload []byte) []byte {
+	t.Helper()
+	data, err := Contract(t).Pack("submitTask", taskID, payload)
+	require.NoError(t, err)
+	return data
+}
+
+// Request returns a DVS request submitting the task taskID.
+func Request(t testing.TB, taskID uint64, payload []byte) *avsitypes.RequestProcessDVSRequest {
+	t.Helper()
+	return &avsitypes.RequestProcessDVSRequest{
+		Request: &avsitypes.DVSRequest{Data: RequestData(t, taskID, payload), Height: 1, ChainId: 1337, GroupNumbers: []uint32{0}},
+	}
+}
+
+// App is the application set up by Setup, e.g. a *baseapp.BaseApp.
+type App interface {
+	SetMsgEncoder(tx.MsgEncoder)
+	GetMsgRouter() *service.MsgRouter
+}
+
+// Setup makes app decode the calls of the task contract and handle them with
+// srv, digesting their results with Keccak-256. The responses are validated
+// by srv too when it is a ResponseServer.
+func Setup(t testing.TB, app App, srv Server) {
+	t.Helper()
+	app.SetMsgEncoder(Coder(t))
+
+	desc := ServiceDesc
+	if _, ok := srv.(ResponseServer); ok {
+		desc = ResponseServiceDesc
+	}
+	configurator := app.GetMsgRouter().GetConfigurator()
+	configurator.RegisterService(desc, srv)
+	configurator.RegisterResultMsgExtractor(&testpb.TestCallResult{}, extractor.NewKeccak256())
+	configurator.OptOutResultMsgExtractor(&testpb.TestMsg{})
+}
@@ -0,0 +1,34 @@
+package types
+
+import "context"
+
+// principalContextKey is the key of the Principal in a context.Context
+const principalContextKey ContextKeyType = "principal"
+
+// Authentication schemes of a Principal
+const (
+	AuthSchemeToken = "token"
+	AuthSchemeJWT   = "jwt"
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Name identifies the caller: the principal of a static token or the sub
+	// claim of a JWT.
+	Name string
+	// Scheme is the scheme the caller authenticated with.
+	Scheme string
+	// Claims are the claims of a JWT, nil for other schemes.
+	Claims map[string]any
+}
+
+// ContextWithPrincipal returns a copy of ctx carrying principal.
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey, principal)
+}
+
+// PrincipalFromContext returns the principal carried by ctx, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey).(Principal)
+	return principal, ok
+}
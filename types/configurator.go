@@ -15,4 +15,15 @@ type Configurator interface {
 
 	// RegisterResultMsgExtractor registers a custom handler for a specific message type
 	RegisterResultMsgExtractor(msg proto.Message, handler ResultMsgExtractor)
+
+	// OptOutResultMsgExtractor declares that results of a specific message type carry no custom data
+	OptOutResultMsgExtractor(msg proto.Message)
+}
+
+// ResultExtractorValidator is implemented by configurators that can report
+// request handlers whose results cannot be extracted
+type ResultExtractorValidator interface {
+	// MissingResultExtractors returns the response types of the request handlers of a service
+	// that have no result extractor and did not opt out
+	MissingResultExtractors(service string) []string
 }
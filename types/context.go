@@ -7,6 +7,8 @@ import (
 	storetypes "cosmossdk.io/store/types"
 	"github.com/0xPellNetwork/pelldvs-libs/log"
 	avsitypes "github.com/0xPellNetwork/pelldvs/avsi/types"
+
+	"github.com/0xPellNetwork/pellapp-sdk/telemetry"
 )
 
 type ContextKeyType string
@@ -31,6 +33,10 @@ type Context struct {
 	requestData               []byte
 	operators                 []*avsitypes.Operator
 	validatedResponse         *avsitypes.DVSResponse
+	principal                 *Principal
+	msgDispatcher             MsgDispatcher
+	dispatchDepth             int
+	dispatchAuthority         string
 	logger                    log.Logger
 }
 
@@ -57,12 +63,42 @@ func (c Context) ValidatedResponse() *avsitypes.DVSResponse {
 	return c.validatedResponse
 }
 
+// Principal returns the authenticated caller of a gRPC query, if any.
+func (c Context) Principal() (Principal, bool) {
+	if c.principal == nil {
+		return Principal{}, false
+	}
+	return *c.principal, true
+}
+
+// MsgDispatcher returns the dispatcher executing messages of other modules
+// from within message handlers, nil outside of them.
+func (c Context) MsgDispatcher() MsgDispatcher { return c.msgDispatcher }
+
+// DispatchDepth returns the number of nested dispatches the context was
+// derived from, 0 for the message of the DVS request.
+func (c Context) DispatchDepth() int { return c.dispatchDepth }
+
+// DispatchAuthority returns the authority the message being handled was
+// dispatched on behalf of, empty for the message of the DVS request or when
+// dispatched by a dispatcher bound to no authority.
+func (c Context) DispatchAuthority() string { return c.dispatchAuthority }
+
 // MultiStore returns the MultiStore for this context.
 func (c Context) MultiStore() storetypes.MultiStore { return c.ms }
 
 // KVStore returns the KV store for a specific store key.
+// When telemetry is enabled, the bytes read and written are counted, and
+// when tracing is enabled, operations are traced under Context().
 func (c Context) KVStore(key storetypes.StoreKey) storetypes.KVStore {
-	return c.ms.GetKVStore(key)
+	store := c.ms.GetKVStore(key)
+	if telemetry.IsTelemetryEnabled() {
+		store = telemetry.NewKVStore(store, key.Name())
+	}
+	if telemetry.IsTracingEnabled() {
+		store = telemetry.NewTracedKVStore(c.baseCtx, store, key.Name())
+	}
+	return store
 }
 
 // CacheContext returns a new Context with the multi-store cached and a new
@@ -173,6 +209,33 @@ func (c Context) WithValidatedResponse(validatedData *avsitypes.DVSResponse) Con
 	return c
 }
 
+// WithPrincipal returns a Context with the authenticated caller.
+func (c Context) WithPrincipal(principal Principal) Context {
+	c.principal = &principal
+	return c
+}
+
+// WithMsgDispatcher returns a Context with the dispatcher executing messages
+// of other modules.
+func (c Context) WithMsgDispatcher(dispatcher MsgDispatcher) Context {
+	c.msgDispatcher = dispatcher
+	return c
+}
+
+// WithDispatchDepth returns a Context with the number of nested dispatches it
+// is derived from.
+func (c Context) WithDispatchDepth(depth int) Context {
+	c.dispatchDepth = depth
+	return c
+}
+
+// WithDispatchAuthority returns a Context with the authority the message
+// being handled was dispatched on behalf of.
+func (c Context) WithDispatchAuthority(authority string) Context {
+	c.dispatchAuthority = authority
+	return c
+}
+
 // WithMultiStore returns a Context with an updated MultiStore.
 func (c Context) WithMultiStore(ms storetypes.MultiStore) Context {
 	c.ms = ms
@@ -0,0 +1,35 @@
+package types
+
+import (
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// MaxDispatchDepth is the maximum number of nested messages dispatched from
+// within message handlers, bounding recursion between modules.
+const MaxDispatchDepth = 8
+
+// MsgDispatcher executes messages of other modules from within message
+// handlers, e.g. a task module rewarding operators through a rewards module.
+// A module dispatches on behalf of its own authority with the dispatcher bound
+// to it at the construction of its keeper, see baseapp.BaseApp.ModuleMsgDispatcher.
+type MsgDispatcher interface {
+	// Authority returns the authority messages are dispatched on behalf of,
+	// empty if the dispatcher is not bound to one.
+	Authority() string
+
+	// Dispatch executes msg with the request handler registered for it, on
+	// behalf of the authority of the dispatcher, on a branch of the state of
+	// ctx. The writes and events of the handler are merged into ctx if it
+	// succeeds, and discarded otherwise. Messages implementing HasAuthority
+	// are refused unless their authority is the one of the dispatcher.
+	Dispatch(ctx Context, msg proto.Message) (*AvsiResult, error)
+}
+
+// HasAuthority is implemented by messages which may only be dispatched on
+// behalf of an authority, e.g. a module. The handlers of other messages may
+// check the authority they were dispatched on behalf of with
+// Context.DispatchAuthority.
+type HasAuthority interface {
+	// GetAuthority returns the authority allowed to dispatch the message.
+	GetAuthority() string
+}
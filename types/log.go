@@ -0,0 +1,10 @@
+package types
+
+// Keys of the request-scoped fields of the context logger
+const (
+	LogKeyChainID     = "chain_id"
+	LogKeyHeight      = "height"
+	LogKeyRequestHash = "request_hash"
+	LogKeyOperators   = "operators"
+	LogKeyMsgType     = "msg_type"
+)
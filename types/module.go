@@ -1,9 +1,16 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	gogogrpc "github.com/cosmos/gogoproto/grpc"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
 )
 
 // AppModule is a marker interface for all app modules in the system
@@ -39,10 +46,29 @@ type MsgResultExtractor interface {
 	RegisterResultMsgExtractors(Configurator)
 }
 
+// HasGenesis is the extension interface for modules with state initialized
+// from and exported to a genesis state.
+type HasGenesis interface {
+	// DefaultGenesis returns the default genesis state of the module
+	DefaultGenesis(codec.JSONCodec) json.RawMessage
+
+	// ValidateGenesis validates the genesis state of the module
+	ValidateGenesis(codec.JSONCodec, json.RawMessage) error
+
+	// InitGenesis initializes the state of the module from its genesis state
+	InitGenesis(Context, codec.JSONCodec, json.RawMessage) error
+
+	// ExportGenesis exports the state of the module as its genesis state
+	ExportGenesis(Context, codec.JSONCodec) (json.RawMessage, error)
+}
+
 // ModuleManager defines a module manager that provides the high level utility
 // for managing and executing operations for a group of modules
 type ModuleManager struct {
 	Modules map[string]any
+
+	// services registered by each module, by module name
+	services map[string][]string
 }
 
 // NewManager creates a new Manager object based on the provided modules
@@ -54,14 +80,82 @@ func NewManager(modules ...BasicModule) *ModuleManager {
 	}
 
 	return &ModuleManager{
-		Modules: moduleMap,
+		Modules:  moduleMap,
+		services: make(map[string][]string),
 	}
 }
 
-// RegisterServices calls RegisterServices on all modules
+// RegisterServices calls RegisterServices on all modules. The modules are
+// given a Configurator wrapping c, recording the services they register, so
+// they must not type-assert it, e.g. to *service.Configurator, but call
+// UnwrapConfigurator to get c.
 func (m *ModuleManager) RegisterServices(c Configurator) {
-	for _, module := range m.Modules {
-		module.(BasicModule).RegisterServices(c)
+	if m.services == nil {
+		m.services = make(map[string][]string)
+	}
+
+	for name, module := range m.Modules {
+		recorder := &serviceRecorder{Configurator: c}
+		module.(BasicModule).RegisterServices(recorder)
+		m.services[name] = append(m.services[name], recorder.services...)
+	}
+}
+
+// ValidateResultExtractors checks that the response of every request handler
+// registered through RegisterServices has a result extractor, either registered
+// by a module or the default one, or explicitly opted out. Otherwise operators
+// would sign responses with an empty digest. It should be called once all
+// modules registered their services and extractors.
+func (m *ModuleManager) ValidateResultExtractors(c Configurator) error {
+	validator, ok := c.(ResultExtractorValidator)
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(m.services))
+	for name := range m.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		for _, service := range m.services[name] {
+			for _, typeURL := range validator.MissingResultExtractors(service) {
+				errs = append(errs, fmt.Errorf("module %s: no result extractor for %s returned by %s", name, typeURL, service))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// serviceRecorder records the services registered by a module
+type serviceRecorder struct {
+	Configurator
+	services []string
+}
+
+// RegisterService records the service name and registers the service
+func (r *serviceRecorder) RegisterService(sd *grpc.ServiceDesc, handler any) {
+	r.services = append(r.services, sd.ServiceName)
+	r.Configurator.RegisterService(sd, handler)
+}
+
+// Unwrap returns the wrapped configurator
+func (r *serviceRecorder) Unwrap() Configurator {
+	return r.Configurator
+}
+
+// UnwrapConfigurator returns the configurator given to
+// ModuleManager.RegisterServices from the one a module is given, or c itself
+// if it does not wrap another configurator.
+func UnwrapConfigurator(c Configurator) Configurator {
+	for {
+		wrapper, ok := c.(interface{ Unwrap() Configurator })
+		if !ok {
+			return c
+		}
+		c = wrapper.Unwrap()
 	}
 }
 
@@ -95,3 +189,84 @@ func (m *ModuleManager) RegisterQueryServices(router gogogrpc.Server) {
 		module.(BasicModule).RegisterQueryServices(router)
 	}
 }
+
+// DefaultGenesis returns the default genesis state of the modules implementing
+// HasGenesis, by module name
+func (m *ModuleManager) DefaultGenesis(cdc codec.JSONCodec) map[string]json.RawMessage {
+	genesis := make(map[string]json.RawMessage)
+	for name, module := range m.Modules {
+		if module, ok := module.(HasGenesis); ok {
+			genesis[name] = module.DefaultGenesis(cdc)
+		}
+	}
+	return genesis
+}
+
+// ValidateGenesis validates the genesis state of the modules implementing
+// HasGenesis. Modules missing from genesis are not validated.
+func (m *ModuleManager) ValidateGenesis(cdc codec.JSONCodec, genesis map[string]json.RawMessage) error {
+	var errs []error
+	for _, name := range m.moduleNames() {
+		module, ok := m.Modules[name].(HasGenesis)
+		if !ok || genesis[name] == nil {
+			continue
+		}
+		if err := module.ValidateGenesis(cdc, genesis[name]); err != nil {
+			errs = append(errs, fmt.Errorf("module %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// InitGenesis initializes the state of the modules implementing HasGenesis
+// from genesis, in module name order. Modules missing from genesis are not
+// initialized.
+func (m *ModuleManager) InitGenesis(ctx Context, cdc codec.JSONCodec, genesis map[string]json.RawMessage) error {
+	for _, name := range m.moduleNames() {
+		module, ok := m.Modules[name].(HasGenesis)
+		if !ok || genesis[name] == nil {
+			continue
+		}
+		if err := module.InitGenesis(ctx, cdc, genesis[name]); err != nil {
+			return fmt.Errorf("module %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ExportGenesis exports the state of the modules implementing HasGenesis, by
+// module name. Only modulesToExport are exported, unless it is empty.
+func (m *ModuleManager) ExportGenesis(ctx Context, cdc codec.JSONCodec, modulesToExport ...string) (map[string]json.RawMessage, error) {
+	for _, name := range modulesToExport {
+		if _, ok := m.Modules[name].(HasGenesis); !ok {
+			return nil, fmt.Errorf("module %s has no genesis to export", name)
+		}
+	}
+	if len(modulesToExport) == 0 {
+		modulesToExport = m.moduleNames()
+	}
+
+	genesis := make(map[string]json.RawMessage)
+	for _, name := range modulesToExport {
+		module, ok := m.Modules[name].(HasGenesis)
+		if !ok {
+			continue
+		}
+		state, err := module.ExportGenesis(ctx, cdc)
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", name, err)
+		}
+		genesis[name] = state
+	}
+	return genesis, nil
+}
+
+// moduleNames returns the names of the modules, sorted
+func (m *ModuleManager) moduleNames() []string {
+	names := make([]string, 0, len(m.Modules))
+	for name := range m.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,15 @@
+package params
+
+import (
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+)
+
+var (
+	// ErrUnknownModule is returned for the params of a module not registered
+	// with the keeper
+	ErrUnknownModule = sdkerrors.Register(ModuleName, 2, "unknown module")
+
+	// ErrInvalidParams is returned for params failing validation or of
+	// another type than the one registered by their module
+	ErrInvalidParams = sdkerrors.Register(ModuleName, 3, "invalid params")
+)
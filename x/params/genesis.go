@@ -0,0 +1,84 @@
+package params
+
+import (
+	"fmt"
+
+	paramsv1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/params/v1"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+// DefaultGenesis returns the default params of the registered modules.
+func (k *Keeper) DefaultGenesis() (*paramsv1.GenesisState, error) {
+	genesis := &paramsv1.GenesisState{}
+	for _, module := range k.Modules() {
+		packed, err := k.packParams(k.subspaces[module].defaults)
+		if err != nil {
+			return nil, err
+		}
+		genesis.Params = append(genesis.Params, &paramsv1.ModuleParams{Module: module, Params: packed})
+	}
+	return genesis, nil
+}
+
+// ValidateGenesis checks that the params of genesis are of registered
+// modules, at most once each, and pass their Validate method, if any.
+func (k *Keeper) ValidateGenesis(genesis *paramsv1.GenesisState) error {
+	seen := make(map[string]bool)
+	for _, moduleParams := range genesis.Params {
+		if seen[moduleParams.Module] {
+			return fmt.Errorf("duplicate params of module %s", moduleParams.Module)
+		}
+		seen[moduleParams.Module] = true
+
+		params, err := k.unpackParams(moduleParams.Module, moduleParams.Params)
+		if err != nil {
+			return err
+		}
+		if err := k.subspaces[moduleParams.Module].validateBasic(params); err != nil {
+			return fmt.Errorf("module %s: %w", moduleParams.Module, err)
+		}
+	}
+	return nil
+}
+
+// InitGenesis validates and stores the params of genesis.
+func (k *Keeper) InitGenesis(ctx sdktypes.Context, genesis *paramsv1.GenesisState) error {
+	for _, moduleParams := range genesis.Params {
+		params, err := k.unpackParams(moduleParams.Module, moduleParams.Params)
+		if err != nil {
+			return err
+		}
+		if err := k.SetParams(ctx, moduleParams.Module, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportGenesis returns the current params of the registered modules.
+func (k *Keeper) ExportGenesis(ctx sdktypes.Context) (*paramsv1.GenesisState, error) {
+	params, err := k.exportParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &paramsv1.GenesisState{Params: params}, nil
+}
+
+// exportParams returns the current params of the registered modules, sorted
+// by module name
+func (k *Keeper) exportParams(ctx sdktypes.Context) ([]*paramsv1.ModuleParams, error) {
+	modules := k.Modules()
+	exported := make([]*paramsv1.ModuleParams, 0, len(modules))
+	for _, module := range modules {
+		params, err := k.GetParams(ctx, module)
+		if err != nil {
+			return nil, err
+		}
+		packed, err := k.packParams(params)
+		if err != nil {
+			return nil, err
+		}
+		exported = append(exported, &paramsv1.ModuleParams{Module: module, Params: packed})
+	}
+	return exported, nil
+}
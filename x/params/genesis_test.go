@@ -0,0 +1,72 @@
+package params
+
+import (
+	"encoding/json"
+	"testing"
+
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+func TestGenesis(t *testing.T) {
+	keeper, subspace, ctx, cdc := setupKeeper(t)
+	mm := sdktypes.NewManager(NewAppModule(keeper))
+
+	genesis := mm.DefaultGenesis(cdc)
+	require.Contains(t, genesis, ModuleName)
+	require.NoError(t, mm.ValidateGenesis(cdc, genesis))
+
+	// the params of the modules are exported as set
+	params := authtypes.DefaultParams()
+	params.SigVerifyCostED25519 = 100
+	require.NoError(t, subspace.Set(ctx, &params))
+	exported, err := mm.ExportGenesis(ctx, cdc)
+	require.NoError(t, err)
+	assert.Contains(t, string(exported[ModuleName]), `"sig_verify_cost_ed25519":"100"`)
+
+	// and initialized from the genesis state
+	otherKeeper, otherSubspace, otherCtx, _ := setupKeeper(t)
+	require.NoError(t, sdktypes.NewManager(NewAppModule(otherKeeper)).InitGenesis(otherCtx, cdc, exported))
+	initialized, err := otherSubspace.Get(otherCtx)
+	require.NoError(t, err)
+	assert.Equal(t, &params, initialized)
+
+	_, err = mm.ExportGenesis(ctx, cdc, "unknown")
+	require.Error(t, err)
+}
+
+func TestValidateGenesis(t *testing.T) {
+	keeper, _, _, cdc := setupKeeper(t)
+	module := NewAppModule(keeper)
+
+	invalid := func(mutate func(map[string]any)) json.RawMessage {
+		var genesis map[string]any
+		require.NoError(t, json.Unmarshal(module.DefaultGenesis(cdc), &genesis))
+		mutate(genesis)
+		bz, err := json.Marshal(genesis)
+		require.NoError(t, err)
+		return bz
+	}
+
+	moduleParams := func(genesis map[string]any) map[string]any {
+		return genesis["params"].([]any)[0].(map[string]any)
+	}
+
+	err := module.ValidateGenesis(cdc, invalid(func(genesis map[string]any) {
+		moduleParams(genesis)["params"].(map[string]any)["tx_sig_limit"] = "0"
+	}))
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	err = module.ValidateGenesis(cdc, invalid(func(genesis map[string]any) {
+		moduleParams(genesis)["module"] = "unknown"
+	}))
+	require.ErrorIs(t, err, ErrUnknownModule)
+
+	err = module.ValidateGenesis(cdc, invalid(func(genesis map[string]any) {
+		genesis["params"] = append(genesis["params"].([]any), moduleParams(genesis))
+	}))
+	require.ErrorContains(t, err, "duplicate params of module tasks")
+}
@@ -0,0 +1,230 @@
+// Package params implements the params module, storing the runtime-tunable
+// params of the other modules, such as thresholds, timeouts and fee rates,
+// and updating them on behalf of a configurable authority.
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+// Params are the params of a module, a protobuf message. Params implementing
+// Validate are validated before they are stored.
+type Params interface {
+	proto.Message
+}
+
+// ValidateFn is a validation hook of the params of a module, run before they
+// are stored, e.g. to check them against the state of the module.
+type ValidateFn[P Params] func(ctx sdktypes.Context, params P) error
+
+// subspace is a module registered with the keeper
+type subspace struct {
+	defaults Params
+	hooks    []func(sdktypes.Context, Params) error
+}
+
+// newParams returns empty params of the type of the module
+func (s *subspace) newParams() Params {
+	return reflect.New(reflect.TypeOf(s.defaults).Elem()).Interface().(Params)
+}
+
+// validateBasic runs the Validate method of params, if any
+func (s *subspace) validateBasic(params Params) error {
+	if v, ok := params.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return errorsmod.Wrap(ErrInvalidParams, err.Error())
+		}
+	}
+	return nil
+}
+
+// validate runs the Validate method of params, if any, then the hooks of the module
+func (s *subspace) validate(ctx sdktypes.Context, params Params) error {
+	if err := s.validateBasic(params); err != nil {
+		return err
+	}
+	for _, hook := range s.hooks {
+		if err := hook(ctx, params); err != nil {
+			return errorsmod.Wrap(ErrInvalidParams, err.Error())
+		}
+	}
+	return nil
+}
+
+// Keeper stores the params of the modules registered through NewSubspace,
+// each under the name of its module.
+type Keeper struct {
+	cdc       codec.BinaryCodec
+	storeKey  storetypes.StoreKey
+	authority string
+
+	subspaces map[string]*subspace
+}
+
+// NewKeeper creates a keeper storing params in the store of storeKey, and
+// updating them through MsgUpdateParams dispatched on behalf of authority,
+// the name of the module allowed to update them, e.g. a governance module
+// dispatching it with its dispatcher, see baseapp.BaseApp.ModuleMsgDispatcher.
+func NewKeeper(cdc codec.BinaryCodec, storeKey storetypes.StoreKey, authority string) *Keeper {
+	if authority == "" {
+		panic("params authority cannot be empty")
+	}
+	return &Keeper{
+		cdc:       cdc,
+		storeKey:  storeKey,
+		authority: authority,
+		subspaces: make(map[string]*subspace),
+	}
+}
+
+// Authority returns the name of the module allowed to update params.
+func (k *Keeper) Authority() string {
+	return k.authority
+}
+
+// Modules returns the names of the modules registered, sorted.
+func (k *Keeper) Modules() []string {
+	modules := make([]string, 0, len(k.subspaces))
+	for module := range k.subspaces {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// GetParams returns the params of module, its default params if they were
+// never set.
+func (k *Keeper) GetParams(ctx sdktypes.Context, module string) (Params, error) {
+	s, err := k.subspace(module)
+	if err != nil {
+		return nil, err
+	}
+
+	bz := ctx.KVStore(k.storeKey).Get([]byte(module))
+	if bz == nil {
+		return proto.Clone(s.defaults), nil
+	}
+	params := s.newParams()
+	if err := k.cdc.Unmarshal(bz, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// SetParams validates and stores the params of module, which must be of the
+// type the module registered.
+func (k *Keeper) SetParams(ctx sdktypes.Context, module string, params Params) error {
+	s, err := k.subspace(module)
+	if err != nil {
+		return err
+	}
+	if reflect.TypeOf(params) != reflect.TypeOf(s.defaults) {
+		return errorsmod.Wrapf(ErrInvalidParams, "module %s expects %T, got %T", module, s.defaults, params)
+	}
+	if err := s.validate(ctx, params); err != nil {
+		return err
+	}
+
+	bz, err := k.cdc.Marshal(params)
+	if err != nil {
+		return err
+	}
+	ctx.KVStore(k.storeKey).Set([]byte(module), bz)
+
+	ctx.EventManager().EmitEvent(sdktypes.NewEvent(EventTypeUpdateParams,
+		sdktypes.NewAttribute(AttributeKeyModule, module),
+	))
+	return nil
+}
+
+// subspace returns the registered module
+func (k *Keeper) subspace(module string) (*subspace, error) {
+	s, ok := k.subspaces[module]
+	if !ok {
+		return nil, errorsmod.Wrap(ErrUnknownModule, module)
+	}
+	return s, nil
+}
+
+// packParams packs the params of a module into an Any
+func (k *Keeper) packParams(params Params) (*codectypes.Any, error) {
+	return codectypes.NewAnyWithValue(params)
+}
+
+// unpackParams unpacks the params of module from an Any, without validating
+// them
+func (k *Keeper) unpackParams(module string, packed *codectypes.Any) (Params, error) {
+	s, err := k.subspace(module)
+	if err != nil {
+		return nil, err
+	}
+	if packed == nil {
+		return nil, errorsmod.Wrapf(ErrInvalidParams, "missing params of module %s", module)
+	}
+	if expected := "/" + proto.MessageName(s.defaults); packed.TypeUrl != expected {
+		return nil, errorsmod.Wrapf(ErrInvalidParams, "module %s expects %s, got %s", module, expected, packed.TypeUrl)
+	}
+
+	params := s.newParams()
+	if err := k.cdc.Unmarshal(packed.Value, params); err != nil {
+		return nil, errorsmod.Wrap(ErrInvalidParams, err.Error())
+	}
+	return params, nil
+}
+
+// Subspace gives a module typed access to its params.
+type Subspace[P Params] struct {
+	keeper *Keeper
+	module string
+}
+
+// NewSubspace registers module with keeper, with params of the type of
+// defaults, validated by hooks in addition to their Validate method, if any.
+// Modules must be registered before the params module registers its
+// interfaces. It panics if the module is already registered.
+func NewSubspace[P Params](keeper *Keeper, module string, defaults P, hooks ...ValidateFn[P]) Subspace[P] {
+	if _, ok := keeper.subspaces[module]; ok {
+		panic(fmt.Sprintf("params of module %s already registered", module))
+	}
+
+	s := &subspace{defaults: defaults}
+	for _, hook := range hooks {
+		s.hooks = append(s.hooks, func(ctx sdktypes.Context, params Params) error {
+			return hook(ctx, params.(P))
+		})
+	}
+	keeper.subspaces[module] = s
+
+	return Subspace[P]{keeper: keeper, module: module}
+}
+
+// Module returns the name of the module.
+func (s Subspace[P]) Module() string {
+	return s.module
+}
+
+// Get returns the params of the module, its default params if they were
+// never set.
+func (s Subspace[P]) Get(ctx sdktypes.Context) (P, error) {
+	params, err := s.keeper.GetParams(ctx, s.module)
+	if err != nil {
+		var zero P
+		return zero, err
+	}
+	return params.(P), nil
+}
+
+// Set validates and stores the params of the module.
+func (s Subspace[P]) Set(ctx sdktypes.Context, params P) error {
+	return s.keeper.SetParams(ctx, s.module, params)
+}
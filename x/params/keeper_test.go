@@ -0,0 +1,104 @@
+package params
+
+import (
+	"errors"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPellNetwork/pellapp-sdk/testutil"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+const (
+	testAuthority = "gov"
+	testModule    = "tasks"
+)
+
+// maxSigLimit rejects params with a signature limit above the one in store
+func maxSigLimit(key storetypes.StoreKey) ValidateFn[*authtypes.Params] {
+	return func(ctx sdktypes.Context, params *authtypes.Params) error {
+		if limit := ctx.KVStore(key).Get([]byte("max_sig_limit")); limit != nil && params.TxSigLimit > uint64(limit[0]) {
+			return errors.New("signature limit too high")
+		}
+		return nil
+	}
+}
+
+func setupKeeper(t *testing.T) (*Keeper, Subspace[*authtypes.Params], sdktypes.Context, codec.Codec) {
+	t.Helper()
+	key := storetypes.NewKVStoreKey(StoreKey)
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_params"))
+
+	registry := codectypes.NewInterfaceRegistry()
+	cdc := codec.NewProtoCodec(registry)
+	keeper := NewKeeper(cdc, key, testAuthority)
+	defaults := authtypes.DefaultParams()
+	subspace := NewSubspace(keeper, testModule, &defaults, maxSigLimit(key))
+	NewAppModule(keeper).RegisterInterfaces(registry)
+
+	return keeper, subspace, ctx, cdc
+}
+
+func TestSubspaceGetSet(t *testing.T) {
+	_, subspace, ctx, _ := setupKeeper(t)
+
+	// the default params until set
+	params, err := subspace.Get(ctx)
+	require.NoError(t, err)
+	defaults := authtypes.DefaultParams()
+	assert.Equal(t, &defaults, params)
+
+	params.TxSigLimit = 3
+	require.NoError(t, subspace.Set(ctx, params))
+	stored, err := subspace.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), stored.TxSigLimit)
+
+	attrs, ok := ctx.EventManager().Events().GetAttributes(AttributeKeyModule)
+	require.True(t, ok)
+	assert.Equal(t, testModule, attrs[0].Value)
+}
+
+func TestSubspaceValidate(t *testing.T) {
+	keeper, subspace, ctx, _ := setupKeeper(t)
+
+	// the Validate method of the params
+	params := authtypes.DefaultParams()
+	params.TxSigLimit = 0
+	require.ErrorIs(t, subspace.Set(ctx, &params), ErrInvalidParams)
+
+	// the validation hooks of the module
+	ctx.KVStore(keeper.storeKey).Set([]byte("max_sig_limit"), []byte{5})
+	params.TxSigLimit = 6
+	err := subspace.Set(ctx, &params)
+	require.ErrorIs(t, err, ErrInvalidParams)
+	require.ErrorContains(t, err, "signature limit too high")
+
+	params.TxSigLimit = 5
+	require.NoError(t, subspace.Set(ctx, &params))
+}
+
+func TestKeeperParams(t *testing.T) {
+	keeper, _, ctx, _ := setupKeeper(t)
+
+	assert.Equal(t, testAuthority, keeper.Authority())
+	assert.Equal(t, []string{testModule}, keeper.Modules())
+
+	_, err := keeper.GetParams(ctx, "unknown")
+	require.ErrorIs(t, err, ErrUnknownModule)
+	require.ErrorIs(t, keeper.SetParams(ctx, "unknown", &authtypes.Params{}), ErrUnknownModule)
+
+	// params of another type than the one of the module
+	err = keeper.SetParams(ctx, testModule, &authtypes.BaseAccount{})
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	require.Panics(t, func() {
+		NewSubspace(keeper, testModule, &authtypes.Params{})
+	})
+}
@@ -0,0 +1,124 @@
+package params
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+
+	paramsv1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/params/v1"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the params module
+	ModuleName = "params"
+
+	// StoreKey is the default store key of the params module
+	StoreKey = ModuleName
+
+	// EventTypeUpdateParams is emitted when the params of a module are stored
+	EventTypeUpdateParams = "update_params"
+
+	// AttributeKeyModule is the name of the module whose params are stored
+	AttributeKeyModule = "module"
+)
+
+var (
+	_ sdktypes.BasicModule        = AppModule{}
+	_ sdktypes.HasGenesis         = AppModule{}
+	_ sdktypes.MsgResultExtractor = AppModule{}
+)
+
+// AppModule is the params module, serving the params of the modules
+// registered with its keeper.
+type AppModule struct {
+	keeper *Keeper
+}
+
+// NewAppModule creates the params module of keeper.
+func NewAppModule(keeper *Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// IsAppModule implements types.AppModule.
+func (AppModule) IsAppModule() {}
+
+// Name implements types.BasicModule.
+func (AppModule) Name() string {
+	return ModuleName
+}
+
+// RegisterServices registers the Msg service handling MsgUpdateParams.
+func (am AppModule) RegisterServices(cfg sdktypes.Configurator) {
+	paramsv1.RegisterMsgServer(cfg, NewMsgServer(am.keeper))
+}
+
+// RegisterInterfaces registers MsgUpdateParams and the params types of the
+// modules registered with the keeper.
+func (am AppModule) RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil), &paramsv1.MsgUpdateParams{})
+
+	registry.RegisterInterface("pellapp.params.v1.Params", (*Params)(nil))
+	for _, module := range am.keeper.Modules() {
+		registry.RegisterImplementations((*Params)(nil), am.keeper.subspaces[module].defaults)
+	}
+}
+
+// RegisterGRPCGatewayRoutes registers the gateway routes of the Query service.
+func (AppModule) RegisterGRPCGatewayRoutes(conn gogogrpc.ClientConn, mux *runtime.ServeMux) {
+	if err := paramsv1.RegisterQueryHandlerClient(context.Background(), mux, paramsv1.NewQueryClient(conn)); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterQueryServices registers the Query service.
+func (am AppModule) RegisterQueryServices(router gogogrpc.Server) {
+	paramsv1.RegisterQueryServer(router, NewQueryServer(am.keeper))
+}
+
+// RegisterResultMsgExtractors opts MsgUpdateParamsResponse out of result
+// extraction, it carries no data for operators to sign.
+func (AppModule) RegisterResultMsgExtractors(cfg sdktypes.Configurator) {
+	cfg.OptOutResultMsgExtractor(&paramsv1.MsgUpdateParamsResponse{})
+}
+
+// DefaultGenesis implements types.HasGenesis.
+func (am AppModule) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
+	genesis, err := am.keeper.DefaultGenesis()
+	if err != nil {
+		panic(err)
+	}
+	return cdc.MustMarshalJSON(genesis)
+}
+
+// ValidateGenesis implements types.HasGenesis.
+func (am AppModule) ValidateGenesis(cdc codec.JSONCodec, bz json.RawMessage) error {
+	var genesis paramsv1.GenesisState
+	if err := cdc.UnmarshalJSON(bz, &genesis); err != nil {
+		return err
+	}
+	return am.keeper.ValidateGenesis(&genesis)
+}
+
+// InitGenesis implements types.HasGenesis.
+func (am AppModule) InitGenesis(ctx sdktypes.Context, cdc codec.JSONCodec, bz json.RawMessage) error {
+	var genesis paramsv1.GenesisState
+	if err := cdc.UnmarshalJSON(bz, &genesis); err != nil {
+		return err
+	}
+	return am.keeper.InitGenesis(ctx, &genesis)
+}
+
+// ExportGenesis implements types.HasGenesis.
+func (am AppModule) ExportGenesis(ctx sdktypes.Context, cdc codec.JSONCodec) (json.RawMessage, error) {
+	genesis, err := am.keeper.ExportGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cdc.MarshalJSON(genesis)
+}
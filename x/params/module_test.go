@@ -0,0 +1,50 @@
+package params
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+	paramsv1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/params/v1"
+	"github.com/0xPellNetwork/pellapp-sdk/service"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+func TestAppModuleUpdateParams(t *testing.T) {
+	keeper, subspace, ctx, cdc := setupKeeper(t)
+	mm := sdktypes.NewManager(NewAppModule(keeper))
+
+	router := service.NewMsgRouter(cdc)
+	cfg := router.GetConfigurator()
+	mm.RegisterServices(cfg)
+	mm.RegisterResultMsgExtractors(cfg)
+	require.NoError(t, mm.ValidateResultExtractors(cfg))
+
+	params := authtypes.DefaultParams()
+	params.TxSigLimit = 2
+	packed, err := codectypes.NewAnyWithValue(&params)
+	require.NoError(t, err)
+	msg := &paramsv1.MsgUpdateParams{Authority: testAuthority, Module: testModule, Params: packed}
+
+	// routed from the data of a DVS request, which anyone can submit
+	data, err := router.EncodeMsgs(msg)
+	require.NoError(t, err)
+	_, err = router.InvokeByMsgData(ctx, data)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	// dispatched on behalf of another module
+	_, err = router.GetMsgDispatcher().ForAuthority("tasks").Dispatch(ctx, msg)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	// dispatched on behalf of the authority
+	_, err = router.GetMsgDispatcher().ForAuthority(testAuthority).Dispatch(ctx, msg)
+	require.NoError(t, err)
+
+	stored, err := subspace.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), stored.TxSigLimit)
+}
@@ -0,0 +1,43 @@
+package params
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+	paramsv1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/params/v1"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+var _ paramsv1.MsgServer = msgServer{}
+
+type msgServer struct {
+	keeper *Keeper
+}
+
+// NewMsgServer returns the params Msg service server of keeper.
+func NewMsgServer(keeper *Keeper) paramsv1.MsgServer {
+	return msgServer{keeper: keeper}
+}
+
+// UpdateParams implements paramsv1.MsgServer. The message is only accepted
+// when dispatched on behalf of the authority of the keeper, its authority
+// being checked by the dispatcher against the module dispatching it. It is
+// refused as the message of a DVS request, whose data anyone can submit.
+func (s msgServer) UpdateParams(goCtx context.Context, msg *paramsv1.MsgUpdateParams) (*paramsv1.MsgUpdateParamsResponse, error) {
+	ctx := sdktypes.UnwrapContext(goCtx)
+	if msg.Authority != s.keeper.authority || ctx.DispatchAuthority() != s.keeper.authority {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "expected to be dispatched by authority %s, got authority %s dispatched by %q",
+			s.keeper.authority, msg.Authority, ctx.DispatchAuthority())
+	}
+
+	params, err := s.keeper.unpackParams(msg.Module, msg.Params)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.keeper.SetParams(ctx, msg.Module, params); err != nil {
+		return nil, err
+	}
+	return &paramsv1.MsgUpdateParamsResponse{}, nil
+}
@@ -0,0 +1,50 @@
+package params
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkerrors "github.com/0xPellNetwork/pellapp-sdk/errors"
+	paramsv1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/params/v1"
+)
+
+func TestMsgServerUpdateParams(t *testing.T) {
+	keeper, subspace, ctx, _ := setupKeeper(t)
+	msgServer := NewMsgServer(keeper)
+
+	params := authtypes.DefaultParams()
+	params.MaxMemoCharacters = 512
+	packed, err := codectypes.NewAnyWithValue(&params)
+	require.NoError(t, err)
+
+	// only the authority updates params, when dispatched on its behalf
+	_, err = msgServer.UpdateParams(ctx, &paramsv1.MsgUpdateParams{Authority: testAuthority, Module: testModule, Params: packed})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	_, err = msgServer.UpdateParams(ctx.WithDispatchAuthority("other"), &paramsv1.MsgUpdateParams{Authority: testAuthority, Module: testModule, Params: packed})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	ctx = ctx.WithDispatchAuthority(testAuthority)
+	_, err = msgServer.UpdateParams(ctx, &paramsv1.MsgUpdateParams{Authority: "other", Module: testModule, Params: packed})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	_, err = msgServer.UpdateParams(ctx, &paramsv1.MsgUpdateParams{Authority: testAuthority, Module: "unknown", Params: packed})
+	require.ErrorIs(t, err, ErrUnknownModule)
+
+	// params of another type than the one of the module
+	wrongType, err := codectypes.NewAnyWithValue(&authtypes.BaseAccount{})
+	require.NoError(t, err)
+	_, err = msgServer.UpdateParams(ctx, &paramsv1.MsgUpdateParams{Authority: testAuthority, Module: testModule, Params: wrongType})
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = msgServer.UpdateParams(ctx, &paramsv1.MsgUpdateParams{Authority: testAuthority, Module: testModule})
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = msgServer.UpdateParams(ctx, &paramsv1.MsgUpdateParams{Authority: testAuthority, Module: testModule, Params: packed})
+	require.NoError(t, err)
+	stored, err := subspace.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(512), stored.MaxMemoCharacters)
+}
@@ -0,0 +1,51 @@
+package params
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paramsv1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/params/v1"
+	sdktypes "github.com/0xPellNetwork/pellapp-sdk/types"
+)
+
+var _ paramsv1.QueryServer = queryServer{}
+
+type queryServer struct {
+	keeper *Keeper
+}
+
+// NewQueryServer returns the params Query service server of keeper.
+func NewQueryServer(keeper *Keeper) paramsv1.QueryServer {
+	return queryServer{keeper: keeper}
+}
+
+// Params implements paramsv1.QueryServer.
+func (s queryServer) Params(ctx context.Context, req *paramsv1.QueryParamsRequest) (*paramsv1.QueryParamsResponse, error) {
+	if req.Module == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty module")
+	}
+
+	params, err := s.keeper.GetParams(sdktypes.UnwrapContext(ctx), req.Module)
+	if errors.Is(err, ErrUnknownModule) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	packed, err := s.keeper.packParams(params)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &paramsv1.QueryParamsResponse{Params: packed}, nil
+}
+
+// AllParams implements paramsv1.QueryServer.
+func (s queryServer) AllParams(ctx context.Context, _ *paramsv1.QueryAllParamsRequest) (*paramsv1.QueryAllParamsResponse, error) {
+	params, err := s.keeper.exportParams(sdktypes.UnwrapContext(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &paramsv1.QueryAllParamsResponse{Params: params}, nil
+}
@@ -0,0 +1,39 @@
+package params
+
+import (
+	"testing"
+
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paramsv1 "github.com/0xPellNetwork/pellapp-sdk/proto/pellapp/params/v1"
+)
+
+func TestQueryServer(t *testing.T) {
+	keeper, subspace, ctx, cdc := setupKeeper(t)
+	queryServer := NewQueryServer(keeper)
+
+	params := authtypes.DefaultParams()
+	params.TxSizeCostPerByte = 20
+	require.NoError(t, subspace.Set(ctx, &params))
+
+	res, err := queryServer.Params(ctx, &paramsv1.QueryParamsRequest{Module: testModule})
+	require.NoError(t, err)
+	var queried authtypes.Params
+	require.NoError(t, cdc.Unmarshal(res.Params.Value, &queried))
+	assert.Equal(t, params, queried)
+
+	_, err = queryServer.Params(ctx, &paramsv1.QueryParamsRequest{Module: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = queryServer.Params(ctx, &paramsv1.QueryParamsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	all, err := queryServer.AllParams(ctx, &paramsv1.QueryAllParamsRequest{})
+	require.NoError(t, err)
+	require.Len(t, all.Params, 1)
+	assert.Equal(t, testModule, all.Params[0].Module)
+	assert.Equal(t, res.Params, all.Params[0].Params)
+}